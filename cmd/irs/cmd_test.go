@@ -8,9 +8,12 @@ import (
 	"bytes"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/moov-io/irs/pkg/config"
+	"github.com/moov-io/irs/pkg/file"
+	"github.com/moov-io/irs/pkg/schema"
 	"github.com/spf13/cobra"
 )
 
@@ -81,6 +84,229 @@ func TestConvertUnknown(t *testing.T) {
 	deleteFile()
 }
 
+func TestFinalize(t *testing.T) {
+	_, err := executeCommand(rootCmd, "finalize", "output", "--input", testJsonFilePath, "--format", config.OutputIrsFormat)
+	if err != nil {
+		t.Error(err)
+	}
+	_, err = executeCommand(rootCmd, "validator", "--input", "output")
+	if err != nil {
+		t.Error(err)
+	}
+	deleteFile()
+
+	_, err = executeCommand(rootCmd, "finalize", "--input", testJsonFilePath, "--format", config.OutputJsonFormat)
+	if err == nil {
+		t.Error("requires output argument")
+	}
+	_, err = executeCommand(rootCmd, "finalize", "output", "--input", testJsonFilePath, "--format", "unknown")
+	if err == nil {
+		t.Error("don't support the format")
+	}
+	deleteFile()
+
+	_, err = executeCommand(rootCmd, "finalize", "output", "--input", testJsonFilePath, "--format", config.OutputIrsFormat, "--normalize")
+	if err != nil {
+		t.Error(err)
+	}
+	_, err = executeCommand(rootCmd, "validator", "--input", "output")
+	if err != nil {
+		t.Error(err)
+	}
+	deleteFile()
+}
+
+func TestCorrect(t *testing.T) {
+	buf, err := os.ReadFile(testJsonFilePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f, err := file.CreateFile(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.Payers()[0].PayeeRecords()[0].PaymentAmount7 = 900
+	amendedPath := filepath.Join(t.TempDir(), "amended.dat")
+	if err = os.WriteFile(amendedPath, f.Ascii(), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = executeCommand(rootCmd, "correct", "output", "--original", testJsonFilePath, "--amended", amendedPath, "--format", config.OutputIrsFormat)
+	if err != nil {
+		t.Error(err)
+	}
+	_, err = executeCommand(rootCmd, "validator", "--input", "output")
+	if err != nil {
+		t.Error(err)
+	}
+	deleteFile()
+
+	_, err = executeCommand(rootCmd, "correct", "--original", testJsonFilePath, "--amended", testJsonFilePath, "--format", config.OutputJsonFormat)
+	if err == nil {
+		t.Error("has no corrections")
+	}
+	_, err = executeCommand(rootCmd, "correct", "--original", testJsonFilePath, "--amended", "unknown", "--format", config.OutputJsonFormat)
+	if err == nil {
+		t.Error("invalid amended file")
+	}
+	_, err = executeCommand(rootCmd, "correct", "--original", testJsonFilePath, "--amended", amendedPath, "--format", "unknown")
+	if err == nil {
+		t.Error("don't support the format")
+	}
+}
+
+func TestDiff(t *testing.T) {
+	buf, err := os.ReadFile(testJsonFilePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f, err := file.CreateFile(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.Payers()[0].PayeeRecords()[0].PaymentAmount7 = 900
+	secondPath := filepath.Join(t.TempDir(), "second.dat")
+	if err = os.WriteFile(secondPath, f.Ascii(), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = executeCommand(rootCmd, "diff", testJsonFilePath, secondPath, "--format", config.OutputTextFormat)
+	if err != nil {
+		t.Error(err)
+	}
+	_, err = executeCommand(rootCmd, "diff", testJsonFilePath, secondPath, "--format", config.OutputJsonFormat)
+	if err != nil {
+		t.Error(err)
+	}
+	_, err = executeCommand(rootCmd, "diff", testJsonFilePath)
+	if err == nil {
+		t.Error("requires two files")
+	}
+	_, err = executeCommand(rootCmd, "diff", testJsonFilePath, "unknown")
+	if err == nil {
+		t.Error("invalid second file")
+	}
+	_, err = executeCommand(rootCmd, "diff", testJsonFilePath, secondPath, "--format", "unknown")
+	if err == nil {
+		t.Error("don't support the format")
+	}
+}
+
+func TestMergeAndSplit(t *testing.T) {
+	buf, err := os.ReadFile(testJsonFilePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f, err := file.CreateFile(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.Payers()[0].PayerRecord().TIN = "111223333"
+	secondPath := filepath.Join(t.TempDir(), "second.dat")
+	if err = os.WriteFile(secondPath, f.Ascii(), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = executeCommand(rootCmd, "merge", "output", testJsonFilePath, secondPath, "--format", config.OutputIrsFormat)
+	if err != nil {
+		t.Error(err)
+	}
+	_, err = executeCommand(rootCmd, "validator", "--input", "output")
+	if err != nil {
+		t.Error(err)
+	}
+
+	dir := t.TempDir()
+	_, err = executeCommand(rootCmd, "split", dir, "--input", "output", "--by", file.SplitByPayer, "--format", config.OutputJsonFormat)
+	if err != nil {
+		t.Error(err)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 {
+		t.Errorf("expected 2 files, got %d", len(entries))
+	}
+	_, err = executeCommand(rootCmd, "validator", "--input", filepath.Join(dir, "111223333.json"))
+	if err != nil {
+		t.Error(err)
+	}
+	_, err = executeCommand(rootCmd, "split", dir, "--input", "output", "--by", "unknown", "--format", config.OutputJsonFormat)
+	if err == nil {
+		t.Error("invalid split key")
+	}
+	_, err = executeCommand(rootCmd, "split", dir, "--input", "output", "--by", file.SplitByPayer, "--format", "unknown")
+	if err == nil {
+		t.Error("don't support the format")
+	}
+	deleteFile()
+
+	_, err = executeCommand(rootCmd, "merge", "output")
+	if err == nil {
+		t.Error("requires files")
+	}
+	_, err = executeCommand(rootCmd, "merge", "output", testJsonFilePath, "unknown", "--format", config.OutputJsonFormat)
+	if err == nil {
+		t.Error("invalid file")
+	}
+	_, err = executeCommand(rootCmd, "merge", "output", testJsonFilePath, secondPath, "--format", "unknown")
+	if err == nil {
+		t.Error("don't support the format")
+	}
+	deleteFile()
+}
+
+func TestImportCSV(t *testing.T) {
+	csvPath := filepath.Join("..", "..", "test", "testdata", "payees.csv")
+	mappingPath := filepath.Join("..", "..", "test", "testdata", "csvMapping.json")
+
+	_, err := executeCommand(rootCmd, "import", "csv", "output", "--input", csvPath, "--mapping", mappingPath, "--format", config.OutputIrsFormat)
+	if err != nil {
+		t.Error(err)
+	}
+	_, err = executeCommand(rootCmd, "validator", "--input", "output")
+	if err != nil {
+		t.Error(err)
+	}
+	deleteFile()
+
+	_, err = executeCommand(rootCmd, "import", "csv", "output", "--input", csvPath, "--mapping", "unknown", "--format", config.OutputJsonFormat)
+	if err == nil {
+		t.Error("invalid mapping file")
+	}
+	_, err = executeCommand(rootCmd, "import", "csv", "output", "--input", mappingPath, "--mapping", mappingPath, "--format", config.OutputJsonFormat)
+	if err == nil {
+		t.Error("invalid csv file")
+	}
+	_, err = executeCommand(rootCmd, "import", "csv", "output", "--input", csvPath, "--mapping", mappingPath, "--format", "unknown")
+	if err == nil {
+		t.Error("don't support the format")
+	}
+	deleteFile()
+}
+
+func TestExport(t *testing.T) {
+	_, err := executeCommand(rootCmd, "export", "output", "--input", testJsonFilePath, "--format", config.OutputCsvFormat)
+	if err != nil {
+		t.Error(err)
+	}
+	buf, err := os.ReadFile("output")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Contains(buf, []byte("payer_tin,")) {
+		t.Error("missing header line")
+	}
+	deleteFile()
+
+	_, err = executeCommand(rootCmd, "export", "output", "--input", testJsonFilePath, "--format", "unknown")
+	if err == nil {
+		t.Error("don't support the format")
+	}
+	deleteFile()
+}
+
 func TestPrintIrs(t *testing.T) {
 	_, err := executeCommand(rootCmd, "print", "--input", testJsonFilePath, "--format", config.OutputIrsFormat)
 	if err != nil {
@@ -109,6 +335,209 @@ func TestValidator(t *testing.T) {
 	}
 }
 
+func TestValidatorReport(t *testing.T) {
+	_, err := executeCommand(rootCmd, "validator", "--input", testJsonFilePath, "--report")
+	if err != nil {
+		t.Error(err)
+	}
+	invalidPath := filepath.Join("..", "..", "test", "testdata", "fileWithInvalidPayment.json")
+	_, err = executeCommand(rootCmd, "validator", "--input", invalidPath, "--report")
+	if err == nil {
+		t.Error("invalid file should fail")
+	}
+	_, err = executeCommand(rootCmd, "validator", "--input", testJsonFilePath, "--report=false")
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestValidatorProfile(t *testing.T) {
+	defer Validate.Flags().Set("profile", "")
+
+	_, err := executeCommand(rootCmd, "validator", "--input", testJsonFilePath, "--report=false", "--profile", "fire-test")
+	if err != nil {
+		t.Error(err)
+	}
+	_, err = executeCommand(rootCmd, "validator", "--input", testJsonFilePath, "--profile", "fire-production")
+	if err == nil {
+		t.Error("test file should fail the production profile")
+	}
+	_, err = executeCommand(rootCmd, "validator", "--input", testJsonFilePath, "--report", "--profile", "fire-production")
+	if err == nil {
+		t.Error("test file should fail the production profile")
+	}
+	_, err = executeCommand(rootCmd, "validator", "--input", testJsonFilePath, "--report=false", "--profile", "unknown")
+	if err == nil {
+		t.Error("unknown profile should fail")
+	}
+}
+
+func TestMask(t *testing.T) {
+	defer Print.Flags().Set("mask", "")
+	defer Convert.Flags().Set("mask", "")
+
+	_, err := executeCommand(rootCmd, "print", "--input", testJsonFilePath, "--format", config.OutputJsonFormat, "--mask")
+	if err != nil {
+		t.Error(err)
+	}
+	_, err = executeCommand(rootCmd, "print", "--input", testJsonFilePath, "--format", config.OutputIrsFormat, "--mask=names,addresses")
+	if err != nil {
+		t.Error(err)
+	}
+	_, err = executeCommand(rootCmd, "print", "--input", testJsonFilePath, "--mask=phones")
+	if err == nil {
+		t.Error("unknown kind of personal data should fail")
+	}
+
+	_, err = executeCommand(rootCmd, "convert", "output", "--input", testJsonFilePath, "--format", config.OutputJsonFormat, "--mask=all")
+	if err != nil {
+		t.Error(err)
+	}
+	buf, err := os.ReadFile("output")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(buf), "987654321") {
+		t.Error("converted file should have masked TINs")
+	}
+	deleteFile()
+}
+
+func TestDollars(t *testing.T) {
+	defer rootCmd.PersistentFlags().Set("dollars", "false")
+	dollarsFilePath := filepath.Join("..", "..", "test", "testdata", "oneTransactionFileDollars.json")
+
+	_, err := executeCommand(rootCmd, "validator", "--input", dollarsFilePath)
+	if err == nil {
+		t.Error("amounts in dollars should fail without the dollars flag")
+	}
+	_, err = executeCommand(rootCmd, "validator", "--input", dollarsFilePath, "--dollars")
+	if err != nil {
+		t.Error(err)
+	}
+
+	_, err = executeCommand(rootCmd, "convert", "output", "--input", dollarsFilePath, "--format", config.OutputJsonFormat, "--dollars")
+	if err != nil {
+		t.Error(err)
+	}
+	buf, err := os.ReadFile("output")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(buf), `"payment_amount_7": "7.00"`) {
+		t.Error("converted file should have amounts in dollars")
+	}
+	deleteFile()
+}
+
+func TestSchema(t *testing.T) {
+	defer Schema.Flags().Set("format", schema.FormatJSONSchema)
+
+	_, err := executeCommand(rootCmd, "schema")
+	if err != nil {
+		t.Error(err)
+	}
+	_, err = executeCommand(rootCmd, "schema", "--format", schema.FormatOpenAPI)
+	if err != nil {
+		t.Error(err)
+	}
+	_, err = executeCommand(rootCmd, "schema", "--format", "xml")
+	if err == nil {
+		t.Error("xml should be unsupported")
+	}
+}
+
+func TestIris(t *testing.T) {
+	defer deleteFile()
+	defer Convert.Flags().Set("format", "json")
+	defer Print.Flags().Set("format", "json")
+
+	irisPath := filepath.Join("..", "..", "test", "testdata", "oneTransactionFileIris.json")
+	_, err := executeCommand(rootCmd, "convert", "output", "--input", irisPath, "--format", "iris")
+	if err != nil {
+		t.Error(err)
+	}
+	_, err = executeCommand(rootCmd, "print", "--input", irisPath, "--format", "iris")
+	if err != nil {
+		t.Error(err)
+	}
+	// iris transmissions are read like irs files
+	_, err = executeCommand(rootCmd, "print", "--input", "output", "--format", "irs")
+	if err != nil {
+		t.Error(err)
+	}
+	_, err = executeCommand(rootCmd, "print", "--input", testJsonFilePath, "--format", "iris")
+	if err == nil {
+		t.Error("amount code 7 of 1099-MISC should be unsupported")
+	}
+}
+
+func TestInspect(t *testing.T) {
+	defer Inspect.Flags().Set("format", "text")
+	defer Inspect.Flags().Set("sequence", "0")
+	defer Inspect.Flags().Set("position", "0")
+	defer Inspect.Flags().Set("type", "")
+
+	asciiPath := filepath.Join("..", "..", "test", "testdata", "oneTransactionFile.ascii")
+	_, err := executeCommand(rootCmd, "inspect", "--input", asciiPath)
+	if err != nil {
+		t.Error(err)
+	}
+	_, err = executeCommand(rootCmd, "inspect", "--input", asciiPath, "--type", "B", "--format", "json")
+	if err != nil {
+		t.Error(err)
+	}
+	_, err = executeCommand(rootCmd, "inspect", "--input", asciiPath, "--sequence", "3")
+	if err != nil {
+		t.Error(err)
+	}
+	_, err = executeCommand(rootCmd, "inspect", "--input", asciiPath, "--position", "3")
+	if err != nil {
+		t.Error(err)
+	}
+	_, err = executeCommand(rootCmd, "inspect", "--input", asciiPath, "--format", "xml")
+	if err == nil {
+		t.Error("xml should be unsupported")
+	}
+	_, err = executeCommand(rootCmd, "inspect", "--input", testJsonFilePath, "--format", "text")
+	if err == nil {
+		t.Error("json file should be unsupported")
+	}
+}
+
+func TestInspectSequenceNumbers(t *testing.T) {
+	defer Inspect.Flags().Set("sequence", "0")
+
+	buf, err := os.ReadFile(filepath.Join("..", "..", "test", "testdata", "oneTransactionFile.ascii"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the records at positions 3 and 4 are swapped, so their sequence numbers are out of order
+	third, fourth := 2*config.RecordLength, 3*config.RecordLength
+	data := string(buf[:third]) + string(buf[fourth:fourth+config.RecordLength]) + string(buf[third:fourth]) + string(buf[fourth+config.RecordLength:])
+
+	inspections := filterInspections(file.Inspect([]byte(data)), 3, 0, "")
+	if len(inspections) != 1 || inspections[0].Position != 4 || inspections[0].SequenceNumber != 3 {
+		t.Errorf("sequence number 3 should select the record at position 4")
+	}
+	inspections = filterInspections(file.Inspect([]byte(data)), 0, 3, "")
+	if len(inspections) != 1 || inspections[0].Position != 3 || inspections[0].SequenceNumber != 4 {
+		t.Errorf("position 3 should select the record with sequence number 4")
+	}
+	if len(filterInspections(file.Inspect([]byte(data)), 3, 3, "")) != 0 {
+		t.Errorf("sequence number 3 isn't at position 3")
+	}
+
+	asciiPath := filepath.Join(t.TempDir(), "swapped.dat")
+	if err = os.WriteFile(asciiPath, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+	_, err = executeCommand(rootCmd, "inspect", "--input", asciiPath, "--sequence", "3")
+	if err != nil {
+		t.Error(err)
+	}
+}
+
 func TestUnknown(t *testing.T) {
 	_, err := executeCommand(rootCmd, "unknown")
 	if err == nil {
@@ -122,3 +551,55 @@ func TestWeb(t *testing.T) {
 		t.Error(err)
 	}
 }
+
+func TestForm1042S(t *testing.T) {
+	defer deleteFile()
+	defer Convert.Flags().Set("format", "json")
+	defer Print.Flags().Set("format", "json")
+	defer Finalize.Flags().Set("format", "json")
+
+	jsonPath := filepath.Join("..", "..", "test", "testdata", "form1042s.json")
+	asciiPath := filepath.Join("..", "..", "test", "testdata", "form1042s.ascii")
+
+	_, err := executeCommand(rootCmd, "validator", "--input", jsonPath)
+	if err != nil {
+		t.Error(err)
+	}
+	_, err = executeCommand(rootCmd, "validator", "--input", asciiPath)
+	if err != nil {
+		t.Error(err)
+	}
+	_, err = executeCommand(rootCmd, "print", "--input", asciiPath, "--format", config.OutputJsonFormat)
+	if err != nil {
+		t.Error(err)
+	}
+	_, err = executeCommand(rootCmd, "print", "--input", jsonPath, "--format", config.OutputIrisFormat)
+	if err == nil {
+		t.Error("iris should be unsupported for form 1042-S files")
+	}
+
+	_, err = executeCommand(rootCmd, "convert", "output", "--input", jsonPath, "--format", config.OutputIrsFormat)
+	if err != nil {
+		t.Error(err)
+	}
+	converted, err := os.ReadFile("output")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected, err := os.ReadFile(asciiPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(converted, expected) {
+		t.Error("converted file should be the form 1042-S ascii file")
+	}
+
+	_, err = executeCommand(rootCmd, "finalize", "output", "--input", asciiPath, "--format", config.OutputJsonFormat)
+	if err != nil {
+		t.Error(err)
+	}
+	_, err = executeCommand(rootCmd, "validator", "--input", "output")
+	if err != nil {
+		t.Error(err)
+	}
+}
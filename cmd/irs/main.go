@@ -11,18 +11,23 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/spf13/cobra"
 
 	"github.com/moov-io/base/log"
 	"github.com/moov-io/irs/pkg/config"
 	"github.com/moov-io/irs/pkg/file"
+	"github.com/moov-io/irs/pkg/records1042s"
+	"github.com/moov-io/irs/pkg/schema"
 	"github.com/moov-io/irs/pkg/service"
+	"github.com/moov-io/irs/pkg/utils"
 )
 
 var (
-	inputFile string
-	rawData   []byte
+	inputFile     string
+	rawData       []byte
+	dollarAmounts bool
 )
 
 var WebCmd = &cobra.Command{
@@ -58,18 +63,161 @@ var Validate = &cobra.Command{
 	Short: "Validate irs file",
 	Long:  "Validate an incoming irs file",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		f, err := file.CreateFile(rawData)
+		report, err := cmd.Flags().GetBool("report")
 		if err != nil {
 			return err
 		}
-		return f.Validate()
+		name, err := cmd.Flags().GetString("profile")
+		if err != nil {
+			return err
+		}
+
+		if records1042s.IsFile(rawData) {
+			if report || len(name) > 0 {
+				return errors.New("report and profile are not supported for form 1042-S files")
+			}
+			f, err := records1042s.CreateFile(rawData)
+			if err != nil {
+				return err
+			}
+			return f.Validate()
+		}
+
+		f, err := createFile(rawData)
+		if err != nil {
+			return err
+		}
+		if !report && len(name) == 0 {
+			return f.Validate()
+		}
+
+		result := f.ValidateAll()
+		if len(name) > 0 {
+			profile, err := file.LookupProfile(name)
+			if err != nil {
+				return err
+			}
+			result = f.ValidateProfile(profile)
+		}
+		if !report {
+			return result.Err()
+		}
+		buf, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(buf))
+		if !result.Valid() {
+			return errors.New("invalid file")
+		}
+		return nil
 	},
 }
 
 var Print = &cobra.Command{
 	Use:   "print",
 	Short: "Print irs file",
-	Long:  "Print an incoming irs file with special format (options: irs, json)",
+	Long:  "Print an incoming irs file with special format (options: irs, json, iris)",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, err := cmd.Flags().GetString("format")
+		if err != nil {
+			return err
+		}
+		if format != config.OutputJsonFormat && format != config.OutputIrsFormat && format != config.OutputIrisFormat {
+			return errors.New("format not supported")
+		}
+
+		if records1042s.IsFile(rawData) {
+			output, err := convert1042SFile(cmd, rawData, format)
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(output))
+			return nil
+		}
+
+		f, err := createFile(rawData)
+		if err != nil {
+			return err
+		}
+		f, err = maskFile(cmd, f)
+		if err != nil {
+			return err
+		}
+
+		output, err := formatFile(f, format)
+		if err != nil {
+			return err
+		}
+
+		fmt.Println(string(output))
+		return nil
+	},
+}
+
+var Convert = &cobra.Command{
+	Use:   "convert [output]",
+	Short: "Convert irs file format",
+	Long:  "Convert an incoming irs file into another format (options: irs, json, iris)",
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) < 1 {
+			return errors.New("requires output argument")
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, err := cmd.Flags().GetString("format")
+		if err != nil {
+			return err
+		}
+		if format != config.OutputJsonFormat && format != config.OutputIrsFormat && format != config.OutputIrisFormat {
+			return errors.New("format not supported")
+		}
+
+		if records1042s.IsFile(rawData) {
+			output, err := convert1042SFile(cmd, rawData, format)
+			if err != nil {
+				return err
+			}
+			return os.WriteFile(args[0], output, 0644)
+		}
+
+		f, err := createFile(rawData)
+		if err != nil {
+			return err
+		}
+		file.FillNameControls(f)
+		f, err = maskFile(cmd, f)
+		if err != nil {
+			return err
+		}
+
+		output, err := formatFile(f, format)
+		if err != nil {
+			return err
+		}
+
+		wFile, err := os.Create(args[0])
+		if err != nil {
+			return err
+		}
+		_, err = wFile.Write(output)
+		wFile.Close()
+
+		return err
+	},
+}
+
+var Finalize = &cobra.Command{
+	Use:   "finalize [output]",
+	Short: "Finalize irs file",
+	Long:  "Recompute record counts, control totals and record sequence numbers of an incoming irs file (options: irs, json)",
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) < 1 {
+			return errors.New("requires output argument")
+		}
+		return nil
+	},
 	RunE: func(cmd *cobra.Command, args []string) error {
 		format, err := cmd.Flags().GetString("format")
 		if err != nil {
@@ -79,37 +227,188 @@ var Print = &cobra.Command{
 			return errors.New("format not supported")
 		}
 
-		f, err := file.CreateFile(rawData)
+		normalize, err := cmd.Flags().GetBool("normalize")
 		if err != nil {
 			return err
 		}
 
-		output := f.Ascii()
-		if format == config.OutputJsonFormat {
-			buf, err := json.Marshal(f)
+		if records1042s.IsFile(rawData) {
+			f, err := records1042s.CreateFile(rawData)
 			if err != nil {
 				return err
 			}
-			var pretty bytes.Buffer
-			err = json.Indent(&pretty, buf, "", "  ")
+			if err = f.Finalize(); err != nil {
+				return err
+			}
+			output, err := format1042SFile(f, format)
 			if err != nil {
 				return err
 			}
-			output = pretty.Bytes()
+			return os.WriteFile(args[0], output, 0644)
 		}
 
-		fmt.Println(string(output))
+		f, err := createFile(rawData)
+		if err != nil {
+			return err
+		}
+		if normalize {
+			if err = file.NormalizeAddresses(f); err != nil {
+				return err
+			}
+		}
+		if err = f.Finalize(); err != nil {
+			return err
+		}
+
+		output, err := formatFile(f, format)
+		if err != nil {
+			return err
+		}
+
+		return os.WriteFile(args[0], output, 0644)
+	},
+}
+
+var Correct = &cobra.Command{
+	Use:   "correct [output]",
+	Short: "Create correction file",
+	Long:  "Create a correction file from an original and an amended irs file (options: irs, json)",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, err := cmd.Flags().GetString("format")
+		if err != nil {
+			return err
+		}
+		if format != config.OutputJsonFormat && format != config.OutputIrsFormat {
+			return errors.New("format not supported")
+		}
+
+		readFlagFile := func(name string) (file.File, error) {
+			path, err := cmd.Flags().GetString(name)
+			if err != nil {
+				return nil, err
+			}
+			return readFile(path)
+		}
+		original, err := readFlagFile("original")
+		if err != nil {
+			return err
+		}
+		amended, err := readFlagFile("amended")
+		if err != nil {
+			return err
+		}
+
+		f, err := file.Correct(original, amended)
+		if err != nil {
+			return err
+		}
+
+		output, err := formatFile(f, format)
+		if err != nil {
+			return err
+		}
+
+		if len(args) == 0 {
+			fmt.Println(string(output))
+			return nil
+		}
+		return os.WriteFile(args[0], output, 0644)
+	},
+}
+
+var Diff = &cobra.Command{
+	Use:   "diff [first] [second]",
+	Short: "Compare irs files",
+	Long:  "Report added, removed and changed records of two irs files (options: text, json)",
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) < 2 {
+			return errors.New("requires two file arguments")
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, err := cmd.Flags().GetString("format")
+		if err != nil {
+			return err
+		}
+		if format != config.OutputJsonFormat && format != config.OutputTextFormat {
+			return errors.New("format not supported")
+		}
+
+		first, err := readFile(args[0])
+		if err != nil {
+			return err
+		}
+		second, err := readFile(args[1])
+		if err != nil {
+			return err
+		}
+
+		report, err := file.Diff(first, second)
+		if err != nil {
+			return err
+		}
+
+		if format == config.OutputJsonFormat {
+			buf, err := json.MarshalIndent(report, "", "  ")
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(buf))
+			return nil
+		}
+		fmt.Println(report.String())
 		return nil
 	},
 }
 
-var Convert = &cobra.Command{
-	Use:   "convert [output]",
-	Short: "Convert irs file format",
-	Long:  "Convert an incoming irs file into another format (options: irs, json)",
+var Merge = &cobra.Command{
+	Use:   "merge [output] [file]...",
+	Short: "Merge irs files",
+	Long:  "Merge payers of irs files into one transmission with the transmitter of the first file (options: irs, json)",
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) < 2 {
+			return errors.New("requires output and file arguments")
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, err := cmd.Flags().GetString("format")
+		if err != nil {
+			return err
+		}
+		if format != config.OutputJsonFormat && format != config.OutputIrsFormat {
+			return errors.New("format not supported")
+		}
+
+		files := make([]file.File, 0, len(args)-1)
+		for _, path := range args[1:] {
+			f, err := readFile(path)
+			if err != nil {
+				return err
+			}
+			files = append(files, f)
+		}
+
+		f, err := file.Merge(files...)
+		if err != nil {
+			return err
+		}
+		output, err := formatFile(f, format)
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(args[0], output, 0644)
+	},
+}
+
+var Split = &cobra.Command{
+	Use:   "split [output directory]",
+	Short: "Split irs file",
+	Long:  "Split payers of an incoming irs file into transmissions by payer TIN or type of return (options: irs, json)",
 	Args: func(cmd *cobra.Command, args []string) error {
 		if len(args) < 1 {
-			return errors.New("requires output argument")
+			return errors.New("requires output directory argument")
 		}
 		return nil
 	},
@@ -121,35 +420,303 @@ var Convert = &cobra.Command{
 		if format != config.OutputJsonFormat && format != config.OutputIrsFormat {
 			return errors.New("format not supported")
 		}
+		by, err := cmd.Flags().GetString("by")
+		if err != nil {
+			return err
+		}
 
-		f, err := file.CreateFile(rawData)
+		f, err := createFile(rawData)
+		if err != nil {
+			return err
+		}
+		files, err := file.Split(f, by)
 		if err != nil {
 			return err
 		}
 
-		output := f.Ascii()
+		if err = os.MkdirAll(args[0], 0755); err != nil {
+			return err
+		}
+		extension := ".dat"
 		if format == config.OutputJsonFormat {
-			buf, err := json.Marshal(f)
+			extension = ".json"
+		}
+		for _, split := range files {
+			payer := split.Payers()[0].PayerRecord()
+			name := payer.TIN
+			if by == file.SplitByTypeOfReturn {
+				name = payer.TypeOfReturn
+			}
+			output, err := formatFile(split, format)
 			if err != nil {
 				return err
 			}
-			var pretty bytes.Buffer
-			err = json.Indent(&pretty, buf, "", "  ")
-			if err != nil {
+			if err = os.WriteFile(filepath.Join(args[0], name+extension), output, 0644); err != nil {
 				return err
 			}
-			output = pretty.Bytes()
 		}
+		return nil
+	},
+}
 
-		wFile, err := os.Create(args[0])
+var Export = &cobra.Command{
+	Use:   "export [output]",
+	Short: "Export payees of irs file",
+	Long:  "Export one row per payee of an incoming irs file with box names of payment amounts (options: csv)",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, err := cmd.Flags().GetString("format")
 		if err != nil {
 			return err
 		}
-		_, err = wFile.Write(output)
-		wFile.Close()
+		if format != config.OutputCsvFormat {
+			return errors.New("format not supported")
+		}
 
-		return err
+		f, err := createFile(rawData)
+		if err != nil {
+			return err
+		}
+
+		if len(args) == 0 {
+			return file.ExportCSV(os.Stdout, f)
+		}
+		output, err := os.Create(args[0])
+		if err != nil {
+			return err
+		}
+		defer output.Close()
+		return file.ExportCSV(output, f)
+	},
+}
+
+var Import = &cobra.Command{
+	Use:   "import",
+	Short: "Import irs file",
+	Long:  "Create irs file from other data formats",
+}
+
+var ImportCSV = &cobra.Command{
+	Use:   "csv [output]",
+	Short: "Import csv file",
+	Long:  "Create irs file from an incoming csv file with a mapping file (options: irs, json)",
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) < 1 {
+			return errors.New("requires output argument")
+		}
+		return nil
 	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, err := cmd.Flags().GetString("format")
+		if err != nil {
+			return err
+		}
+		if format != config.OutputJsonFormat && format != config.OutputIrsFormat {
+			return errors.New("format not supported")
+		}
+		path, err := cmd.Flags().GetString("mapping")
+		if err != nil {
+			return err
+		}
+		buf, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		mapping := &file.CSVMapping{}
+		if err = json.Unmarshal(buf, mapping); err != nil {
+			return err
+		}
+
+		f, err := file.ImportCSV(bytes.NewReader(rawData), mapping)
+		if err != nil {
+			return err
+		}
+		output, err := formatFile(f, format)
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(args[0], output, 0644)
+	},
+}
+
+var Schema = &cobra.Command{
+	Use:   "schema",
+	Short: "Print schema of json irs files",
+	Long:  "Print schema of json irs files generated from the record layouts (options: json-schema, openapi)",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, err := cmd.Flags().GetString("format")
+		if err != nil {
+			return err
+		}
+		year, err := cmd.Flags().GetInt("year")
+		if err != nil {
+			return err
+		}
+
+		generated, err := schema.Generate(format, year)
+		if err != nil {
+			return err
+		}
+		buf, err := json.MarshalIndent(generated, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(buf))
+		return nil
+	},
+}
+
+var Inspect = &cobra.Command{
+	Use:   "inspect",
+	Short: "Inspect fields of fire ascii irs file",
+	Long:  "Print every field of the records of a fire ascii irs file with its positions, raw and parsed value (options: text, json)",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, err := cmd.Flags().GetString("format")
+		if err != nil {
+			return err
+		}
+		if format != config.OutputJsonFormat && format != config.OutputTextFormat {
+			return errors.New("format not supported")
+		}
+		sequence, err := cmd.Flags().GetInt("sequence")
+		if err != nil {
+			return err
+		}
+		position, err := cmd.Flags().GetInt("position")
+		if err != nil {
+			return err
+		}
+		recordType, err := cmd.Flags().GetString("type")
+		if err != nil {
+			return err
+		}
+		if json.Valid(rawData) {
+			return errors.New("inspect requires fire ascii file")
+		}
+
+		inspections := filterInspections(file.Inspect(rawData), sequence, position, recordType)
+
+		if format == config.OutputJsonFormat {
+			buf, err := json.MarshalIndent(inspections, "", "  ")
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(buf))
+		} else {
+			for _, inspection := range inspections {
+				fmt.Println(inspection.String())
+			}
+		}
+
+		for _, inspection := range inspections {
+			if !inspection.Valid() {
+				return errors.New("invalid records")
+			}
+		}
+		return nil
+	},
+}
+
+// filterInspections returns the inspected records with the record sequence number, the position
+// in the file and the record type, zero values match every record
+func filterInspections(all []*file.RecordInspection, sequence, position int, recordType string) []*file.RecordInspection {
+	inspections := make([]*file.RecordInspection, 0)
+	for _, inspection := range all {
+		if sequence > 0 && inspection.SequenceNumber != sequence {
+			continue
+		}
+		if position > 0 && inspection.Position != position {
+			continue
+		}
+		if len(recordType) > 0 && !strings.EqualFold(inspection.RecordType, recordType) {
+			continue
+		}
+		inspections = append(inspections, inspection)
+	}
+	return inspections
+}
+
+// formatFile returns contents of the file with the output format, json amounts are dollars with the dollars flag
+func formatFile(f file.File, format string) ([]byte, error) {
+	if format == config.OutputIrisFormat {
+		return f.Iris()
+	}
+	if format != config.OutputJsonFormat {
+		return f.Ascii(), nil
+	}
+	if !dollarAmounts {
+		return json.MarshalIndent(f, "", "  ")
+	}
+	buf, err := file.MarshalDollarJSON(f)
+	if err != nil {
+		return nil, err
+	}
+	var pretty bytes.Buffer
+	if err = json.Indent(&pretty, buf, "", "  "); err != nil {
+		return nil, err
+	}
+	return pretty.Bytes(), nil
+}
+
+// format1042SFile returns contents of the Form 1042-S file with the output format
+func format1042SFile(f *records1042s.File, format string) ([]byte, error) {
+	switch format {
+	case config.OutputIrisFormat:
+		return nil, fmt.Errorf("form 1042-S %w", utils.ErrUnsupportedIris)
+	case config.OutputJsonFormat:
+		return json.MarshalIndent(f, "", "  ")
+	}
+	return f.Ascii(), nil
+}
+
+// convert1042SFile parses Form 1042-S file of irs or json format and returns its contents with the output format,
+// amounts of Form 1042-S files are whole dollars and personal data can't be masked
+func convert1042SFile(cmd *cobra.Command, buf []byte, format string) ([]byte, error) {
+	if dollarAmounts {
+		return nil, errors.New("dollars flag is not supported for form 1042-S files")
+	}
+	mask, err := cmd.Flags().GetString("mask")
+	if err != nil {
+		return nil, err
+	}
+	if len(mask) > 0 {
+		return nil, errors.New("mask flag is not supported for form 1042-S files")
+	}
+
+	f, err := records1042s.CreateFile(buf)
+	if err != nil {
+		return nil, err
+	}
+	return format1042SFile(f, format)
+}
+
+// createFile parses irs file of irs or json format, json amounts are dollars with the dollars flag
+func createFile(buf []byte) (file.File, error) {
+	if dollarAmounts {
+		return file.CreateFileFromDollars(buf)
+	}
+	return file.CreateFile(buf)
+}
+
+// readFile reads irs file of irs, json or iris format
+func readFile(path string) (file.File, error) {
+	buf, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return createFile(buf)
+}
+
+// maskFile returns the file masked with the kinds of personal data of the mask flag, if the flag is set
+func maskFile(cmd *cobra.Command, f file.File) (file.File, error) {
+	value, err := cmd.Flags().GetString("mask")
+	if err != nil || len(value) == 0 {
+		return f, err
+	}
+	options, err := file.ParseMaskOptions(value)
+	if err != nil {
+		return nil, err
+	}
+	return file.Mask(f, options)
 }
 
 var rootCmd = &cobra.Command{
@@ -157,7 +724,7 @@ var rootCmd = &cobra.Command{
 	Short: "",
 	Long:  "",
 	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
-		isWeb := false
+		skipInput := false
 		cmdNames := make([]string, 0)
 		getName := func(c *cobra.Command) {}
 		getName = func(c *cobra.Command) {
@@ -165,14 +732,14 @@ var rootCmd = &cobra.Command{
 				return
 			}
 			cmdNames = append([]string{c.Name()}, cmdNames...)
-			if c.Name() == "web" {
-				isWeb = true
+			if c.Name() == "web" || c.Name() == "correct" || c.Name() == "diff" || c.Name() == "merge" || c.Name() == "schema" {
+				skipInput = true
 			}
 			getName(c.Parent())
 		}
 		getName(cmd)
 
-		if !isWeb {
+		if !skipInput {
 			if inputFile == "" {
 				path, err := os.Getwd()
 				if err != nil {
@@ -197,15 +764,53 @@ var rootCmd = &cobra.Command{
 func initRootCmd() {
 	WebCmd.Flags().BoolP("test", "t", false, "test server")
 	Convert.Flags().String("format", "json", "format of irs file(required)")
+	Convert.Flags().String("mask", "", "mask TINs and the kinds of personal data (tins, names, accounts, addresses, all)")
+	Convert.Flags().Lookup("mask").NoOptDefVal = file.MaskTINs
 	Convert.MarkFlagRequired("format")
 	Print.Flags().String("format", "json", "print format")
+	Print.Flags().String("mask", "", "mask TINs and the kinds of personal data (tins, names, accounts, addresses, all)")
+	Print.Flags().Lookup("mask").NoOptDefVal = file.MaskTINs
+	Finalize.Flags().String("format", "json", "format of irs file")
+	Finalize.Flags().Bool("normalize", false, "normalize payer and payee addresses")
+	Correct.Flags().String("original", "", "original irs file(required)")
+	Correct.Flags().String("amended", "", "amended irs file(required)")
+	Correct.Flags().String("format", "json", "format of correction file")
+	Correct.MarkFlagRequired("original")
+	Correct.MarkFlagRequired("amended")
+	Diff.Flags().String("format", "text", "format of differences (text, json)")
+	Merge.Flags().String("format", "json", "format of merged file")
+	Split.Flags().String("format", "json", "format of split files")
+	Split.Flags().String("by", file.SplitByPayer, "split by payer TIN or type of return (payer, type)")
+	Export.Flags().String("format", config.OutputCsvFormat, "format of exported file")
+	ImportCSV.Flags().String("mapping", "", "csv mapping file(required)")
+	ImportCSV.Flags().String("format", "json", "format of irs file")
+	ImportCSV.MarkFlagRequired("mapping")
+	Import.AddCommand(ImportCSV)
+	Validate.Flags().Bool("report", false, "print all validation errors as json report")
+	Validate.Flags().String("profile", "", "validation profile of the report and exit status (default, fire-production, fire-test, lenient-import), other commands keep the default checks")
+	Schema.Flags().String("format", schema.FormatJSONSchema, "format of schema (json-schema, openapi)")
+	Schema.Flags().Int("year", config.DefaultTaxYear, "tax year of the record layouts")
+	Inspect.Flags().String("format", "text", "format of inspected records (text, json)")
+	Inspect.Flags().Int("sequence", 0, "inspect only records with the record sequence number")
+	Inspect.Flags().Int("position", 0, "inspect only the record at the position in the file")
+	Inspect.Flags().String("type", "", "inspect only records of the type (T, A, B, C, K, F)")
 
 	rootCmd.SilenceUsage = true
 	rootCmd.PersistentFlags().StringVar(&inputFile, "input", "", "input file (default is $PWD/irs.json)")
+	rootCmd.PersistentFlags().BoolVar(&dollarAmounts, "dollars", false, "read and write json amounts as decimal dollars like \"1234.56\" instead of cents")
 	rootCmd.AddCommand(WebCmd)
 	rootCmd.AddCommand(Convert)
 	rootCmd.AddCommand(Print)
 	rootCmd.AddCommand(Validate)
+	rootCmd.AddCommand(Finalize)
+	rootCmd.AddCommand(Correct)
+	rootCmd.AddCommand(Diff)
+	rootCmd.AddCommand(Merge)
+	rootCmd.AddCommand(Split)
+	rootCmd.AddCommand(Import)
+	rootCmd.AddCommand(Export)
+	rootCmd.AddCommand(Schema)
+	rootCmd.AddCommand(Inspect)
 }
 
 func main() {
@@ -35,7 +35,7 @@ type ConvertOpts struct {
 
 /*
 Convert Convert irs file
-Convert from original irs file to new irs file
+Convert from original irs file to new irs file. Form 1042-S files of Publication 1187 are detected automatically and converted to json or ascii.
   - @param ctx _context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
   - @param optional nil or *ConvertOpts - Optional Parameters:
   - @param "Format" (optional.String) -  print irs file type
@@ -256,7 +256,7 @@ type PrintOpts struct {
 
 /*
 Print Print irs file with specific format
-Print irs file with requested file format.
+Print irs file with requested file format. Form 1042-S files of Publication 1187 are detected automatically and printed as json or ascii.
   - @param ctx _context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
   - @param optional nil or *PrintOpts - Optional Parameters:
   - @param "Format" (optional.String) -  print irs file type
@@ -377,7 +377,7 @@ type ValidatorOpts struct {
 
 /*
 Validator Validate irs file
-Validation of irs file.
+Validation of irs file. Form 1042-S files of Publication 1187 are detected automatically and validated with their control totals. Set report to true to get all validation errors as json and profile (default, fire-production, fire-test, lenient-import) to grade them with a validation profile, profiles only affect the result of this endpoint.
   - @param ctx _context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
   - @param optional nil or *ValidatorOpts - Optional Parameters:
   - @param "File" (optional.Interface of *os.File) -  irs file to upload
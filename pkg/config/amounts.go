@@ -0,0 +1,59 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package config
+
+// withheldAmountFields are the state and local withholding fields of extension blocks
+var withheldAmountFields = []string{"StateIncomeTaxWithheld", "LocalIncomeTaxWithheld"}
+
+// controlTotalFields are the control totals of “C” and “K” records
+var controlTotalFields = []string{
+	"ControlTotal1", "ControlTotal2", "ControlTotal3", "ControlTotal4", "ControlTotal5", "ControlTotal6",
+	"ControlTotal7", "ControlTotal8", "ControlTotal9", "ControlTotalA", "ControlTotalB", "ControlTotalC",
+	"ControlTotalD", "ControlTotalE", "ControlTotalF", "ControlTotalG", "ControlTotalH", "ControlTotalJ",
+}
+
+// AmountFields are the fields holding money amounts in cents by record type or extension block type
+//
+// Amounts are whole cents in the fire ascii file and in the default json
+// encoding. Counts, percentages, years and codes of integer fields aren't amounts.
+var AmountFields = map[string][]string{
+	BRecordType: {
+		"PaymentAmount1", "PaymentAmount2", "PaymentAmount3", "PaymentAmount4", "PaymentAmount5", "PaymentAmount6",
+		"PaymentAmount7", "PaymentAmount8", "PaymentAmount9", "PaymentAmountA", "PaymentAmountB", "PaymentAmountC",
+		"PaymentAmountD", "PaymentAmountE", "PaymentAmountF", "PaymentAmountG", "PaymentAmountH", "PaymentAmountJ",
+	},
+	CRecordType:     controlTotalFields,
+	KRecordType:     append([]string{"StateIncomeTaxWithheldTotal", "LocalIncomeTaxWithheldTotal"}, controlTotalFields...),
+	Sub1099DaType:   withheldAmountFields,
+	Sub1099DivType:  withheldAmountFields,
+	Sub1099GType:    withheldAmountFields,
+	Sub1099IntType:  withheldAmountFields,
+	Sub1099KType:    withheldAmountFields,
+	Sub1099LtcType:  withheldAmountFields,
+	Sub1099MiscType: withheldAmountFields,
+	Sub1099NecType:  withheldAmountFields,
+	Sub1099OidType:  withheldAmountFields,
+	Sub1099PatrType: withheldAmountFields,
+	Sub1099RType:    withheldAmountFields,
+	Sub1099SType:    withheldAmountFields,
+	Sub1099SaType:   withheldAmountFields,
+	SubW2GType:      withheldAmountFields,
+}
+
+// LossAmountCodes are the amount codes by type of return whose payment amounts and
+// control totals may be negative
+//
+// Pub 1220 allows negative amounts only for items that reflect a loss on
+// Form 1099-B, 1099-OID or 1099-Q, those are the profit or (loss) boxes of 1099-B,
+// the earnings (or loss) box of 1099-Q and the amounts of 1099-OID, whose boxes
+// Pub 1220 doesn't narrow down.
+var LossAmountCodes = map[string]map[string]bool{
+	Sub1099BType: {"9": true, "A": true, "B": true, "C": true},
+	Sub1099OidType: {
+		"1": true, "2": true, "3": true, "4": true, "5": true,
+		"6": true, "7": true, "A": true, "B": true, "C": true,
+	},
+	Sub1099QType: {"2": true},
+}
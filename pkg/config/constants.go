@@ -39,6 +39,8 @@ const (
 	Sub1099CType = "1099-C"
 	// Sub1099CapType indicates extension block type of payee “B” record for form 1099-CAP
 	Sub1099CapType = "1099-CAP"
+	// Sub1099DaType indicates extension block type of payee “B” record for form 1099-DA
+	Sub1099DaType = "1099-DA"
 	// Sub1099DivType indicates extension block type of payee “B” record for form 1099-DIV
 	Sub1099DivType = "1099-DIV"
 	// Sub1099GType indicates extension block type of payee “B” record for form 1099-G
@@ -63,6 +65,8 @@ const (
 	Sub1099PatrType = "1099-PATR"
 	// Sub1099QType indicates extension block type of payee “B” record for form 1099-Q
 	Sub1099QType = "1099-Q"
+	// Sub1099QaType indicates extension block type of payee “B” record for form 1099-QA
+	Sub1099QaType = "1099-QA"
 	// Sub1099RType indicates extension block type of payee “B” record for form 1099-R
 	Sub1099RType = "1099-R"
 	// Sub1099SType indicates extension block type of payee “B” record for form 1099-S
@@ -79,6 +83,8 @@ const (
 	Sub5498Type = "5498"
 	// Sub5498EsaType indicates extension block type of payee “B” record for form 5498-ESA
 	Sub5498EsaType = "5498ESA"
+	// Sub5498QaType indicates extension block type of payee “B” record for form 5498-QA
+	Sub5498QaType = "5498-QA"
 	// Sub5498SaType indicates extension block type of payee “B” record for form 5498-SA
 	Sub5498SaType = "5498SA"
 	// SubW2GType indicates extension block type of payee “B” record for form W-2G
@@ -169,8 +175,8 @@ var StateAbbreviationCodes = map[string]string{
 	"ID": "Idaho",
 	"IL": "Illinois",
 	"IN": "Indiana",
-	"IA": "IA",
-	"KS": "KS",
+	"IA": "Iowa",
+	"KS": "Kansas",
 	"KY": "Kentucky",
 	"LA": "Louisiana",
 	"ME": "Maine",
@@ -186,7 +192,7 @@ var StateAbbreviationCodes = map[string]string{
 	"NH": "New Hampshire",
 	"NJ": "New Jersey",
 	"NM": "New Mexico",
-	"NY": "NY",
+	"NY": "New York",
 	"NC": "North Carolina",
 	"ND": "North Dakota",
 	"MP": "No. Mariana Islands",
@@ -210,6 +216,83 @@ var StateAbbreviationCodes = map[string]string{
 	"WY": "Wyoming",
 }
 
+// Military State Codes of APO, FPO and DPO addresses
+var MilitaryStateCodes = map[string]string{
+	"AA": "Armed Forces Americas",
+	"AE": "Armed Forces Europe",
+	"AP": "Armed Forces Pacific",
+}
+
+// Military City Codes of APO, FPO and DPO addresses
+var MilitaryCityCodes = map[string]string{
+	"APO": "Army Post Office",
+	"FPO": "Fleet Post Office",
+	"DPO": "Diplomatic Post Office",
+}
+
+// ZIP Code prefix (first three digits) ranges of states and military state codes
+var ZipCodePrefixes = map[string][][2]int{
+	"AL": {{350, 369}},
+	"AK": {{995, 999}},
+	"AS": {{967, 967}},
+	"AZ": {{850, 865}},
+	"AR": {{716, 729}},
+	"CA": {{900, 961}},
+	"CO": {{800, 816}},
+	"CT": {{60, 69}},
+	"DE": {{197, 199}},
+	"DC": {{200, 200}, {202, 205}, {569, 569}},
+	"FL": {{320, 349}},
+	"GA": {{300, 319}, {398, 399}},
+	"GU": {{969, 969}},
+	"HI": {{967, 968}},
+	"ID": {{832, 838}},
+	"IL": {{600, 629}},
+	"IN": {{460, 479}},
+	"IA": {{500, 528}},
+	"KS": {{660, 679}},
+	"KY": {{400, 427}},
+	"LA": {{700, 714}},
+	"ME": {{39, 49}},
+	"MD": {{206, 219}},
+	"MA": {{10, 27}, {55, 55}},
+	"MI": {{480, 499}},
+	"MN": {{550, 567}},
+	"MS": {{386, 397}},
+	"MO": {{630, 658}},
+	"MT": {{590, 599}},
+	"NE": {{680, 693}},
+	"NV": {{889, 898}},
+	"NH": {{30, 38}},
+	"NJ": {{70, 89}},
+	"NM": {{870, 884}},
+	"NY": {{5, 5}, {63, 63}, {100, 149}},
+	"NC": {{270, 289}},
+	"ND": {{580, 588}},
+	"MP": {{969, 969}},
+	"OH": {{430, 459}},
+	"OK": {{730, 731}, {734, 749}},
+	"OR": {{970, 979}},
+	"PA": {{150, 196}},
+	"PR": {{6, 7}, {9, 9}},
+	"RI": {{28, 29}},
+	"SC": {{290, 299}},
+	"SD": {{570, 577}},
+	"TN": {{370, 385}},
+	"TX": {{733, 733}, {750, 799}, {885, 885}},
+	"UT": {{840, 847}},
+	"VT": {{50, 59}},
+	"VA": {{201, 201}, {220, 246}},
+	"VI": {{8, 8}},
+	"WA": {{980, 994}},
+	"WV": {{247, 268}},
+	"WI": {{530, 549}},
+	"WY": {{820, 831}, {834, 834}},
+	"AA": {{340, 340}},
+	"AE": {{90, 98}},
+	"AP": {{962, 966}},
+}
+
 // Codes for participating states in the CF/SF Program
 var ParticipateStateCodes = map[int]string{
 	1:  "Alabama",
@@ -257,6 +340,7 @@ var TypeOfReturns = map[string]string{
 	"B":  "1099-B",
 	"5":  "1099-C",
 	"P":  "1099-CAP",
+	"DA": "1099-DA",
 	"1":  "1099-DIV",
 	"F":  "1099-G",
 	"J":  "1099-H",
@@ -269,6 +353,7 @@ var TypeOfReturns = map[string]string{
 	"D":  "1099-OID",
 	"7":  "1099-PATR",
 	"Q":  "1099-Q",
+	"Y":  "1099-QA",
 	"9":  "1099-R",
 	"S":  "1099-S",
 	"M":  "1099-SA",
@@ -277,6 +362,7 @@ var TypeOfReturns = map[string]string{
 	"Z":  "3922",
 	"L":  "5498",
 	"V":  "5498-ESA",
+	"QA": "5498-QA",
 	"K":  "5498-SA",
 	"W":  "W-2G",
 }
@@ -385,6 +471,13 @@ var AmountCodes = map[string]map[string]string{
 	"1099-CAP": {
 		"2": "Aggregate amount received",
 	},
+	"1099-DA": {
+		"1": "Proceeds",
+		"2": "Cost or other basis",
+		"3": "Accrued market discount",
+		"4": "Federal income tax withheld",
+		"5": "Wash sale loss disallowed",
+	},
 	"1099-DIV": {
 		"1": "Total ordinary dividends",
 		"2": "Qualified dividends",
@@ -513,6 +606,11 @@ var AmountCodes = map[string]map[string]string{
 		"2": "Earnings (or loss)",
 		"3": "Basis",
 	},
+	"1099-QA": {
+		"1": "Gross distributions",
+		"2": "Earnings",
+		"3": "Basis",
+	},
 	"1099-R": {
 		"1": "Gross distribution",
 		"2": "Taxable amount",
@@ -567,6 +665,12 @@ var AmountCodes = map[string]map[string]string{
 		"1": "Coverdell ESA contributions",
 		"2": "Rollover contributions",
 	},
+	"5498-QA": {
+		"1": "ABLE contributions",
+		"2": "Rollover contributions",
+		"3": "Cumulative contributions",
+		"4": "Fair market value of account",
+	},
 	"5498-SA": {
 		"1": "Employee",
 		"2": "Total contributions made in 2019",
@@ -606,4 +710,7 @@ var DistributionCodes = []string{
 const (
 	OutputJsonFormat = "json"
 	OutputIrsFormat  = "irs"
+	OutputTextFormat = "text"
+	OutputCsvFormat  = "csv"
+	OutputIrisFormat = "iris"
 )
@@ -0,0 +1,214 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package config
+
+const (
+	// RecordLength1042S indicates length of records of Form 1042-S files (Pub 1187)
+	RecordLength1042S = 1020
+	// WRecordType indicates type of withholding agent “W” record of Form 1042-S files
+	WRecordType = "W"
+	// QRecordType indicates type of recipient “Q” record of Form 1042-S files
+	QRecordType = "Q"
+)
+
+const (
+	// TestIndicator1042S indicates a test file in the “T” record of Form 1042-S files
+	TestIndicator1042S = "TEST"
+	// OriginalReturnIndicator1042S indicates an original return
+	OriginalReturnIndicator1042S = "0"
+	// AmendedReturnIndicator1042S indicates an amended return
+	AmendedReturnIndicator1042S = "1"
+	// Chapter3Indicator indicates withholding under chapter 3
+	Chapter3Indicator = "3"
+	// Chapter4Indicator indicates withholding under chapter 4
+	Chapter4Indicator = "4"
+)
+
+var (
+	// Transmitter “T” Record of Form 1042-S files
+	T1042SRecordLayout = map[string]SpecField{
+		"RecordType":             {0, 1, Alphanumeric, Required},
+		"TaxYear":                {1, 4, DateYear, Required},
+		"PriorYearDataIndicator": {5, 1, Alphanumeric, Applicable},
+		"TIN":                    {6, 9, Numeric, Required},
+		"TransmitterName":        {15, 40, Alphanumeric, Required},
+		"AddressLine1":           {55, 40, Alphanumeric, Required},
+		"AddressLine2":           {95, 40, Alphanumeric, Applicable},
+		"City":                   {135, 40, Alphanumeric, Required},
+		"StateCode":              {175, 2, Alphanumeric, Applicable},
+		"ProvinceCode":           {177, 2, Alphanumeric, Applicable},
+		"CountryCode":            {179, 2, Alphanumeric, Applicable},
+		"PostalCode":             {181, 9, Alphanumeric, Applicable},
+		"ContactName":            {190, 40, Alphanumeric, Required},
+		"ContactDepartmentTitle": {230, 40, Alphanumeric, Applicable},
+		"ContactTelephoneNumber": {270, 20, TelephoneNumber, Required},
+		"ContactEmailAddress":    {290, 50, Email, Applicable},
+		"TCC":                    {340, 5, Alphanumeric, Required},
+		"TestIndicator":          {345, 4, Alphanumeric, Applicable},
+		"Blank1":                 {349, 150, Alphanumeric, Nullable},
+		"RecordSequenceNumber":   {499, 8, ZeroNumeric, Required},
+		"Blank2":                 {507, 513, Alphanumeric, Nullable},
+	}
+	// Withholding Agent “W” Record of Form 1042-S files
+	W1042SRecordLayout = map[string]SpecField{
+		"RecordType":             {0, 1, Alphanumeric, Required},
+		"ReturnTypeIndicator":    {1, 1, Alphanumeric, Required},
+		"ProRataBasisReporting":  {2, 1, Alphanumeric, Applicable},
+		"TIN":                    {3, 9, Numeric, Required},
+		"Chapter3StatusCode":     {12, 2, Numeric, Applicable},
+		"Chapter4StatusCode":     {14, 2, Numeric, Applicable},
+		"Name1":                  {16, 40, Alphanumeric, Required},
+		"Name2":                  {56, 40, Alphanumeric, Applicable},
+		"Name3":                  {96, 40, Alphanumeric, Applicable},
+		"AddressLine1":           {136, 40, Alphanumeric, Required},
+		"AddressLine2":           {176, 40, Alphanumeric, Applicable},
+		"City":                   {216, 40, Alphanumeric, Required},
+		"StateCode":              {256, 2, Alphanumeric, Applicable},
+		"ProvinceCode":           {258, 2, Alphanumeric, Applicable},
+		"CountryCode":            {260, 2, Alphanumeric, Applicable},
+		"PostalCode":             {262, 9, Alphanumeric, Applicable},
+		"ContactName":            {271, 40, Alphanumeric, Applicable},
+		"ContactDepartmentTitle": {311, 40, Alphanumeric, Applicable},
+		"ContactTelephoneNumber": {351, 20, TelephoneNumber, Applicable},
+		"FinalReturnIndicator":   {371, 1, Alphanumeric, Applicable},
+		"GIIN":                   {372, 19, Alphanumeric, Applicable},
+		"Blank1":                 {391, 108, Alphanumeric, Nullable},
+		"RecordSequenceNumber":   {499, 8, ZeroNumeric, Required},
+		"Blank2":                 {507, 513, Alphanumeric, Nullable},
+	}
+	// Recipient “Q” Record of Form 1042-S files
+	Q1042SRecordLayout = map[string]SpecField{
+		"RecordType":               {0, 1, Alphanumeric, Required},
+		"ReturnTypeIndicator":      {1, 1, Alphanumeric, Required},
+		"ProRataBasisReporting":    {2, 1, Alphanumeric, Applicable},
+		"UniqueFormIdentifier":     {3, 10, Numeric, Required},
+		"AmendmentNumber":          {13, 1, ZeroNumeric, Applicable},
+		"IncomeCode":               {14, 2, Numeric, Required},
+		"GrossIncome":              {16, 12, ZeroNumeric, Applicable},
+		"WithholdingAllowance":     {28, 12, ZeroNumeric, Applicable},
+		"NetIncome":                {40, 12, ZeroNumeric, Applicable},
+		"TaxRate":                  {52, 4, ZeroNumeric, Applicable},
+		"ExemptionCode":            {56, 2, Alphanumeric, Applicable},
+		"ChapterIndicator":         {58, 1, Alphanumeric, Required},
+		"FederalTaxWithheld":       {59, 12, ZeroNumeric, Applicable},
+		"WithholdingByOtherAgents": {71, 12, ZeroNumeric, Applicable},
+		"TotalWithholdingCredit":   {83, 12, ZeroNumeric, Applicable},
+		"AmountRepaid":             {95, 12, ZeroNumeric, Applicable},
+		"TIN":                      {107, 9, Numeric, Applicable},
+		"TINType":                  {116, 1, Alphanumeric, Applicable},
+		"ForeignTIN":               {117, 22, Alphanumeric, Applicable},
+		"GIIN":                     {139, 19, Alphanumeric, Applicable},
+		"Chapter3StatusCode":       {158, 2, Numeric, Applicable},
+		"Chapter4StatusCode":       {160, 2, Numeric, Applicable},
+		"Name1":                    {162, 40, Alphanumeric, Required},
+		"Name2":                    {202, 40, Alphanumeric, Applicable},
+		"AddressLine1":             {242, 40, Alphanumeric, Required},
+		"AddressLine2":             {282, 40, Alphanumeric, Applicable},
+		"City":                     {322, 40, Alphanumeric, Required},
+		"StateCode":                {362, 2, Alphanumeric, Applicable},
+		"ProvinceCode":             {364, 2, Alphanumeric, Applicable},
+		"CountryCode":              {366, 2, Alphanumeric, Required},
+		"PostalCode":               {368, 9, Alphanumeric, Applicable},
+		"CountryOfResidence":       {377, 2, Alphanumeric, Applicable},
+		"DateOfBirth":              {379, 8, Date, Applicable},
+		"AccountNumber":            {387, 20, Alphanumeric, Applicable},
+		"Blank1":                   {407, 92, Alphanumeric, Nullable},
+		"RecordSequenceNumber":     {499, 8, ZeroNumeric, Required},
+		"Blank2":                   {507, 513, Alphanumeric, Nullable},
+	}
+	// Reconciliation “C” Record of Form 1042-S files
+	C1042SRecordLayout = map[string]SpecField{
+		"RecordType":              {0, 1, Alphanumeric, Required},
+		"TotalQRecords":           {1, 8, ZeroNumeric, Required},
+		"Blank1":                  {9, 6, Alphanumeric, Nullable},
+		"TotalGrossIncome":        {15, 15, ZeroNumeric, Applicable},
+		"TotalFederalTaxWithheld": {30, 15, ZeroNumeric, Applicable},
+		"TotalWithholdingCredit":  {45, 15, ZeroNumeric, Applicable},
+		"Blank2":                  {60, 439, Alphanumeric, Nullable},
+		"RecordSequenceNumber":    {499, 8, ZeroNumeric, Required},
+		"Blank3":                  {507, 513, Alphanumeric, Nullable},
+	}
+	// End of Transmission “F” Record of Form 1042-S files
+	F1042SRecordLayout = map[string]SpecField{
+		"RecordType":           {0, 1, Alphanumeric, Required},
+		"NumberWRecords":       {1, 3, ZeroNumeric, Required},
+		"Blank1":               {4, 495, Alphanumeric, Nullable},
+		"RecordSequenceNumber": {499, 8, ZeroNumeric, Required},
+		"Blank2":               {507, 513, Alphanumeric, Nullable},
+	}
+)
+
+// Record layouts of Form 1042-S files by record type
+var Record1042SLayouts = map[string]map[string]SpecField{
+	TRecordType: T1042SRecordLayout,
+	WRecordType: W1042SRecordLayout,
+	QRecordType: Q1042SRecordLayout,
+	CRecordType: C1042SRecordLayout,
+	FRecordType: F1042SRecordLayout,
+}
+
+// Income codes of Form 1042-S
+var IncomeCodes1042S = map[string]string{
+	"01": "Interest paid by U.S. obligors—general",
+	"02": "Interest paid on real property mortgages",
+	"03": "Interest paid to controlling foreign corporations",
+	"04": "Interest paid by foreign corporations",
+	"05": "Interest on tax-free covenant bonds",
+	"06": "Dividends paid by U.S. corporations—general",
+	"07": "Dividends qualifying for direct dividend rate",
+	"08": "Dividends paid by foreign corporations",
+	"09": "Capital gains",
+	"10": "Industrial royalties",
+	"11": "Motion picture or television copyright royalties",
+	"12": "Other royalties",
+	"13": "Royalties paid on certain publicly offered securities",
+	"14": "Real property income and natural resources royalties",
+	"15": "Pensions, annuities, alimony, and/or insurance premiums",
+	"16": "Scholarship or fellowship grants",
+	"17": "Compensation for independent personal services",
+	"18": "Compensation for dependent personal services",
+	"19": "Compensation for teaching",
+	"20": "Compensation during studying and training",
+	"22": "Interest paid on deposit with a foreign branch of a domestic corporation or partnership",
+	"23": "Other income",
+	"24": "Qualified investment entity (QIE) distributions of capital gains",
+	"25": "Trust distributions subject to IRC section 1445",
+	"26": "Unsevered growing crops and timber distributions by a trust subject to IRC section 1445",
+	"27": "Publicly traded partnership distributions subject to IRC section 1446",
+	"28": "Gambling winnings",
+	"29": "Deposit interest",
+	"30": "Original issue discount (OID)",
+	"31": "Short-term OID",
+	"32": "Notional principal contract income",
+	"33": "Substitute payment—interest",
+	"34": "Substitute payment—dividends",
+	"35": "Substitute payment—other",
+	"36": "Capital gains distributions",
+	"37": "Return of capital",
+	"38": "Eligible deferred compensation items",
+	"39": "Distributions from a nongrantor trust",
+	"40": "Other dividend equivalents under IRC section 871(m)",
+	"41": "Guarantee of indebtedness",
+	"42": "Earnings as an artist or athlete—no central withholding agreement",
+	"43": "Earnings as an artist or athlete—central withholding agreement",
+	"44": "Specified Federal procurement payments",
+	"50": "Income previously reported under escrow procedure",
+	"51": "Interest paid on certain actively traded or publicly offered securities",
+	"52": "Dividends paid on certain actively traded or publicly offered securities",
+	"53": "Substitute payments—dividends from certain actively traded or publicly offered securities",
+	"54": "Substitute payments—other income from certain actively traded or publicly offered securities",
+	"55": "Taxable death benefits on life insurance contracts",
+	"56": "Dividend equivalents under IRC section 871(m) as a result of applying the combined transaction rules",
+	"57": "Amount realized under IRC section 1446(f)",
+	"58": "Distributions from a publicly traded partnership",
+}
+
+// Recipient U.S. TIN types of Form 1042-S
+var TINTypes1042S = map[string]string{
+	"0": "No TIN",
+	"1": "SSN or ITIN",
+	"2": "EIN",
+	"3": "QI-EIN, WP-EIN or WT-EIN",
+}
@@ -0,0 +1,136 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package config
+
+const (
+	// IrisNamespace is the namespace of IRIS intake transmissions
+	IrisNamespace = "urn:us:gov:treasury:irs:ir"
+	// IrisSchemaVersion is the version of the IRIS intake schema of transmissions
+	IrisSchemaVersion = "1.0"
+	// IrisParentFormType is the transmittal form of IRIS submissions
+	IrisParentFormType = "1096"
+)
+
+// IrisAmount is the IRIS element of a payment amount code
+type IrisAmount struct {
+	Code    string
+	Element string
+}
+
+// IrisFormTypes are the IRIS form type codes of the types of return IRIS output supports
+var IrisFormTypes = map[string]string{
+	Sub1099DivType:  "1099DIV",
+	Sub1099GType:    "1099G",
+	Sub1099IntType:  "1099INT",
+	Sub1099MiscType: "1099MISC",
+	Sub1099NecType:  "1099NEC",
+	Sub1099OidType:  "1099OID",
+	Sub1099RType:    "1099R",
+}
+
+// IrisAmountElements are the IRIS elements of the payment amount codes by type of return, in schema order
+//
+// Amounts of other codes have no IRIS element and can't be written to IRIS transmissions.
+var IrisAmountElements = map[string][]IrisAmount{
+	Sub1099DivType: {
+		{"1", "TotalOrdinaryDividendsAmt"},
+		{"2", "QualifiedDividendsAmt"},
+		{"3", "TotalCapitalGainDistributionAmt"},
+		{"6", "UnrecapturedSection1250GainAmt"},
+		{"7", "Section1202GainAmt"},
+		{"8", "CollectiblesGainAmt"},
+		{"9", "NondividendDistributionsAmt"},
+		{"A", "FederalIncomeTaxWithheldAmt"},
+		{"5", "Section199ADividendsAmt"},
+		{"B", "InvestmentExpenseAmt"},
+		{"C", "ForeignTaxPaidAmt"},
+		{"D", "CashLiquidationDistributionsAmt"},
+		{"E", "NoncashLiquidationDistributionsAmt"},
+		{"F", "ExemptInterestDividendsAmt"},
+		{"G", "SpecifiedPrivateActivityBondInterestDividendsAmt"},
+	},
+	Sub1099GType: {
+		{"1", "UnemploymentCompensationAmt"},
+		{"2", "StateLocalIncomeTaxRefundAmt"},
+		{"4", "FederalIncomeTaxWithheldAmt"},
+		{"5", "RTAAPaymentsAmt"},
+		{"6", "TaxableGrantsAmt"},
+		{"7", "AgricultureSubsidyPaymentsAmt"},
+		{"9", "MarketGainAmt"},
+	},
+	Sub1099IntType: {
+		{"1", "InterestIncomeAmt"},
+		{"2", "EarlyWithdrawalPenaltyAmt"},
+		{"3", "USSavingsBondsTreasuryObligationsInterestAmt"},
+		{"4", "FederalIncomeTaxWithheldAmt"},
+		{"5", "InvestmentExpenseAmt"},
+		{"6", "ForeignTaxPaidAmt"},
+		{"8", "TaxExemptInterestAmt"},
+		{"9", "SpecifiedPrivateActivityBondInterestAmt"},
+		{"A", "MarketDiscountAmt"},
+		{"B", "BondPremiumAmt"},
+		{"E", "TreasuryObligationBondPremiumAmt"},
+		{"D", "TaxExemptBondPremiumAmt"},
+	},
+	Sub1099MiscType: {
+		{"1", "RentAmt"},
+		{"2", "RoyaltyAmt"},
+		{"3", "OtherIncomeAmt"},
+		{"4", "FederalIncomeTaxWithheldAmt"},
+		{"5", "FishingBoatProceedsAmt"},
+		{"6", "MedicalHealthCarePaymentAmt"},
+		{"8", "SubstitutePaymentAmt"},
+		{"A", "CropInsuranceProceedsAmt"},
+		{"C", "GrossProceedsPaidToAttorneyAmt"},
+		{"D", "Section409ADeferralAmt"},
+		{"B", "ExcessGoldenParachutePaymentAmt"},
+		{"E", "NonqualifiedDeferredCompensationAmt"},
+	},
+	Sub1099NecType: {
+		{"1", "NonemployeeCompensationAmt"},
+		{"4", "FederalIncomeTaxWithheldAmt"},
+	},
+	Sub1099OidType: {
+		{"1", "OriginalIssueDiscountAmt"},
+		{"2", "OtherPeriodicInterestAmt"},
+		{"3", "EarlyWithdrawalPenaltyAmt"},
+		{"4", "FederalIncomeTaxWithheldAmt"},
+		{"A", "MarketDiscountAmt"},
+		{"B", "AcquisitionPremiumAmt"},
+		{"6", "USTreasuryObligationOIDAmt"},
+		{"7", "InvestmentExpenseAmt"},
+		{"5", "BondPremiumAmt"},
+		{"C", "TaxExemptOIDAmt"},
+	},
+	Sub1099RType: {
+		{"1", "GrossDistributionAmt"},
+		{"2", "TaxableAmt"},
+		{"3", "CapitalGainAmt"},
+		{"4", "FederalIncomeTaxWithheldAmt"},
+		{"5", "EmployeeContributionsAmt"},
+		{"6", "NetUnrealizedAppreciationAmt"},
+		{"8", "OtherAmt"},
+		{"9", "TotalEmployeeContributionsAmt"},
+		{"A", "TraditionalIRASEPSIMPLEDistributionAmt"},
+		{"B", "IRRAllocableAmt"},
+	},
+}
+
+// IrisIndicator is the IRIS element of an indicator field of extension blocks
+type IrisIndicator struct {
+	Field   string
+	Element string
+	// Value is the Pub 1220 value of the field if the indicator is set
+	Value string
+}
+
+// IrisIndicatorElements are the IRIS elements of the indicator fields of extension blocks, in schema order
+//
+// Indicators are “1” in IRIS if the field isn't blank in the extension block.
+var IrisIndicatorElements = []IrisIndicator{
+	{"SecondTinNotice", "SecondTINNoticeInd", SecondTINNotice},
+	{"DirectSalesIndicator", "DirectSalesInd", DirectSalesIndicator},
+	{"FATCA", "FATCAFilingRequirementInd", FatcaFilingRequirementIndicator},
+}
@@ -0,0 +1,154 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package config
+
+import (
+	"errors"
+	"sort"
+	"sync"
+)
+
+// DefaultTaxYear is the tax year of the Publication 1220 revision described by the record layouts of this package
+const DefaultTaxYear = 2020
+
+// ErrInvalidRevision is given when a layout revision can't be registered
+var ErrInvalidRevision = errors.New("is an invalid layout revision")
+
+// Revision contains the record layouts of a Publication 1220 revision.
+//
+// A revision applies to its tax year and to every later tax year until the
+// next registered revision. Layouts missing from a revision are taken from
+// the previous revision, so a revision only needs to list changed layouts.
+type Revision struct {
+	// TaxYear is the first tax year the revision applies to
+	TaxYear int
+	// Records contains layouts of “T”, “A”, “B”, “C”, “K” and “F” records keyed by record type
+	Records map[string]map[string]SpecField
+	// SubRecords contains layouts of “B” record extension blocks keyed by type of return
+	SubRecords map[string]map[string]SpecField
+}
+
+var (
+	revisionsMu sync.RWMutex
+	revisions   = map[int]*Revision{
+		DefaultTaxYear: {
+			TaxYear:    DefaultTaxYear,
+			Records:    RecordLayouts,
+			SubRecords: SubRecordLayouts,
+		},
+	}
+)
+
+// RegisterRevision adds the layouts of a Publication 1220 revision, replacing a revision of the same tax year
+func RegisterRevision(revision *Revision) error {
+	if revision == nil || revision.TaxYear < 1900 {
+		return ErrInvalidRevision
+	}
+
+	revisionsMu.Lock()
+	defer revisionsMu.Unlock()
+	revisions[revision.TaxYear] = revision
+	return nil
+}
+
+// UnregisterRevision removes the revision of the tax year, the revision of DefaultTaxYear can't be removed
+func UnregisterRevision(taxYear int) {
+	if taxYear == DefaultTaxYear {
+		return
+	}
+
+	revisionsMu.Lock()
+	defer revisionsMu.Unlock()
+	delete(revisions, taxYear)
+}
+
+// TaxYears returns tax years of all registered revisions in ascending order
+func TaxYears() []int {
+	revisionsMu.RLock()
+	defer revisionsMu.RUnlock()
+	return taxYears()
+}
+
+// CurrentTaxYear returns tax year of the latest registered revision
+func CurrentTaxYear() int {
+	revisionsMu.RLock()
+	defer revisionsMu.RUnlock()
+
+	years := taxYears()
+	return years[len(years)-1]
+}
+
+// LayoutYear returns tax year that selects the layout revision of records with the payment year.
+//
+// Prior year data is reported with the record format of the current revision of Publication 1220,
+// not the format of its payment year, so it uses CurrentTaxYear unless the payment year is later.
+func LayoutYear(paymentYear int, priorYearData bool) int {
+	if current := CurrentTaxYear(); priorYearData && current > paymentYear {
+		return current
+	}
+	return paymentYear
+}
+
+// RevisionYear returns tax year of the revision that applies to the tax year.
+// Zero selects the revision of DefaultTaxYear, and years before the first revision select the first revision.
+func RevisionYear(taxYear int) int {
+	if taxYear == 0 {
+		taxYear = DefaultTaxYear
+	}
+
+	revisionsMu.RLock()
+	defer revisionsMu.RUnlock()
+
+	years := taxYears()
+	index := sort.SearchInts(years, taxYear+1) - 1
+	if index < 0 {
+		index = 0
+	}
+	return years[index]
+}
+
+// RecordLayout returns layout of the record type that applies to the tax year
+func RecordLayout(taxYear int, recordType string) map[string]SpecField {
+	return lookupLayout(taxYear, func(revision *Revision) map[string]SpecField {
+		return revision.Records[recordType]
+	})
+}
+
+// SubRecordLayout returns layout of the extension block type that applies to the tax year
+func SubRecordLayout(taxYear int, subRecordType string) map[string]SpecField {
+	return lookupLayout(taxYear, func(revision *Revision) map[string]SpecField {
+		return revision.SubRecords[subRecordType]
+	})
+}
+
+func lookupLayout(taxYear int, get func(*Revision) map[string]SpecField) map[string]SpecField {
+	year := RevisionYear(taxYear)
+
+	revisionsMu.RLock()
+	defer revisionsMu.RUnlock()
+
+	years := taxYears()
+	for index := sort.SearchInts(years, year); index >= 0; index-- {
+		if layout := get(revisions[years[index]]); layout != nil {
+			return layout
+		}
+	}
+	// layouts introduced after the revision
+	for _, year := range years {
+		if layout := get(revisions[year]); layout != nil {
+			return layout
+		}
+	}
+	return nil
+}
+
+func taxYears() []int {
+	years := make([]int, 0, len(revisions))
+	for year := range revisions {
+		years = append(years, year)
+	}
+	sort.Ints(years)
+	return years
+}
@@ -39,6 +39,8 @@ const (
 	Email
 	DateYear
 	Date
+	// SignedNumeric is a ZeroNumeric amount with a leading “+” or “-” sign or a negative over-punch in the units position
+	SignedNumeric
 )
 
 var (
@@ -99,8 +101,8 @@ var (
 		"TransferAgentIndicator":  {132, 1, Alphanumeric, Required},
 		"PayerShippingAddress":    {133, 40, Alphanumeric, Required},
 		"PayerCity":               {173, 40, Alphanumeric, Required},
-		"PayerState":              {213, 2, Alphanumeric, Required},
-		"PayerZipCode":            {215, 9, Numeric, Applicable},
+		"PayerState":              {213, 2, Alphanumeric, Applicable},
+		"PayerZipCode":            {215, 9, Alphanumeric, Applicable},
 		"PayerTelephoneNumber":    {224, 15, TelephoneNumber, Required},
 		"Blank3":                  {239, 260, Alphanumeric, Nullable},
 		"RecordSequenceNumber":    {499, 8, ZeroNumeric, Required},
@@ -118,24 +120,24 @@ var (
 		"PayerAccountNumber":       {20, 20, Alphanumeric, Applicable},
 		"PayerOfficeCode":          {40, 4, Alphanumeric, Applicable},
 		"Blank1":                   {44, 10, Alphanumeric, Nullable},
-		"PaymentAmount1":           {54, 12, ZeroNumeric, Applicable},
-		"PaymentAmount2":           {66, 12, ZeroNumeric, Applicable},
-		"PaymentAmount3":           {78, 12, ZeroNumeric, Applicable},
-		"PaymentAmount4":           {90, 12, ZeroNumeric, Applicable},
-		"PaymentAmount5":           {102, 12, ZeroNumeric, Applicable},
-		"PaymentAmount6":           {114, 12, ZeroNumeric, Applicable},
-		"PaymentAmount7":           {126, 12, ZeroNumeric, Applicable},
-		"PaymentAmount8":           {138, 12, ZeroNumeric, Applicable},
-		"PaymentAmount9":           {150, 12, ZeroNumeric, Applicable},
-		"PaymentAmountA":           {162, 12, ZeroNumeric, Applicable},
-		"PaymentAmountB":           {174, 12, ZeroNumeric, Applicable},
-		"PaymentAmountC":           {186, 12, ZeroNumeric, Applicable},
-		"PaymentAmountD":           {198, 12, ZeroNumeric, Applicable},
-		"PaymentAmountE":           {210, 12, ZeroNumeric, Applicable},
-		"PaymentAmountF":           {222, 12, ZeroNumeric, Applicable},
-		"PaymentAmountG":           {234, 12, ZeroNumeric, Applicable},
-		"PaymentAmountH":           {246, 12, ZeroNumeric, Applicable},
-		"PaymentAmountJ":           {258, 12, ZeroNumeric, Applicable},
+		"PaymentAmount1":           {54, 12, SignedNumeric, Applicable},
+		"PaymentAmount2":           {66, 12, SignedNumeric, Applicable},
+		"PaymentAmount3":           {78, 12, SignedNumeric, Applicable},
+		"PaymentAmount4":           {90, 12, SignedNumeric, Applicable},
+		"PaymentAmount5":           {102, 12, SignedNumeric, Applicable},
+		"PaymentAmount6":           {114, 12, SignedNumeric, Applicable},
+		"PaymentAmount7":           {126, 12, SignedNumeric, Applicable},
+		"PaymentAmount8":           {138, 12, SignedNumeric, Applicable},
+		"PaymentAmount9":           {150, 12, SignedNumeric, Applicable},
+		"PaymentAmountA":           {162, 12, SignedNumeric, Applicable},
+		"PaymentAmountB":           {174, 12, SignedNumeric, Applicable},
+		"PaymentAmountC":           {186, 12, SignedNumeric, Applicable},
+		"PaymentAmountD":           {198, 12, SignedNumeric, Applicable},
+		"PaymentAmountE":           {210, 12, SignedNumeric, Applicable},
+		"PaymentAmountF":           {222, 12, SignedNumeric, Applicable},
+		"PaymentAmountG":           {234, 12, SignedNumeric, Applicable},
+		"PaymentAmountH":           {246, 12, SignedNumeric, Applicable},
+		"PaymentAmountJ":           {258, 12, SignedNumeric, Applicable},
 		"Blank2":                   {270, 16, Alphanumeric, Nullable},
 		"ForeignCountryIndicator":  {286, 1, Alphanumeric, Applicable},
 		"FirstPayeeNameLine":       {287, 40, Alphanumeric, Required},
@@ -143,8 +145,8 @@ var (
 		"PayeeMailingAddress":      {367, 40, Alphanumeric, Required},
 		"Blank3":                   {407, 40, Alphanumeric, Nullable},
 		"PayeeCity":                {447, 40, Alphanumeric, Required},
-		"PayeeState":               {487, 2, Alphanumeric, Required},
-		"PayeeZipCode":             {489, 9, Numeric, Applicable},
+		"PayeeState":               {487, 2, Alphanumeric, Applicable},
+		"PayeeZipCode":             {489, 9, Alphanumeric, Applicable},
 		"Blank4":                   {498, 1, Alphanumeric, Nullable},
 		"RecordSequenceNumber":     {499, 8, ZeroNumeric, Required},
 		"Blank5":                   {507, 36, Alphanumeric, Nullable},
@@ -155,24 +157,24 @@ var (
 		"RecordType":           {0, 1, Alphanumeric, Required},
 		"NumberPayees":         {1, 8, ZeroNumeric, Required},
 		"Blank1":               {9, 6, Alphanumeric, Nullable},
-		"ControlTotal1":        {15, 18, ZeroNumeric, Applicable},
-		"ControlTotal2":        {33, 18, ZeroNumeric, Applicable},
-		"ControlTotal3":        {51, 18, ZeroNumeric, Applicable},
-		"ControlTotal4":        {69, 18, ZeroNumeric, Applicable},
-		"ControlTotal5":        {87, 18, ZeroNumeric, Applicable},
-		"ControlTotal6":        {105, 18, ZeroNumeric, Applicable},
-		"ControlTotal7":        {123, 18, ZeroNumeric, Applicable},
-		"ControlTotal8":        {141, 18, ZeroNumeric, Applicable},
-		"ControlTotal9":        {159, 18, ZeroNumeric, Applicable},
-		"ControlTotalA":        {177, 18, ZeroNumeric, Applicable},
-		"ControlTotalB":        {195, 18, ZeroNumeric, Applicable},
-		"ControlTotalC":        {213, 18, ZeroNumeric, Applicable},
-		"ControlTotalD":        {231, 18, ZeroNumeric, Applicable},
-		"ControlTotalE":        {249, 18, ZeroNumeric, Applicable},
-		"ControlTotalF":        {267, 18, ZeroNumeric, Applicable},
-		"ControlTotalG":        {285, 18, ZeroNumeric, Applicable},
-		"ControlTotalH":        {303, 18, ZeroNumeric, Applicable},
-		"ControlTotalJ":        {321, 18, ZeroNumeric, Applicable},
+		"ControlTotal1":        {15, 18, SignedNumeric, Applicable},
+		"ControlTotal2":        {33, 18, SignedNumeric, Applicable},
+		"ControlTotal3":        {51, 18, SignedNumeric, Applicable},
+		"ControlTotal4":        {69, 18, SignedNumeric, Applicable},
+		"ControlTotal5":        {87, 18, SignedNumeric, Applicable},
+		"ControlTotal6":        {105, 18, SignedNumeric, Applicable},
+		"ControlTotal7":        {123, 18, SignedNumeric, Applicable},
+		"ControlTotal8":        {141, 18, SignedNumeric, Applicable},
+		"ControlTotal9":        {159, 18, SignedNumeric, Applicable},
+		"ControlTotalA":        {177, 18, SignedNumeric, Applicable},
+		"ControlTotalB":        {195, 18, SignedNumeric, Applicable},
+		"ControlTotalC":        {213, 18, SignedNumeric, Applicable},
+		"ControlTotalD":        {231, 18, SignedNumeric, Applicable},
+		"ControlTotalE":        {249, 18, SignedNumeric, Applicable},
+		"ControlTotalF":        {267, 18, SignedNumeric, Applicable},
+		"ControlTotalG":        {285, 18, SignedNumeric, Applicable},
+		"ControlTotalH":        {303, 18, SignedNumeric, Applicable},
+		"ControlTotalJ":        {321, 18, SignedNumeric, Applicable},
 		"Blank2":               {339, 160, Alphanumeric, Nullable},
 		"RecordSequenceNumber": {499, 8, ZeroNumeric, Required},
 		"Blank3":               {507, 241, Alphanumeric, Nullable},
@@ -183,24 +185,24 @@ var (
 		"RecordType":                  {0, 1, Alphanumeric, Required},
 		"NumberPayees":                {1, 8, ZeroNumeric, Required},
 		"Blank1":                      {9, 6, Alphanumeric, Nullable},
-		"ControlTotal1":               {15, 18, ZeroNumeric, Applicable},
-		"ControlTotal2":               {33, 18, ZeroNumeric, Applicable},
-		"ControlTotal3":               {51, 18, ZeroNumeric, Applicable},
-		"ControlTotal4":               {69, 18, ZeroNumeric, Applicable},
-		"ControlTotal5":               {87, 18, ZeroNumeric, Applicable},
-		"ControlTotal6":               {105, 18, ZeroNumeric, Applicable},
-		"ControlTotal7":               {123, 18, ZeroNumeric, Applicable},
-		"ControlTotal8":               {141, 18, ZeroNumeric, Applicable},
-		"ControlTotal9":               {159, 18, ZeroNumeric, Applicable},
-		"ControlTotalA":               {177, 18, ZeroNumeric, Applicable},
-		"ControlTotalB":               {195, 18, ZeroNumeric, Applicable},
-		"ControlTotalC":               {213, 18, ZeroNumeric, Applicable},
-		"ControlTotalD":               {231, 18, ZeroNumeric, Applicable},
-		"ControlTotalE":               {249, 18, ZeroNumeric, Applicable},
-		"ControlTotalF":               {267, 18, ZeroNumeric, Applicable},
-		"ControlTotalG":               {285, 18, ZeroNumeric, Applicable},
-		"ControlTotalH":               {303, 18, ZeroNumeric, Applicable},
-		"ControlTotalJ":               {321, 18, ZeroNumeric, Applicable},
+		"ControlTotal1":               {15, 18, SignedNumeric, Applicable},
+		"ControlTotal2":               {33, 18, SignedNumeric, Applicable},
+		"ControlTotal3":               {51, 18, SignedNumeric, Applicable},
+		"ControlTotal4":               {69, 18, SignedNumeric, Applicable},
+		"ControlTotal5":               {87, 18, SignedNumeric, Applicable},
+		"ControlTotal6":               {105, 18, SignedNumeric, Applicable},
+		"ControlTotal7":               {123, 18, SignedNumeric, Applicable},
+		"ControlTotal8":               {141, 18, SignedNumeric, Applicable},
+		"ControlTotal9":               {159, 18, SignedNumeric, Applicable},
+		"ControlTotalA":               {177, 18, SignedNumeric, Applicable},
+		"ControlTotalB":               {195, 18, SignedNumeric, Applicable},
+		"ControlTotalC":               {213, 18, SignedNumeric, Applicable},
+		"ControlTotalD":               {231, 18, SignedNumeric, Applicable},
+		"ControlTotalE":               {249, 18, SignedNumeric, Applicable},
+		"ControlTotalF":               {267, 18, SignedNumeric, Applicable},
+		"ControlTotalG":               {285, 18, SignedNumeric, Applicable},
+		"ControlTotalH":               {303, 18, SignedNumeric, Applicable},
+		"ControlTotalJ":               {321, 18, SignedNumeric, Applicable},
 		"Blank2":                      {339, 160, Alphanumeric, Nullable},
 		"RecordSequenceNumber":        {499, 8, ZeroNumeric, Required},
 		"Blank3":                      {507, 199, Alphanumeric, Nullable},
@@ -386,6 +388,28 @@ var (
 		"Blank4":                {179, 26, Alphanumeric, Nullable},
 		"Blank5":                {205, 2, Alphanumeric, Nullable},
 	}
+	// Record Layout Positions 544-750 for Form 1099-DA
+	Sub1099DALayout = map[string]SpecField{
+		"SecondTinNotice":                {0, 1, Alphanumeric, Applicable},
+		"NoncoveredSecurityIndicator":    {1, 1, Alphanumeric, Applicable},
+		"TypeGainLossIndicator":          {2, 1, Alphanumeric, Applicable},
+		"GrossProceedsIndicator":         {3, 1, Alphanumeric, Applicable},
+		"DateSoldDisposed":               {4, 8, Date, Applicable},
+		"DigitalAssetCode":               {12, 13, Alphanumeric, Applicable},
+		"DigitalAssetName":               {25, 39, Alphanumeric, Applicable},
+		"DateAcquired":                   {64, 8, Date, Applicable},
+		"LossNotAllowedIndicator":        {72, 1, Alphanumeric, Applicable},
+		"ApplicableCheckboxForm8949":     {73, 1, Alphanumeric, Applicable},
+		"ApplicableCheckboxCollectables": {74, 1, Alphanumeric, Applicable},
+		"FATCA":                          {75, 1, Alphanumeric, Applicable},
+		"ApplicableCheckboxQOF":          {76, 1, Alphanumeric, Applicable},
+		"NumberUnits":                    {77, 30, Alphanumeric, Applicable},
+		"Blank1":                         {107, 12, Alphanumeric, Nullable},
+		"SpecialDataEntries":             {119, 60, Alphanumeric, Applicable},
+		"StateIncomeTaxWithheld":         {179, 12, ZeroNumeric, Applicable},
+		"LocalIncomeTaxWithheld":         {191, 12, ZeroNumeric, Applicable},
+		"Blank2":                         {203, 4, Alphanumeric, Nullable},
+	}
 	// Record Layout Positions 544-750 for Form 1099-DIV
 	Sub1099DIVLayout = map[string]SpecField{
 		"SecondTinNotice":          {0, 1, Alphanumeric, Applicable},
@@ -538,6 +562,17 @@ var (
 		"Blank3":                   {179, 26, Alphanumeric, Nullable},
 		"Blank4":                   {205, 2, Alphanumeric, Nullable},
 	}
+	// Record Layout Positions 544-750 for Form 1099-QA
+	Sub1099QALayout = map[string]SpecField{
+		"Blank1":                     {0, 3, Alphanumeric, Nullable},
+		"ProgramTransferIndicator":   {3, 1, Alphanumeric, Applicable},
+		"AccountTerminatedIndicator": {4, 1, Alphanumeric, Applicable},
+		"DesignatedBeneficiary":      {5, 1, Alphanumeric, Applicable},
+		"Blank2":                     {6, 113, Alphanumeric, Nullable},
+		"SpecialDataEntries":         {119, 60, Alphanumeric, Applicable},
+		"Blank3":                     {179, 26, Alphanumeric, Nullable},
+		"Blank4":                     {205, 2, Alphanumeric, Nullable},
+	}
 	// Record Layout Positions 544-750 for Form 1099-R
 	Sub1099RLayout = map[string]SpecField{
 		"Blank1":                              {0, 1, Alphanumeric, Nullable},
@@ -644,6 +679,15 @@ var (
 		"Blank2":             {179, 26, Alphanumeric, Nullable},
 		"Blank3":             {205, 2, Alphanumeric, Nullable},
 	}
+	// Record Layout Positions 544-750 for Form 5498-QA
+	Sub5498QALayout = map[string]SpecField{
+		"Blank1":             {0, 3, Alphanumeric, Nullable},
+		"DisabilityCode":     {3, 1, Alphanumeric, Applicable},
+		"Blank2":             {4, 115, Alphanumeric, Nullable},
+		"SpecialDataEntries": {119, 60, Alphanumeric, Applicable},
+		"Blank3":             {179, 26, Alphanumeric, Nullable},
+		"Blank4":             {205, 2, Alphanumeric, Nullable},
+	}
 	// Record Layout Positions 544-750 for Form 5498-SA
 	Sub5498SALayout = map[string]SpecField{
 		"Blank1":                        {0, 3, Alphanumeric, Nullable},
@@ -675,6 +719,56 @@ var (
 	}
 )
 
+// Record layouts by record type
+var RecordLayouts = map[string]map[string]SpecField{
+	TRecordType: TRecordLayout,
+	ARecordType: ARecordLayout,
+	BRecordType: BRecordLayout,
+	CRecordType: CRecordLayout,
+	KRecordType: KRecordLayout,
+	FRecordType: FRecordLayout,
+}
+
+// Extension block layouts (positions 544-750 of payee “B” record) by type of return
+var SubRecordLayouts = map[string]map[string]SpecField{
+	Sub1097BtcType:  Sub1097BTCLayout,
+	Sub1098Type:     Sub1098Layout,
+	Sub1098CType:    Sub1098CLayout,
+	Sub1098EType:    Sub1098ELayout,
+	Sub1098FType:    Sub1098FLayout,
+	Sub1098QType:    Sub1098QLayout,
+	Sub1098TType:    Sub1098TLayout,
+	Sub1099AType:    Sub1099ALayout,
+	Sub1099BType:    Sub1099BLayout,
+	Sub1099CType:    Sub1099CLayout,
+	Sub1099CapType:  Sub1099CAPLayout,
+	Sub1099DaType:   Sub1099DALayout,
+	Sub1099DivType:  Sub1099DIVLayout,
+	Sub1099GType:    Sub1099GLayout,
+	Sub1099HType:    Sub1099HLayout,
+	Sub1099IntType:  Sub1099INTLayout,
+	Sub1099KType:    Sub1099KLayout,
+	Sub1099LsType:   Sub1099LSLayout,
+	Sub1099LtcType:  Sub1099LTCLayout,
+	Sub1099MiscType: Sub1099MISCLayout,
+	Sub1099NecType:  Sub1099NECLayout,
+	Sub1099OidType:  Sub1099OIDLayout,
+	Sub1099PatrType: Sub1099PATRLayout,
+	Sub1099QType:    Sub1099QLayout,
+	Sub1099QaType:   Sub1099QALayout,
+	Sub1099RType:    Sub1099RLayout,
+	Sub1099SType:    Sub1099SLayout,
+	Sub1099SaType:   Sub1099SALayout,
+	Sub1099SbType:   Sub1099SBLayout,
+	Sub3921Type:     Sub3921Layout,
+	Sub3922Type:     Sub3922Layout,
+	Sub5498Type:     Sub5498Layout,
+	Sub5498EsaType:  Sub5498ESALayout,
+	Sub5498QaType:   Sub5498QALayout,
+	Sub5498SaType:   Sub5498SALayout,
+	SubW2GType:      SubW2GLayout,
+}
+
 func ToSpecifications(fieldsFormat map[string]SpecField) []SpecRecord {
 	var records []SpecRecord
 	for key, field := range fieldsFormat {
@@ -0,0 +1,89 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package config
+
+// FilingThreshold is the smallest payment amount of an amount code that requires an information return
+type FilingThreshold struct {
+	// TaxYear is the first tax year of the threshold, it applies until a later threshold of the same box
+	TaxYear int
+	// AmountCode is the payment amount code of the box
+	AmountCode string
+	// Amount is the smallest amount in cents
+	Amount int
+	// Transactions is the smallest number of payment transactions (1099-K), zero if not applicable
+	Transactions int
+	// TypeWagerCodes limits the threshold to types of wager (W-2G), empty for all payees
+	TypeWagerCodes []string
+}
+
+// FilingThresholds of types of return
+//
+// Boxes without a threshold require a return for any amount. For 1099-NEC and
+// 1099-MISC the $600 threshold became $2,000 for payments after 2025. The
+// 1099-K threshold is more than $20,000 and more than 200 transactions.
+// W-2G thresholds are net of the wager, which isn't part of the record.
+var FilingThresholds = map[string][]FilingThreshold{
+	"1099-NEC": {
+		{TaxYear: DefaultTaxYear, AmountCode: "1", Amount: 60000},
+		{TaxYear: 2026, AmountCode: "1", Amount: 200000},
+	},
+	"1099-MISC": {
+		{TaxYear: DefaultTaxYear, AmountCode: "1", Amount: 60000},
+		{TaxYear: DefaultTaxYear, AmountCode: "2", Amount: 1000},
+		{TaxYear: DefaultTaxYear, AmountCode: "3", Amount: 60000},
+		{TaxYear: DefaultTaxYear, AmountCode: "5", Amount: 60000},
+		{TaxYear: DefaultTaxYear, AmountCode: "6", Amount: 60000},
+		{TaxYear: DefaultTaxYear, AmountCode: "7", Amount: 60000},
+		{TaxYear: DefaultTaxYear, AmountCode: "8", Amount: 1000},
+		{TaxYear: DefaultTaxYear, AmountCode: "A", Amount: 60000},
+		{TaxYear: DefaultTaxYear, AmountCode: "C", Amount: 60000},
+		{TaxYear: 2026, AmountCode: "1", Amount: 200000},
+		{TaxYear: 2026, AmountCode: "3", Amount: 200000},
+		{TaxYear: 2026, AmountCode: "5", Amount: 200000},
+		{TaxYear: 2026, AmountCode: "6", Amount: 200000},
+		{TaxYear: 2026, AmountCode: "A", Amount: 200000},
+		{TaxYear: 2026, AmountCode: "C", Amount: 200000},
+	},
+	"1099-INT": {
+		{TaxYear: DefaultTaxYear, AmountCode: "1", Amount: 1000},
+		{TaxYear: DefaultTaxYear, AmountCode: "3", Amount: 1000},
+		{TaxYear: DefaultTaxYear, AmountCode: "8", Amount: 1000},
+	},
+	"1099-DIV": {
+		{TaxYear: DefaultTaxYear, AmountCode: "1", Amount: 1000},
+		{TaxYear: DefaultTaxYear, AmountCode: "2", Amount: 1000},
+		{TaxYear: DefaultTaxYear, AmountCode: "3", Amount: 1000},
+		{TaxYear: DefaultTaxYear, AmountCode: "5", Amount: 1000},
+		{TaxYear: DefaultTaxYear, AmountCode: "9", Amount: 1000},
+		{TaxYear: DefaultTaxYear, AmountCode: "D", Amount: 60000},
+		{TaxYear: DefaultTaxYear, AmountCode: "E", Amount: 60000},
+		{TaxYear: DefaultTaxYear, AmountCode: "F", Amount: 1000},
+	},
+	"1099-K": {
+		{TaxYear: DefaultTaxYear, AmountCode: "1", Amount: 2000001, Transactions: 201},
+	},
+	"W-2G": {
+		{TaxYear: DefaultTaxYear, AmountCode: "1", Amount: 60000, TypeWagerCodes: []string{"1", "2", "3", "4", "9"}},
+		{TaxYear: DefaultTaxYear, AmountCode: "1", Amount: 150000, TypeWagerCodes: []string{"5"}},
+		{TaxYear: DefaultTaxYear, AmountCode: "1", Amount: 120000, TypeWagerCodes: []string{"6", "7"}},
+		{TaxYear: DefaultTaxYear, AmountCode: "1", Amount: 500000, TypeWagerCodes: []string{"8"}},
+		{TaxYear: 2026, AmountCode: "1", Amount: 200000, TypeWagerCodes: []string{"5", "6", "7"}},
+	},
+}
+
+// GrossAmountCodes are the amount codes of gross payments of types of return,
+// which federal income tax withheld should not exceed
+//
+// Types of return without an entry use all amount codes other than federal income tax withheld.
+var GrossAmountCodes = map[string][]string{
+	"1099-NEC":  {"1"},
+	"1099-MISC": {"1", "2", "3", "5", "6", "7", "8", "A", "B", "C", "E"},
+	"1099-INT":  {"1", "3", "8"},
+	"1099-DA":   {"1"},
+	"1099-DIV":  {"1", "3", "9", "D", "E", "F"},
+	"1099-K":    {"1"},
+	"1099-R":    {"1"},
+	"W-2G":      {"1"},
+}
@@ -0,0 +1,33 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package file
+
+import (
+	"fmt"
+
+	"github.com/moov-io/irs/pkg/utils"
+)
+
+// NormalizeAddresses normalizes addresses of payer “A” and payee “B” records, see utils.NormalizeAddress
+//
+// The error of the first address that can't be normalized names its record sequence number.
+func NormalizeAddresses(f File) error {
+	if f == nil {
+		return utils.ErrInvalidFile
+	}
+	for _, person := range f.Payers() {
+		if payer := person.PayerRecord(); payer != nil {
+			if err := payer.NormalizeAddress(); err != nil {
+				return fmt.Errorf("%w (record %d)", err, payer.SequenceNumber())
+			}
+		}
+		for _, payee := range person.PayeeRecords() {
+			if err := payee.NormalizeAddress(); err != nil {
+				return fmt.Errorf("%w (record %d)", err, payee.SequenceNumber())
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package file
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+
+	"gopkg.in/check.v1"
+
+	"github.com/moov-io/irs/pkg/config"
+	"github.com/moov-io/irs/pkg/utils"
+)
+
+func (t *FileTest) TestNormalizeAddresses(c *check.C) {
+	f, err := CreateFile(t.oneTransactionJson)
+	c.Assert(err, check.IsNil)
+	payer := f.Payers()[0].PayerRecord()
+	payer.ForeignEntityIndicator = ""
+	payer.PayerCity = "New York"
+	payer.PayerState = "new york"
+	payee := f.Payers()[0].PayeeRecords()[0]
+	payee.PayeeMailingAddress = "5678 Industry Place"
+	payee.PayeeState = "California"
+	payee.PayeeZipCode = "92222-1234"
+
+	c.Assert(NormalizeAddresses(f), check.IsNil)
+	c.Assert(payer.PayerCity, check.Equals, "NEW YORK")
+	c.Assert(payer.PayerState, check.Equals, "NY")
+	c.Assert(payee.PayeeMailingAddress, check.Equals, "5678 INDUSTRY PLACE")
+	c.Assert(payee.PayeeState, check.Equals, "CA")
+	c.Assert(payee.PayeeZipCode, check.Equals, "922221234")
+	c.Assert(f.Validate(), check.IsNil)
+
+	payee.ForeignCountryIndicator = config.ForeignCountryIndicator
+	payee.PayeeCity, payee.PayeeState, payee.PayeeZipCode = "Toronto", "Ontario", "M5E 1E5 Canada"
+	c.Assert(NormalizeAddresses(f), check.IsNil)
+	c.Assert(payee.PayeeCity, check.Equals, "TORONTO ONTARIO M5E 1E5 CANADA")
+	c.Assert(payee.PayeeState, check.Equals, "")
+	c.Assert(f.Validate(), check.IsNil)
+
+	// foreign addresses survive a round trip through the irs format
+	ascii, err := CreateFile(f.Ascii())
+	c.Assert(err, check.IsNil)
+	c.Assert(ascii.Payers()[0].PayeeRecords()[0].PayeeCity, check.Equals, "TORONTO ONTARIO M5E 1E5 CANADA")
+
+	payee.ForeignCountryIndicator = ""
+	payee.PayeeState = "Atlantis"
+	err = NormalizeAddresses(f)
+	c.Assert(err, check.ErrorMatches, "is an invalid value of state \\(record 3\\)")
+
+	c.Assert(NormalizeAddresses(nil), check.Equals, utils.ErrInvalidFile)
+}
+
+// matchZipCodes sets ZIP Codes of California to the payees, the payees of the fixtures
+// live in California with ZIP Codes of Virginia
+func matchZipCodes(f File) {
+	for _, person := range f.Payers() {
+		for _, payee := range person.PayeeRecords() {
+			payee.PayeeZipCode = "92222"
+		}
+	}
+}
+
+func (t *FileTest) TestZipCodeStateWarnings(c *check.C) {
+	f, err := CreateFile(t.oneTransactionZipCodeStateJson)
+	c.Assert(err, check.IsNil)
+	c.Assert(f.Validate(), check.IsNil)
+	report := f.ValidateAll()
+	c.Assert(report.Valid(), check.Equals, true)
+	c.Assert(report.Count(SeverityWarning), check.Equals, 1)
+	c.Assert(report.Entries[0].RecordType, check.Equals, config.BRecordType)
+	c.Assert(report.Entries[0].SequenceNumber, check.Equals, 4)
+	c.Assert(report.Entries[0].FieldName, check.Equals, "PayeeZipCode")
+	c.Assert(report.Entries[0].Code, check.Equals, utils.CodeZipCodeState)
+	c.Assert(report.Entries[0].Message, check.Equals, "has zip code 22222, which isn't in state CA")
+
+	payee := f.Payers()[0].PayeeRecords()[1]
+
+	// foreign addresses aren't checked
+	payee.ForeignCountryIndicator = config.ForeignCountryIndicator
+	report = f.ValidateAll()
+	c.Assert(report.Count(SeverityWarning), check.Equals, 0)
+}
+
+func (t *FileTest) TestImportCSVWithNormalizedAddresses(c *check.C) {
+	mapping, data := t.csvMapping(c)
+	mapping.NormalizeAddresses = true
+	data = []byte(strings.Replace(string(data), "MOON,CA,22222", "Moon,California,22222-0001", 1))
+
+	f, err := ImportCSV(bytes.NewReader(data), mapping)
+	c.Assert(err, check.IsNil)
+	payee := f.Payers()[0].PayeeRecords()[0]
+	c.Assert(payee.PayeeCity, check.Equals, "MOON")
+	c.Assert(payee.PayeeState, check.Equals, "CA")
+	c.Assert(payee.PayeeZipCode, check.Equals, "222220001")
+
+	data = []byte(strings.Replace(string(data), "Moon,California", "Moon,Atlantis", 1))
+	_, err = ImportCSV(bytes.NewReader(data), mapping)
+	var csvErrs CSVErrors
+	c.Assert(errors.As(err, &csvErrs), check.Equals, true)
+	c.Assert(len(csvErrs), check.Equals, 1)
+	c.Assert(csvErrs[0].Line, check.Equals, 2)
+}
@@ -0,0 +1,107 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package file
+
+import (
+	"github.com/moov-io/irs/pkg/config"
+	"github.com/moov-io/irs/pkg/records"
+	"github.com/moov-io/irs/pkg/utils"
+)
+
+// Builder constructs a file from business data of records
+//
+// Record types, counts, control totals and record sequence numbers are filled
+// by Build, so callers only supply “T”, “A”, “B” and “K” records.
+// The first error is kept and returned by Build.
+type Builder struct {
+	file   *fileInstance
+	person *PaymentPerson
+	err    error
+}
+
+// NewBuilder returns a new file builder
+func NewBuilder() *Builder {
+	return &Builder{
+		file: &fileInstance{
+			Transmitter:    &records.TRecord{RecordType: config.TRecordType},
+			EndTransmitter: &records.FRecord{RecordType: config.FRecordType},
+		},
+	}
+}
+
+// Transmitter sets transmitter “T” record
+func (b *Builder) Transmitter(transmitter *records.TRecord) *Builder {
+	if b.err != nil {
+		return b
+	}
+	if transmitter == nil {
+		b.err = utils.ErrInvalidFile
+		return b
+	}
+	transmitter.RecordType = config.TRecordType
+	b.file.Transmitter = transmitter
+	return b
+}
+
+// AddPayer starts new payment person with payer “A” record
+func (b *Builder) AddPayer(payer *records.ARecord) *Builder {
+	if b.err != nil {
+		return b
+	}
+	if payer == nil {
+		b.err = utils.ErrNonExistPayer
+		return b
+	}
+	payer.RecordType = config.ARecordType
+	b.person = NewPaymentPerson(payer)
+	b.err = b.file.AddPayer(b.person)
+	return b
+}
+
+// AddPayee appends payee “B” record to current payment person
+func (b *Builder) AddPayee(payee *records.BRecord) *Builder {
+	if b.err != nil {
+		return b
+	}
+	if b.person == nil {
+		b.err = utils.ErrNonExistPayer
+		return b
+	}
+	if payee != nil {
+		payee.RecordType = config.BRecordType
+	}
+	b.err = b.person.AddPayee(payee)
+	return b
+}
+
+// AddState appends state totals “K” record to current payment person
+func (b *Builder) AddState(state *records.KRecord) *Builder {
+	if b.err != nil {
+		return b
+	}
+	if b.person == nil {
+		b.err = utils.ErrNonExistPayer
+		return b
+	}
+	if state != nil {
+		state.RecordType = config.KRecordType
+	}
+	b.err = b.person.AddState(state)
+	return b
+}
+
+// Build finalizes and validates the file
+func (b *Builder) Build() (File, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	if err := b.file.Finalize(); err != nil {
+		return nil, err
+	}
+	if err := b.file.Validate(); err != nil {
+		return nil, err
+	}
+	return b.file, nil
+}
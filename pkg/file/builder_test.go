@@ -0,0 +1,97 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package file
+
+import (
+	"gopkg.in/check.v1"
+
+	"github.com/moov-io/irs/pkg/config"
+	"github.com/moov-io/irs/pkg/records"
+	"github.com/moov-io/irs/pkg/subrecords"
+	"github.com/moov-io/irs/pkg/utils"
+)
+
+func (t *FileTest) TestBuilder(c *check.C) {
+	f, err := CreateFile(t.oneTransactionJson)
+	c.Assert(err, check.IsNil)
+	c.Assert(len(f.Payers()), check.Equals, 1)
+	person := f.Payers()[0]
+
+	builder := NewBuilder().Transmitter(f.TransmitterRecord()).AddPayer(person.PayerRecord())
+	for _, payee := range person.PayeeRecords() {
+		payee.RecordType = ""
+		builder.AddPayee(payee)
+	}
+	for _, state := range person.StateRecords() {
+		builder.AddState(state)
+	}
+	built, err := builder.Build()
+	c.Assert(err, check.IsNil)
+	c.Assert(built.Validate(), check.IsNil)
+
+	c.Assert(built.TransmitterRecord().TotalNumberPayees, check.Equals, 2)
+	c.Assert(built.EndTransmitterRecord().NumberPayerRecords, check.Equals, 1)
+	c.Assert(len(built.Payers()), check.Equals, 1)
+	builtPerson := built.Payers()[0]
+	c.Assert(builtPerson.EndPayerRecord().ControlTotal7, check.Equals, 1400)
+	c.Assert(len(builtPerson.PayeeRecords()), check.Equals, 2)
+	c.Assert(builtPerson.PayeeRecords()[0].Extension().Type(), check.Equals, config.Sub1099MiscType)
+	c.Assert(len(builtPerson.StateRecords()), check.Equals, 1)
+}
+
+func (t *FileTest) TestBuilderWithNewPayee(c *check.C) {
+	f, err := CreateFile(t.oneTransactionJson)
+	c.Assert(err, check.IsNil)
+	person := f.Payers()[0]
+	source := person.PayeeRecords()[0]
+
+	payee := &records.BRecord{}
+	utils.CopyStruct(source, payee)
+	c.Assert(payee.Extension(), check.IsNil)
+
+	newPerson := NewPaymentPerson(person.PayerRecord())
+	c.Assert(newPerson.AddPayee(payee), check.IsNil)
+	c.Assert(payee.Extension(), check.NotNil)
+	c.Assert(payee.TypeOfReturn(), check.Equals, config.Sub1099MiscType)
+	c.Assert(payee.SetExtension(source.Extension()), check.IsNil)
+
+	built, err := NewBuilder().
+		Transmitter(f.TransmitterRecord()).
+		AddPayer(person.PayerRecord()).
+		AddPayee(payee).
+		AddState(person.StateRecords()[0]).
+		Build()
+	c.Assert(err, check.IsNil)
+	c.Assert(built.Payers()[0].EndPayerRecord().NumberPayees, check.Equals, 1)
+
+	extension, err := subrecords.NewSubRecord(config.Sub1099NecType)
+	c.Assert(err, check.IsNil)
+	c.Assert(payee.SetExtension(extension), check.IsNil)
+	c.Assert(payee.TypeOfReturn(), check.Equals, config.Sub1099NecType)
+	c.Assert(payee.SetExtension(nil), check.NotNil)
+}
+
+func (t *FileTest) TestBuilderWithError(c *check.C) {
+	_, err := NewBuilder().AddPayee(&records.BRecord{}).Build()
+	c.Assert(err, check.Equals, utils.ErrNonExistPayer)
+
+	_, err = NewBuilder().AddState(&records.KRecord{}).Build()
+	c.Assert(err, check.Equals, utils.ErrNonExistPayer)
+
+	_, err = NewBuilder().Transmitter(nil).AddPayer(&records.ARecord{}).Build()
+	c.Assert(err, check.Equals, utils.ErrInvalidFile)
+
+	_, err = NewBuilder().AddPayer(nil).Build()
+	c.Assert(err, check.Equals, utils.ErrNonExistPayer)
+
+	_, err = NewBuilder().AddPayer(&records.ARecord{}).AddPayee(nil).Build()
+	c.Assert(err, check.NotNil)
+
+	_, err = NewBuilder().AddPayer(&records.ARecord{TypeOfReturn: "A"}).AddState(nil).Build()
+	c.Assert(err, check.NotNil)
+
+	_, err = NewBuilder().Build()
+	c.Assert(err, check.NotNil)
+}
@@ -0,0 +1,236 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package file
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/moov-io/irs/pkg/config"
+	"github.com/moov-io/irs/pkg/records"
+	"github.com/moov-io/irs/pkg/utils"
+)
+
+// Correct returns a correction file with the differences between original and amended files
+//
+// Payees are matched by TIN, payer account number and type of return.
+// Changed money amounts, codes, names or addresses are one-transaction (Type 1)
+// corrections: the amended payee with corrected return indicator “G”.
+// Original payees missing from the amended file are reported as Type 1
+// corrections with zero payment amounts.
+// A changed TIN (matched by account number) or type of return (matched by TIN
+// and account number) is a two-transaction (Type 2) correction: the original
+// payee with indicator “G” and zero payment amounts, then the amended payee
+// with indicator “C”. “G” and “C” payees are reported under separate payer
+// “A” records, and the file is finalized and validated.
+func Correct(original, amended File) (File, error) {
+	if original == nil || amended == nil || amended.TransmitterRecord() == nil {
+		return nil, utils.ErrInvalidFile
+	}
+
+	originals := correctionEntries(original)
+	groups := &correctionGroups{}
+
+	for _, entry := range correctionEntries(amended) {
+		match := originals.find(func(o *correctionEntry) bool {
+			return o.payee.TIN == entry.payee.TIN &&
+				o.payee.PayerAccountNumber == entry.payee.PayerAccountNumber &&
+				o.payee.TypeOfReturn() == entry.payee.TypeOfReturn()
+		})
+		if match != nil {
+			changed, err := payeeChanged(match.payee, entry.payee)
+			if err != nil {
+				return nil, err
+			}
+			if changed {
+				if err = groups.add(entry.payer, entry.payee, config.CorrectedReturnIndicatorG, false); err != nil {
+					return nil, err
+				}
+			}
+			continue
+		}
+
+		// incorrect TIN or type of return
+		match = originals.find(func(o *correctionEntry) bool {
+			if o.payee.PayerAccountNumber != entry.payee.PayerAccountNumber {
+				return false
+			}
+			if o.payee.TypeOfReturn() == entry.payee.TypeOfReturn() {
+				return len(entry.payee.PayerAccountNumber) > 0
+			}
+			return o.payee.TIN == entry.payee.TIN
+		})
+		if match == nil {
+			return nil, fmt.Errorf("%w (TIN %s)", utils.ErrUnmatchedPayee, entry.payee.TIN)
+		}
+		if err := groups.add(match.payer, match.payee, config.CorrectedReturnIndicatorG, true); err != nil {
+			return nil, err
+		}
+		if err := groups.add(entry.payer, entry.payee, config.CorrectedReturnIndicatorC, false); err != nil {
+			return nil, err
+		}
+	}
+
+	// returns that should not have been filed
+	for _, entry := range originals {
+		if !entry.used {
+			if err := groups.add(entry.payer, entry.payee, config.CorrectedReturnIndicatorG, true); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if len(groups.list) == 0 {
+		return nil, utils.ErrNoCorrections
+	}
+	return groups.build(amended.TransmitterRecord())
+}
+
+type correctionEntry struct {
+	payer *records.ARecord
+	payee *records.BRecord
+	used  bool
+}
+
+type correctionEntryList []*correctionEntry
+
+func correctionEntries(f File) correctionEntryList {
+	var entries correctionEntryList
+	for _, person := range f.Payers() {
+		payer := person.PayerRecord()
+		if payer == nil {
+			continue
+		}
+		for _, payee := range person.PayeeRecords() {
+			entries = append(entries, &correctionEntry{payer: payer, payee: payee})
+		}
+	}
+	return entries
+}
+
+// find returns the first unused entry that matches and marks it used
+func (l correctionEntryList) find(match func(*correctionEntry) bool) *correctionEntry {
+	for _, entry := range l {
+		if !entry.used && match(entry) {
+			entry.used = true
+			return entry
+		}
+	}
+	return nil
+}
+
+type correctionGroup struct {
+	payer     *records.ARecord
+	indicator string
+	payees    []*records.BRecord
+}
+
+type correctionGroups struct {
+	list []*correctionGroup
+}
+
+// add appends a copy of the payee with the indicator to the group of the payer
+func (g *correctionGroups) add(payer *records.ARecord, payee *records.BRecord, indicator string, zeroAmounts bool) error {
+	corrected, err := copyPayee(payee)
+	if err != nil {
+		return err
+	}
+	corrected.CorrectedReturnIndicator = indicator
+	if zeroAmounts {
+		for _, code := range amountCodes {
+			value, err := utils.GetField(corrected, "PaymentAmount"+code)
+			if err != nil {
+				return err
+			}
+			value.SetInt(0)
+		}
+	}
+
+	for _, group := range g.list {
+		if group.payer == payer && group.indicator == indicator {
+			group.payees = append(group.payees, corrected)
+			return nil
+		}
+	}
+	g.list = append(g.list, &correctionGroup{
+		payer:     payer,
+		indicator: indicator,
+		payees:    []*records.BRecord{corrected},
+	})
+	return nil
+}
+
+func (g *correctionGroups) build(transmitter *records.TRecord) (File, error) {
+	tRecord := &records.TRecord{}
+	utils.CopyStruct(transmitter, tRecord)
+
+	builder := NewBuilder().Transmitter(tRecord)
+	for _, group := range g.list {
+		aRecord := &records.ARecord{}
+		utils.CopyStruct(group.payer, aRecord)
+		builder.AddPayer(aRecord)
+
+		states := make([]string, 0)
+		existed := make(map[string]bool)
+		for _, payee := range group.payees {
+			builder.AddPayee(payee)
+			if state, ok := stateAbbreviation(payee.FederalState()); ok && !existed[state] {
+				existed[state] = true
+				states = append(states, state)
+			}
+		}
+
+		// state totals of the combined federal/state filing program
+		if aRecord.CombinedFSFilingProgram == config.FSFilingProgramApproved {
+			for _, state := range states {
+				builder.AddState(&records.KRecord{CombinedFederalStateCode: state})
+			}
+		}
+	}
+	return builder.Build()
+}
+
+// payeeChanged returns true if the payees differ in anything other than sequence number and corrected return indicator
+func payeeChanged(original, amended *records.BRecord) (bool, error) {
+	originalCopy, err := copyPayee(original)
+	if err != nil {
+		return false, err
+	}
+	amendedCopy, err := copyPayee(amended)
+	if err != nil {
+		return false, err
+	}
+	for _, payee := range []*records.BRecord{originalCopy, amendedCopy} {
+		payee.CorrectedReturnIndicator = ""
+		payee.SetSequenceNumber(0)
+	}
+	return !bytes.Equal(originalCopy.Ascii(), amendedCopy.Ascii()), nil
+}
+
+// copyPayee returns a copy of the payee including its extension block
+func copyPayee(payee *records.BRecord) (*records.BRecord, error) {
+	record, err := records.NewBRecord(payee.TypeOfReturn())
+	if err != nil {
+		return nil, err
+	}
+	if err = record.Parse(payee.Ascii()); err != nil {
+		return nil, err
+	}
+	return record.(*records.BRecord), nil
+}
+
+// stateAbbreviation returns the state abbreviation of a combined federal/state code
+func stateAbbreviation(code int) (string, bool) {
+	name, ok := config.ParticipateStateCodes[code]
+	if !ok {
+		return "", false
+	}
+	for abbreviation, state := range config.StateAbbreviationCodes {
+		if state == name {
+			return abbreviation, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,109 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package file
+
+import (
+	"errors"
+
+	"gopkg.in/check.v1"
+
+	"github.com/moov-io/irs/pkg/config"
+	"github.com/moov-io/irs/pkg/records"
+	"github.com/moov-io/irs/pkg/utils"
+)
+
+func (t *FileTest) correctionFiles(c *check.C) (File, File) {
+	original, err := CreateFile(t.oneTransactionJson)
+	c.Assert(err, check.IsNil)
+	amended, err := CreateFile(t.oneTransactionJson)
+	c.Assert(err, check.IsNil)
+	for _, f := range []File{original, amended} {
+		for index, payee := range f.Payers()[0].PayeeRecords() {
+			payee.PayerAccountNumber = []string{"ACCOUNT1", "ACCOUNT2"}[index]
+		}
+	}
+	return original, amended
+}
+
+func (t *FileTest) TestCorrectType1(c *check.C) {
+	original, amended := t.correctionFiles(c)
+	payee := amended.Payers()[0].PayeeRecords()[0]
+	payee.PaymentAmount7 = 900
+	payee.FirstPayeeNameLine = "SPACELY SPROCKETS"
+
+	f, err := Correct(original, amended)
+	c.Assert(err, check.IsNil)
+	c.Assert(f.Validate(), check.IsNil)
+	c.Assert(len(f.Payers()), check.Equals, 1)
+
+	person := f.Payers()[0]
+	c.Assert(len(person.PayeeRecords()), check.Equals, 1)
+	corrected := person.PayeeRecords()[0]
+	c.Assert(corrected.CorrectedReturnIndicator, check.Equals, config.CorrectedReturnIndicatorG)
+	c.Assert(corrected.PaymentAmount7, check.Equals, 900)
+	c.Assert(corrected.FirstPayeeNameLine, check.Equals, "SPACELY SPROCKETS")
+	c.Assert(person.EndPayerRecord().ControlTotal7, check.Equals, 900)
+	c.Assert(len(person.StateRecords()), check.Equals, 1)
+
+	// amended payees are not changed
+	c.Assert(payee.CorrectedReturnIndicator, check.Equals, "")
+}
+
+func (t *FileTest) TestCorrectType2(c *check.C) {
+	original, amended := t.correctionFiles(c)
+	payee := amended.Payers()[0].PayeeRecords()[1]
+	payee.TIN = "111223333"
+
+	f, err := Correct(original, amended)
+	c.Assert(err, check.IsNil)
+	c.Assert(f.Validate(), check.IsNil)
+	c.Assert(len(f.Payers()), check.Equals, 2)
+
+	first := f.Payers()[0].PayeeRecords()
+	c.Assert(len(first), check.Equals, 1)
+	c.Assert(first[0].CorrectedReturnIndicator, check.Equals, config.CorrectedReturnIndicatorG)
+	c.Assert(first[0].TIN, check.Equals, original.Payers()[0].PayeeRecords()[1].TIN)
+	c.Assert(first[0].PaymentCodes(), check.Equals, "")
+
+	second := f.Payers()[1].PayeeRecords()
+	c.Assert(len(second), check.Equals, 1)
+	c.Assert(second[0].CorrectedReturnIndicator, check.Equals, config.CorrectedReturnIndicatorC)
+	c.Assert(second[0].TIN, check.Equals, "111223333")
+	c.Assert(second[0].PaymentAmount7, check.Equals, payee.PaymentAmount7)
+}
+
+func (t *FileTest) TestCorrectReturnNotFiled(c *check.C) {
+	original, amended := t.correctionFiles(c)
+	person := amended.Payers()[0]
+	person.Payees = person.Payees[:1]
+
+	f, err := Correct(original, amended)
+	c.Assert(err, check.IsNil)
+	c.Assert(len(f.Payers()), check.Equals, 1)
+	payees := f.Payers()[0].PayeeRecords()
+	c.Assert(len(payees), check.Equals, 1)
+	c.Assert(payees[0].CorrectedReturnIndicator, check.Equals, config.CorrectedReturnIndicatorG)
+	c.Assert(payees[0].PayerAccountNumber, check.Equals, "ACCOUNT2")
+	c.Assert(payees[0].PaymentCodes(), check.Equals, "")
+}
+
+func (t *FileTest) TestCorrectWithError(c *check.C) {
+	original, amended := t.correctionFiles(c)
+	_, err := Correct(original, amended)
+	c.Assert(err, check.Equals, utils.ErrNoCorrections)
+
+	_, err = Correct(nil, amended)
+	c.Assert(err, check.Equals, utils.ErrInvalidFile)
+
+	payee := amended.Payers()[0].PayeeRecords()[1]
+	payee.TIN = "111223333"
+	payee.PayerAccountNumber = "ACCOUNT3"
+	_, err = Correct(original, amended)
+	c.Assert(errors.Is(err, utils.ErrUnmatchedPayee), check.Equals, true)
+
+	amended.Payers()[0].Payees = append(amended.Payers()[0].Payees, &records.BRecord{})
+	_, err = Correct(original, amended)
+	c.Assert(err, check.NotNil)
+}
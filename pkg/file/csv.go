@@ -0,0 +1,594 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package file
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/moov-io/irs/pkg/config"
+	"github.com/moov-io/irs/pkg/records"
+	"github.com/moov-io/irs/pkg/subrecords"
+	"github.com/moov-io/irs/pkg/utils"
+)
+
+// Prefixes of csv mapping targets
+const (
+	CSVPayerPrefix = "ARecord."
+	CSVPayeePrefix = "BRecord."
+)
+
+// csvDateFormats are accepted formats of date columns
+var csvDateFormats = []string{config.DateFormat, "2006-01-02", "01/02/2006"}
+
+// CSVMapping describes how columns of a csv file are imported as payer “A” and payee “B” records
+//
+// Columns maps column headers to targets:
+//   - “ARecord.<Field>” sets a field of the payer “A” record, rows with equal payer columns belong to one payer
+//   - “BRecord.<Field>” or “<Field>” sets a field of the payee “B” record
+//   - a box name of config.AmountCodes for the type of return sets the payment amount of the box
+//   - “<Extension>.<Field>” such as “Sub1099NEC.DirectSalesIndicator” sets a field of the extension block
+//
+// Payment amounts are dollars with optional cents (“1,234.56”) unless AmountsInCents is set.
+// Other numeric fields take Publication 1220 values.
+type CSVMapping struct {
+	// TypeOfReturn is the form name (“1099-NEC”) or type of return code (“NE”) of all payees
+	TypeOfReturn string `json:"type_of_return"`
+	// AmountsInCents selects payment amounts in cents
+	AmountsInCents bool `json:"amounts_in_cents,omitempty"`
+	// Transmitter is the transmitter “T” record of the file
+	Transmitter records.TRecord `json:"transmitter"`
+	// Payer contains default values of payer “A” records
+	Payer records.ARecord `json:"payer"`
+	// Columns maps column headers to targets
+	Columns map[string]string `json:"columns"`
+	// NormalizeAddresses selects normalization of payer and payee addresses, see utils.NormalizeAddress
+	NormalizeAddresses bool `json:"normalize_addresses,omitempty"`
+}
+
+// CSVError is an error of a row of the imported csv file
+type CSVError struct {
+	// Line is the one-based line number of the row
+	Line int
+	// Column is the column header, empty for errors of the whole row
+	Column string
+	// Err is the error of the row
+	Err error
+}
+
+func (e *CSVError) Error() string {
+	if len(e.Column) > 0 {
+		return fmt.Sprintf("line %d column %q: %v", e.Line, e.Column, e.Err)
+	}
+	return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+}
+
+func (e *CSVError) Unwrap() error {
+	return e.Err
+}
+
+// CSVErrors collects errors of all rows of the imported csv file
+type CSVErrors []*CSVError
+
+// Error returns all errors, one error per line
+func (e CSVErrors) Error() string {
+	lines := make([]string, 0, len(e))
+	for _, err := range e {
+		lines = append(lines, err.Error())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// csv mapping target kinds
+const (
+	csvPayer = iota
+	csvPayee
+	csvExtension
+)
+
+type csvColumn struct {
+	header string
+	index  int
+	kind   int
+	field  string
+	amount bool
+}
+
+type csvPayerRows struct {
+	payer  *records.ARecord
+	line   int
+	payees []*records.BRecord
+	lines  []int
+}
+
+// ImportCSV returns a file with payers and payees of a csv file with header line
+//
+// Every row is a payee “B” record. Payers are grouped by the values of the
+// payer columns in order of appearance. Conversion and field validation errors
+// are returned as CSVErrors pointing to lines and columns of the csv file.
+// The file is finalized and validated.
+func ImportCSV(r io.Reader, mapping *CSVMapping) (File, error) {
+	if mapping == nil {
+		return nil, utils.ErrInvalidMapping
+	}
+	form, code, err := mappingTypeOfReturn(mapping.TypeOfReturn)
+	if err != nil {
+		return nil, err
+	}
+
+	reader := csv.NewReader(r)
+	header, err := reader.Read()
+	if err != nil {
+		return nil, err
+	}
+	columns, err := mapping.columns(header, form)
+	if err != nil {
+		return nil, err
+	}
+
+	var payers []*csvPayerRows
+	payerIndexes := make(map[string]int)
+	var errs CSVErrors
+	for {
+		row, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) && errors.Is(err, csv.ErrFieldCount) {
+				errs = append(errs, &CSVError{Line: parseErr.Line, Err: parseErr.Err})
+				continue
+			}
+			return nil, err
+		}
+		line, _ := reader.FieldPos(0)
+
+		// payer of the row
+		key := make([]string, 0)
+		for _, column := range columns {
+			if column.kind == csvPayer {
+				key = append(key, strings.TrimSpace(row[column.index]))
+			}
+		}
+		index, existed := payerIndexes[strings.Join(key, "\x00")]
+		if !existed {
+			payer := mapping.newPayer(code, columns)
+			index = len(payers)
+			payerIndexes[strings.Join(key, "\x00")] = index
+			payers = append(payers, &csvPayerRows{payer: payer, line: line})
+		}
+		rows := payers[index]
+
+		payee := &records.BRecord{PaymentYear: rows.payer.PaymentYear}
+		if err = payee.SetTypeOfReturn(form); err != nil {
+			return nil, err
+		}
+		for _, column := range columns {
+			var target interface{} = payee
+			switch column.kind {
+			case csvPayer:
+				if existed {
+					continue
+				}
+				target = rows.payer
+			case csvExtension:
+				target = payee.Extension()
+			}
+			field := reflect.ValueOf(target).Elem().FieldByName(column.field)
+			if err := setCSVValue(field, row[column.index], column.amount && !mapping.AmountsInCents); err != nil {
+				errs = append(errs, &CSVError{Line: line, Column: column.header, Err: err})
+			}
+		}
+		if mapping.NormalizeAddresses {
+			if !existed {
+				if err := rows.payer.NormalizeAddress(); err != nil {
+					errs = append(errs, &CSVError{Line: line, Err: err})
+				}
+			}
+			if err := payee.NormalizeAddress(); err != nil {
+				errs = append(errs, &CSVError{Line: line, Err: err})
+			}
+		}
+		rows.payees = append(rows.payees, payee)
+		rows.lines = append(rows.lines, line)
+	}
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	builder := NewBuilder()
+	transmitter := mapping.Transmitter
+	builder.Transmitter(&transmitter)
+	for _, rows := range payers {
+		builder.AddPayer(rows.payer)
+		for _, payee := range rows.payees {
+			builder.AddPayee(payee)
+		}
+	}
+	if builder.err != nil {
+		return nil, builder.err
+	}
+	f := builder.file
+	if err = f.Finalize(); err != nil {
+		return nil, err
+	}
+
+	// field errors of payers and payees
+	for _, rows := range payers {
+		errs = append(errs, csvFieldErrors(rows.payer, rows.line, columns, csvPayer)...)
+		for index, payee := range rows.payees {
+			errs = append(errs, csvFieldErrors(payee, rows.lines[index], columns, csvPayee)...)
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	if err = f.Validate(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// columns resolves column targets of the mapping
+func (m *CSVMapping) columns(header []string, form string) ([]*csvColumn, error) {
+	indexes := make(map[string]int)
+	for index, name := range header {
+		indexes[strings.TrimSpace(name)] = index
+	}
+
+	extension, err := subrecords.NewSubRecord(form)
+	if err != nil {
+		return nil, err
+	}
+	extensionName := reflect.TypeOf(extension).Elem().Name()
+
+	columns := make([]*csvColumn, 0, len(m.Columns))
+	for name, target := range m.Columns {
+		index, ok := indexes[name]
+		if !ok {
+			return nil, fmt.Errorf("%w: column %q not found", utils.ErrInvalidMapping, name)
+		}
+		column := &csvColumn{header: name, index: index}
+
+		var record interface{}
+		switch {
+		case strings.HasPrefix(target, CSVPayerPrefix):
+			column.kind, column.field, record = csvPayer, strings.TrimPrefix(target, CSVPayerPrefix), &records.ARecord{}
+		case strings.HasPrefix(target, CSVPayeePrefix):
+			column.kind, column.field, record = csvPayee, strings.TrimPrefix(target, CSVPayeePrefix), &records.BRecord{}
+		case strings.HasPrefix(target, extensionName+"."):
+			column.kind, column.field, record = csvExtension, strings.TrimPrefix(target, extensionName+"."), extension
+		default:
+			column.kind, column.field, record = csvPayee, target, &records.BRecord{}
+			if code, ok := boxCode(form, target); ok {
+				column.field = "PaymentAmount" + code
+			}
+		}
+		if !csvField(record, column.field) {
+			return nil, fmt.Errorf("%w: unknown target %q of column %q", utils.ErrInvalidMapping, target, name)
+		}
+		column.amount = column.kind == csvPayee && strings.HasPrefix(column.field, "PaymentAmount")
+		columns = append(columns, column)
+	}
+
+	// keep errors in column order
+	sort.Slice(columns, func(i, j int) bool {
+		return columns[i].index < columns[j].index
+	})
+	return columns, nil
+}
+
+// newPayer returns a payer “A” record with default values and amount codes of the mapped boxes
+func (m *CSVMapping) newPayer(code string, columns []*csvColumn) *records.ARecord {
+	payer := m.Payer
+	payer.TypeOfReturn = code
+	if payer.PaymentYear == 0 {
+		payer.PaymentYear = m.Transmitter.PaymentYear
+	}
+
+	mapped := make(map[string]bool)
+	for _, column := range columns {
+		if column.amount {
+			mapped[strings.TrimPrefix(column.field, "PaymentAmount")] = true
+		}
+	}
+	if len(mapped) > 0 {
+		codes := ""
+		for _, amountCode := range amountCodes {
+			if mapped[amountCode] {
+				codes += amountCode
+			}
+		}
+		payer.AmountCodes = codes
+	}
+	return &payer
+}
+
+// mappingTypeOfReturn returns form name and type of return code
+func mappingTypeOfReturn(typeOfReturn string) (string, string, error) {
+	for code, form := range config.TypeOfReturns {
+		if strings.EqualFold(typeOfReturn, form) || typeOfReturn == code {
+			return form, code, nil
+		}
+	}
+	return "", "", fmt.Errorf("%w: type of return %q", utils.ErrInvalidMapping, typeOfReturn)
+}
+
+// boxCode returns amount code of the box name of the form
+func boxCode(form, name string) (string, bool) {
+	for code, box := range config.AmountCodes[form] {
+		if strings.EqualFold(strings.TrimSpace(name), box) {
+			return code, true
+		}
+	}
+	return "", false
+}
+
+// csvField returns true if the record has a settable field with the name
+func csvField(record interface{}, name string) bool {
+	field, ok := reflect.TypeOf(record).Elem().FieldByName(name)
+	if !ok || !field.IsExported() {
+		return false
+	}
+	switch field.Type.Kind() {
+	case reflect.String, reflect.Int:
+		return true
+	}
+	return field.Type == reflect.TypeOf(time.Time{})
+}
+
+// setCSVValue sets the field with the value of a csv column
+func setCSVValue(field reflect.Value, value string, dollars bool) error {
+	value = strings.TrimSpace(value)
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(value)
+		return nil
+	case reflect.Int:
+		amount, err := parseCSVAmount(value, dollars)
+		if err != nil {
+			return err
+		}
+		field.SetInt(int64(amount))
+		return nil
+	}
+
+	if len(value) == 0 {
+		field.Set(reflect.ValueOf(time.Time{}))
+		return nil
+	}
+	for _, layout := range csvDateFormats {
+		if date, err := time.Parse(layout, value); err == nil {
+			field.Set(reflect.ValueOf(date))
+			return nil
+		}
+	}
+	return utils.ErrValidDate
+}
+
+// parseCSVAmount returns integer value of the column, dollars are converted to cents
+func parseCSVAmount(value string, dollars bool) (int, error) {
+	value = strings.NewReplacer("$", "", ",", "").Replace(value)
+	if len(value) == 0 {
+		return 0, nil
+	}
+	if !dollars {
+		amount, err := strconv.Atoi(value)
+		if err != nil {
+			return 0, utils.ErrNumeric
+		}
+		return amount, nil
+	}
+
+	amount, err := utils.ParseDollars(value)
+	if err != nil {
+		return 0, utils.ErrNumeric
+	}
+	return amount, nil
+}
+
+// csvFieldErrors returns field errors of error severity of the record at the line of the csv file
+func csvFieldErrors(record records.Record, line int, columns []*csvColumn, kind int) CSVErrors {
+	var errs CSVErrors
+	for _, err := range records.ValidateFields(record) {
+		if err.Severity != utils.SeverityError {
+			continue
+		}
+		errKind := kind
+		if kind == csvPayee && err.Start >= config.RecordLength-config.SubRecordLength {
+			errKind = csvExtension
+		}
+		csvErr := &CSVError{Line: line, Err: fmt.Errorf("%s %w", err.FieldName, err.Err)}
+		for _, column := range columns {
+			if column.kind == errKind && column.field == err.FieldName {
+				csvErr.Column = column.header
+				break
+			}
+		}
+		errs = append(errs, csvErr)
+	}
+	return errs
+}
+
+type csvExportColumn struct {
+	header string
+	value  func(payer *records.ARecord, payee *records.BRecord) string
+}
+
+// ExportCSV writes one row per payee “B” record with a header line
+//
+// Rows start with the payer TIN, payer name and form, followed by payee fields
+// named by their json names, payment amounts in dollars named by the box names
+// of config.AmountCodes, extension block fields and the state abbreviation of
+// the combined federal/state code. Boxes and extension fields of all forms in
+// the file are included; cells of other forms are empty.
+func ExportCSV(w io.Writer, f File) error {
+	if f == nil {
+		return utils.ErrInvalidFile
+	}
+
+	columns := csvExportColumns(f)
+	writer := csv.NewWriter(w)
+	header := make([]string, 0, len(columns))
+	for _, column := range columns {
+		header = append(header, column.header)
+	}
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+
+	for _, person := range f.Payers() {
+		payer := person.PayerRecord()
+		if payer == nil {
+			continue
+		}
+		for _, payee := range person.PayeeRecords() {
+			row := make([]string, 0, len(columns))
+			for _, column := range columns {
+				row = append(row, column.value(payer, payee))
+			}
+			if err := writer.Write(row); err != nil {
+				return err
+			}
+		}
+		writer.Flush()
+		if err := writer.Error(); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// csvExportColumns returns columns of payers, payees, boxes and extension blocks of all forms in the file
+func csvExportColumns(f File) []*csvExportColumn {
+	columns := []*csvExportColumn{
+		{header: "payer_tin", value: func(payer *records.ARecord, _ *records.BRecord) string {
+			return payer.TIN
+		}},
+		{header: "first_payer_name", value: func(payer *records.ARecord, _ *records.BRecord) string {
+			return payer.FirstPayerNameLine
+		}},
+		{header: "type_of_return", value: func(payer *records.ARecord, _ *records.BRecord) string {
+			return config.TypeOfReturns[payer.TypeOfReturn]
+		}},
+	}
+
+	for _, name := range jsonFields(reflect.TypeOf(records.BRecord{})) {
+		if name.json == "record_type" || name.json == "record_sequence_number" || strings.HasPrefix(name.field, "PaymentAmount") {
+			continue
+		}
+		field := name.field
+		columns = append(columns, &csvExportColumn{header: name.json, value: func(_ *records.ARecord, payee *records.BRecord) string {
+			return csvString(reflect.ValueOf(payee).Elem().FieldByName(field))
+		}})
+	}
+
+	// forms of the file in order of appearance
+	var forms []string
+	existed := make(map[string]bool)
+	for _, person := range f.Payers() {
+		if payer := person.PayerRecord(); payer != nil {
+			form := config.TypeOfReturns[payer.TypeOfReturn]
+			if len(form) > 0 && !existed[form] {
+				existed[form] = true
+				forms = append(forms, form)
+			}
+		}
+	}
+
+	// box names
+	existed = make(map[string]bool)
+	for _, form := range forms {
+		for _, code := range amountCodes {
+			box, ok := config.AmountCodes[form][code]
+			if !ok || existed[box] {
+				continue
+			}
+			existed[box] = true
+			columns = append(columns, &csvExportColumn{header: box, value: func(payer *records.ARecord, payee *records.BRecord) string {
+				code, ok := boxCode(config.TypeOfReturns[payer.TypeOfReturn], box)
+				if !ok {
+					return ""
+				}
+				amount, _ := payee.PaymentAmount(code)
+				return utils.FormatDollars(amount)
+			}})
+		}
+	}
+
+	// extension fields
+	existed = make(map[string]bool)
+	for _, form := range forms {
+		extension, err := subrecords.NewSubRecord(form)
+		if err != nil {
+			continue
+		}
+		for _, name := range jsonFields(reflect.TypeOf(extension).Elem()) {
+			if existed[name.json] {
+				continue
+			}
+			existed[name.json] = true
+			field := name.field
+			columns = append(columns, &csvExportColumn{header: name.json, value: func(_ *records.ARecord, payee *records.BRecord) string {
+				if payee.Extension() == nil {
+					return ""
+				}
+				value := reflect.ValueOf(payee.Extension()).Elem().FieldByName(field)
+				if !value.IsValid() {
+					return ""
+				}
+				return csvString(value)
+			}})
+		}
+	}
+
+	return append(columns, &csvExportColumn{header: "state", value: func(_ *records.ARecord, payee *records.BRecord) string {
+		state, _ := stateAbbreviation(payee.FederalState())
+		return state
+	}})
+}
+
+type jsonField struct {
+	field string
+	json  string
+}
+
+// jsonFields returns exported fields of the struct type with json names
+func jsonFields(t reflect.Type) []jsonField {
+	var fields []jsonField
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if !field.IsExported() || len(name) == 0 || name == "-" {
+			continue
+		}
+		fields = append(fields, jsonField{field: field.Name, json: name})
+	}
+	return fields
+}
+
+// csvString returns the value of the field as csv column
+func csvString(field reflect.Value) string {
+	switch field.Kind() {
+	case reflect.String:
+		return field.String()
+	case reflect.Int:
+		return strconv.FormatInt(field.Int(), 10)
+	}
+	if date, ok := field.Interface().(time.Time); ok && !date.IsZero() {
+		return date.Format("2006-01-02")
+	}
+	return ""
+}
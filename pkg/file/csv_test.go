@@ -0,0 +1,175 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package file
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/check.v1"
+
+	"github.com/moov-io/irs/pkg/subrecords"
+	"github.com/moov-io/irs/pkg/utils"
+)
+
+func (t *FileTest) csvMapping(c *check.C) (*CSVMapping, []byte) {
+	buf, err := os.ReadFile(filepath.Join("..", "..", "test", "testdata", "csvMapping.json"))
+	c.Assert(err, check.IsNil)
+	mapping := &CSVMapping{}
+	c.Assert(json.Unmarshal(buf, mapping), check.IsNil)
+	data, err := os.ReadFile(filepath.Join("..", "..", "test", "testdata", "payees.csv"))
+	c.Assert(err, check.IsNil)
+	return mapping, data
+}
+
+func (t *FileTest) TestImportCSV(c *check.C) {
+	mapping, data := t.csvMapping(c)
+
+	f, err := ImportCSV(bytes.NewReader(data), mapping)
+	c.Assert(err, check.IsNil)
+	c.Assert(f.Validate(), check.IsNil)
+	c.Assert(len(f.Payers()), check.Equals, 2)
+
+	payer := f.Payers()[0].PayerRecord()
+	c.Assert(payer.TIN, check.Equals, "123456789")
+	c.Assert(payer.TypeOfReturn, check.Equals, "NE")
+	c.Assert(payer.AmountCodes, check.Equals, "14")
+	c.Assert(payer.PaymentYear, check.Equals, 2020)
+	c.Assert(payer.PayerCity, check.Equals, "NEW YORK")
+
+	payees := f.Payers()[0].PayeeRecords()
+	c.Assert(len(payees), check.Equals, 2)
+	c.Assert(payees[0].PaymentAmount1, check.Equals, 125050)
+	c.Assert(payees[0].PaymentAmount4, check.Equals, 12500)
+	c.Assert(payees[0].PayerAccountNumber, check.Equals, "ACCOUNT1")
+	c.Assert(payees[0].Extension().(*subrecords.Sub1099NEC).DirectSalesIndicator, check.Equals, "1")
+	c.Assert(payees[1].PaymentAmount1, check.Equals, 70000)
+	c.Assert(f.Payers()[0].EndPayerRecord().ControlTotal1, check.Equals, 195050)
+
+	c.Assert(f.Payers()[1].PayerRecord().FirstPayerNameLine, check.Equals, "QWER INC")
+	c.Assert(f.Payers()[1].PayeeRecords()[0].PaymentAmount1, check.Equals, 9990)
+	c.Assert(f.EndTransmitterRecord().TotalNumberPayees, check.Equals, 3)
+
+	// amounts in cents
+	mapping.AmountsInCents = true
+	_, err = ImportCSV(bytes.NewReader(data), mapping)
+	var csvErrs CSVErrors
+	c.Assert(errors.As(err, &csvErrs), check.Equals, true)
+	c.Assert(len(csvErrs), check.Equals, 2)
+	c.Assert(csvErrs[0].Line, check.Equals, 2)
+	c.Assert(csvErrs[0].Column, check.Equals, "Nonemployee Compensation")
+}
+
+func (t *FileTest) TestImportCSVWithInvalidRows(c *check.C) {
+	mapping, data := t.csvMapping(c)
+	lines := strings.Split(string(data), "\n")
+	lines[1] = strings.Replace(lines[1], "1,250.50", "12.505", 1)
+	lines[2] = strings.Replace(lines[2], "COGSWELL COGS", "", 1)
+	lines[3] = strings.Replace(lines[3], "99.9,,,6", "99.9,,6", 1)
+	data = []byte(strings.Join(lines, "\n"))
+
+	_, err := ImportCSV(bytes.NewReader(data), mapping)
+	var csvErrs CSVErrors
+	c.Assert(errors.As(err, &csvErrs), check.Equals, true)
+	c.Assert(len(csvErrs), check.Equals, 2)
+	c.Assert(csvErrs[0].Line, check.Equals, 2)
+	c.Assert(csvErrs[0].Column, check.Equals, "Nonemployee Compensation")
+	c.Assert(errors.Is(csvErrs[0], utils.ErrNumeric), check.Equals, true)
+	c.Assert(csvErrs[1].Line, check.Equals, 4)
+	c.Assert(csvErrs[1].Column, check.Equals, "")
+
+	// field errors of records
+	lines[1] = strings.Replace(lines[1], "12.505", "1", 1)
+	lines[3] = strings.Replace(lines[3], "99.9,,6", "99.9,,,6", 1)
+	data = []byte(strings.Join(lines, "\n"))
+	_, err = ImportCSV(bytes.NewReader(data), mapping)
+	c.Assert(errors.As(err, &csvErrs), check.Equals, true)
+	c.Assert(len(csvErrs), check.Equals, 1)
+	c.Assert(csvErrs[0].Line, check.Equals, 3)
+	c.Assert(csvErrs[0].Column, check.Equals, "Name")
+	c.Assert(strings.HasPrefix(csvErrs[0].Error(), `line 3 column "Name": FirstPayeeNameLine`), check.Equals, true)
+}
+
+func (t *FileTest) TestImportCSVWithInvalidMapping(c *check.C) {
+	mapping, data := t.csvMapping(c)
+
+	_, err := ImportCSV(bytes.NewReader(data), nil)
+	c.Assert(err, check.Equals, utils.ErrInvalidMapping)
+
+	mapping.Columns["Unknown"] = "TIN"
+	_, err = ImportCSV(bytes.NewReader(data), mapping)
+	c.Assert(errors.Is(err, utils.ErrInvalidMapping), check.Equals, true)
+	delete(mapping.Columns, "Unknown")
+
+	mapping.Columns["Direct Sales"] = "Sub1099MISC.DirectSalesIndicator"
+	_, err = ImportCSV(bytes.NewReader(data), mapping)
+	c.Assert(errors.Is(err, utils.ErrInvalidMapping), check.Equals, true)
+	mapping.Columns["Direct Sales"] = "Sub1099NEC.DirectSalesIndicator"
+
+	mapping.TypeOfReturn = "1099-XYZ"
+	_, err = ImportCSV(bytes.NewReader(data), mapping)
+	c.Assert(errors.Is(err, utils.ErrInvalidMapping), check.Equals, true)
+
+	// type of return code
+	mapping.TypeOfReturn = "NE"
+	_, err = ImportCSV(bytes.NewReader(data), mapping)
+	c.Assert(err, check.IsNil)
+}
+
+func (t *FileTest) TestExportCSV(c *check.C) {
+	mapping, data := t.csvMapping(c)
+	f, err := ImportCSV(bytes.NewReader(data), mapping)
+	c.Assert(err, check.IsNil)
+
+	var buf bytes.Buffer
+	c.Assert(ExportCSV(&buf, f), check.IsNil)
+	rows, err := csv.NewReader(&buf).ReadAll()
+	c.Assert(err, check.IsNil)
+	c.Assert(len(rows), check.Equals, 4)
+
+	column := func(name string) int {
+		for index, header := range rows[0] {
+			if header == name {
+				return index
+			}
+		}
+		c.Fatalf("missing column %s", name)
+		return -1
+	}
+	c.Assert(rows[1][column("payer_tin")], check.Equals, "123456789")
+	c.Assert(rows[1][column("type_of_return")], check.Equals, "1099-NEC")
+	c.Assert(rows[1][column("payees_tin")], check.Equals, "987654321")
+	c.Assert(rows[1][column("Nonemployee Compensation")], check.Equals, "1250.50")
+	c.Assert(rows[1][column("Federal Income Tax Withheld")], check.Equals, "125.00")
+	c.Assert(rows[1][column("direct_sales_indicator")], check.Equals, "1")
+	c.Assert(rows[1][column("combined_federal_state_code")], check.Equals, "6")
+	c.Assert(rows[1][column("state")], check.Equals, "CA")
+	c.Assert(rows[3][column("first_payer_name")], check.Equals, "QWER INC")
+	c.Assert(rows[3][column("Nonemployee Compensation")], check.Equals, "99.90")
+	for _, header := range rows[0] {
+		c.Assert(strings.HasPrefix(header, "payment_amount"), check.Equals, false)
+	}
+
+	// boxes and extension fields of several forms
+	misc, err := CreateFile(t.oneTransactionJson)
+	c.Assert(err, check.IsNil)
+	c.Assert(f.AddPayer(misc.Payers()[0]), check.IsNil)
+	buf.Reset()
+	c.Assert(ExportCSV(&buf, f), check.IsNil)
+	rows, err = csv.NewReader(&buf).ReadAll()
+	c.Assert(err, check.IsNil)
+	c.Assert(len(rows), check.Equals, 6)
+	c.Assert(rows[4][column("type_of_return")], check.Equals, "1099-MISC")
+	c.Assert(rows[4][column("Nonemployee Compensation")], check.Equals, "")
+	c.Assert(rows[4][column("fatca_requirement_indicator")], check.Equals, "1")
+	c.Assert(rows[1][column("fatca_requirement_indicator")], check.Equals, "")
+
+	c.Assert(ExportCSV(&buf, nil), check.Equals, utils.ErrInvalidFile)
+}
@@ -0,0 +1,280 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package file
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/moov-io/irs/pkg/records"
+	"github.com/moov-io/irs/pkg/utils"
+)
+
+// Kinds of record differences
+const (
+	DiffAdded   = "added"
+	DiffRemoved = "removed"
+	DiffChanged = "changed"
+)
+
+// FieldDiff describes a changed field of a record.
+// Fields of the “B” record extension block are prefixed with “Extension.”
+type FieldDiff struct {
+	Name string      `json:"name"`
+	Old  interface{} `json:"old"`
+	New  interface{} `json:"new"`
+}
+
+// RecordDiff describes an added, removed or changed record
+type RecordDiff struct {
+	Kind       string `json:"kind"`
+	RecordType string `json:"record_type"`
+	// PayerTIN and TypeOfReturn identify the payer of “A”, “B”, “C” and “K” records
+	PayerTIN     string `json:"payer_tin,omitempty"`
+	TypeOfReturn string `json:"type_of_return,omitempty"`
+	// PayeeTIN and AccountNumber identify the payee of “B” records
+	PayeeTIN      string `json:"payee_tin,omitempty"`
+	AccountNumber string `json:"account_number,omitempty"`
+	// StateCode identifies the state of “K” records
+	StateCode string      `json:"state_code,omitempty"`
+	Fields    []FieldDiff `json:"fields,omitempty"`
+}
+
+// String returns a human-readable form of the difference
+func (d RecordDiff) String() string {
+	var buf strings.Builder
+	buf.WriteString(fmt.Sprintf("%s %s record", d.Kind, d.RecordType))
+	if len(d.PayerTIN) > 0 || len(d.TypeOfReturn) > 0 {
+		buf.WriteString(fmt.Sprintf(", payer %s (%s)", d.PayerTIN, d.TypeOfReturn))
+	}
+	if len(d.PayeeTIN) > 0 || len(d.AccountNumber) > 0 {
+		buf.WriteString(fmt.Sprintf(", payee %s", d.PayeeTIN))
+		if len(d.AccountNumber) > 0 {
+			buf.WriteString(fmt.Sprintf(" account %s", d.AccountNumber))
+		}
+	}
+	if len(d.StateCode) > 0 {
+		buf.WriteString(fmt.Sprintf(", state %s", d.StateCode))
+	}
+	for _, field := range d.Fields {
+		buf.WriteString(fmt.Sprintf("\n  %s: %q -> %q", field.Name, fmt.Sprint(field.Old), fmt.Sprint(field.New)))
+	}
+	return buf.String()
+}
+
+// DiffReport contains all differences between two files
+type DiffReport struct {
+	Differences []RecordDiff `json:"differences"`
+}
+
+// Equal returns true if the files have no differences
+func (r *DiffReport) Equal() bool {
+	return len(r.Differences) == 0
+}
+
+// String returns a human-readable form of all differences
+func (r *DiffReport) String() string {
+	if r.Equal() {
+		return "no differences"
+	}
+	lines := make([]string, 0, len(r.Differences))
+	for _, diff := range r.Differences {
+		lines = append(lines, diff.String())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Diff returns differences between two files
+//
+// Payers are matched by TIN and type of return, payees by TIN and payer
+// account number and state totals by combined federal/state code. Record
+// sequence numbers are ignored.
+func Diff(a, b File) (*DiffReport, error) {
+	if a == nil || b == nil {
+		return nil, utils.ErrInvalidFile
+	}
+
+	report := &DiffReport{Differences: []RecordDiff{}}
+	report.compare(RecordDiff{RecordType: "T"}, a.TransmitterRecord(), b.TransmitterRecord())
+
+	matched := make(map[*PaymentPerson]bool)
+	for _, personA := range a.Payers() {
+		payerA := personA.PayerRecord()
+		if payerA == nil {
+			return nil, utils.ErrNonExistPayer
+		}
+
+		var personB *PaymentPerson
+		for _, candidate := range b.Payers() {
+			payerB := candidate.PayerRecord()
+			if !matched[candidate] && payerB != nil && payerB.TIN == payerA.TIN && payerB.TypeOfReturn == payerA.TypeOfReturn {
+				personB = candidate
+				break
+			}
+		}
+		if personB == nil {
+			report.person(DiffRemoved, personA)
+			continue
+		}
+		matched[personB] = true
+		report.comparePerson(personA, personB)
+	}
+	for _, personB := range b.Payers() {
+		if !matched[personB] && personB.PayerRecord() != nil {
+			report.person(DiffAdded, personB)
+		}
+	}
+
+	report.compare(RecordDiff{RecordType: "F"}, a.EndTransmitterRecord(), b.EndTransmitterRecord())
+	return report, nil
+}
+
+// person adds all records of the payer as added or removed
+func (r *DiffReport) person(kind string, person *PaymentPerson) {
+	payer := person.PayerRecord()
+	r.add(kind, payerDiff("A", payer))
+	for _, payee := range person.PayeeRecords() {
+		r.add(kind, payeeDiff(payer, payee))
+	}
+	if person.EndPayerRecord() != nil {
+		r.add(kind, payerDiff("C", payer))
+	}
+	for _, state := range person.StateRecords() {
+		r.add(kind, stateDiff(payer, state))
+	}
+}
+
+func (r *DiffReport) comparePerson(a, b *PaymentPerson) {
+	payer := a.PayerRecord()
+	r.compare(payerDiff("A", payer), payer, b.PayerRecord())
+
+	payeesB := b.PayeeRecords()
+	matched := make([]bool, len(payeesB))
+	for _, payeeA := range a.PayeeRecords() {
+		index := -1
+		for i, payeeB := range payeesB {
+			if !matched[i] && payeeB.TIN == payeeA.TIN && payeeB.PayerAccountNumber == payeeA.PayerAccountNumber {
+				index = i
+				break
+			}
+		}
+		if index < 0 {
+			r.add(DiffRemoved, payeeDiff(payer, payeeA))
+			continue
+		}
+		matched[index] = true
+		r.compare(payeeDiff(payer, payeeA), payeeA, payeesB[index])
+	}
+	for i, payeeB := range payeesB {
+		if !matched[i] {
+			r.add(DiffAdded, payeeDiff(payer, payeeB))
+		}
+	}
+
+	r.compare(payerDiff("C", payer), a.EndPayerRecord(), b.EndPayerRecord())
+
+	statesB := b.StateRecords()
+	matched = make([]bool, len(statesB))
+	for _, stateA := range a.StateRecords() {
+		index := -1
+		for i, stateB := range statesB {
+			if !matched[i] && stateB.CombinedFederalStateCode == stateA.CombinedFederalStateCode {
+				index = i
+				break
+			}
+		}
+		if index < 0 {
+			r.add(DiffRemoved, stateDiff(payer, stateA))
+			continue
+		}
+		matched[index] = true
+		r.compare(stateDiff(payer, stateA), stateA, statesB[index])
+	}
+	for i, stateB := range statesB {
+		if !matched[i] {
+			r.add(DiffAdded, stateDiff(payer, stateB))
+		}
+	}
+}
+
+func (r *DiffReport) add(kind string, diff RecordDiff) {
+	diff.Kind = kind
+	r.Differences = append(r.Differences, diff)
+}
+
+// compare adds changed fields of two records of the same type
+func (r *DiffReport) compare(diff RecordDiff, a, b interface{}) {
+	aValue, bValue := reflect.ValueOf(a), reflect.ValueOf(b)
+	aNil, bNil := aValue.IsNil(), bValue.IsNil()
+	switch {
+	case aNil && bNil:
+		return
+	case aNil:
+		r.add(DiffAdded, diff)
+		return
+	case bNil:
+		r.add(DiffRemoved, diff)
+		return
+	}
+
+	diff.Fields = diffFields("", a, b)
+	if aPayee, ok := a.(*records.BRecord); ok {
+		bPayee := b.(*records.BRecord)
+		aExt, bExt := aPayee.Extension(), bPayee.Extension()
+		if aExt != nil && bExt != nil && aExt.Type() == bExt.Type() {
+			diff.Fields = append(diff.Fields, diffFields("Extension.", aExt, bExt)...)
+		} else if aExt != nil || bExt != nil {
+			diff.Fields = append(diff.Fields, FieldDiff{Name: "Extension", Old: aPayee.TypeOfReturn(), New: bPayee.TypeOfReturn()})
+		}
+	}
+	if len(diff.Fields) > 0 {
+		r.add(DiffChanged, diff)
+	}
+}
+
+// diffFields returns exported fields with different values, record sequence numbers are skipped
+func diffFields(prefix string, a, b interface{}) []FieldDiff {
+	var fields []FieldDiff
+	aFields, bFields := reflect.ValueOf(a).Elem(), reflect.ValueOf(b).Elem()
+	for i := 0; i < aFields.NumField(); i++ {
+		field := aFields.Type().Field(i)
+		if !field.IsExported() || field.Name == "RecordSequenceNumber" {
+			continue
+		}
+		aField, bField := aFields.Field(i).Interface(), bFields.FieldByName(field.Name).Interface()
+		if !equalValue(aField, bField) {
+			fields = append(fields, FieldDiff{Name: prefix + field.Name, Old: aField, New: bField})
+		}
+	}
+	return fields
+}
+
+func equalValue(a, b interface{}) bool {
+	if aTime, ok := a.(time.Time); ok {
+		if bTime, ok := b.(time.Time); ok {
+			return aTime.Equal(bTime)
+		}
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+func payerDiff(recordType string, payer *records.ARecord) RecordDiff {
+	return RecordDiff{RecordType: recordType, PayerTIN: payer.TIN, TypeOfReturn: payer.TypeOfReturn}
+}
+
+func payeeDiff(payer *records.ARecord, payee *records.BRecord) RecordDiff {
+	diff := payerDiff("B", payer)
+	diff.PayeeTIN = payee.TIN
+	diff.AccountNumber = payee.PayerAccountNumber
+	return diff
+}
+
+func stateDiff(payer *records.ARecord, state *records.KRecord) RecordDiff {
+	diff := payerDiff("K", payer)
+	diff.StateCode = state.CombinedFederalStateCode
+	return diff
+}
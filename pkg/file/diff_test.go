@@ -0,0 +1,107 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package file
+
+import (
+	"encoding/json"
+	"strings"
+
+	"gopkg.in/check.v1"
+
+	"github.com/moov-io/irs/pkg/subrecords"
+	"github.com/moov-io/irs/pkg/utils"
+)
+
+func (t *FileTest) TestDiffEqual(c *check.C) {
+	a, err := CreateFile(t.oneTransactionJson)
+	c.Assert(err, check.IsNil)
+	b, err := CreateFile(t.oneTransactionJson)
+	c.Assert(err, check.IsNil)
+	b.TransmitterRecord().RecordSequenceNumber = 10
+
+	report, err := Diff(a, b)
+	c.Assert(err, check.IsNil)
+	c.Assert(report.Equal(), check.Equals, true)
+	c.Assert(report.String(), check.Equals, "no differences")
+
+	_, err = Diff(a, nil)
+	c.Assert(err, check.Equals, utils.ErrInvalidFile)
+}
+
+func (t *FileTest) TestDiffChanged(c *check.C) {
+	a, b := t.correctionFiles(c)
+	b.TransmitterRecord().TransmitterName = "NEW TRANSMITTER"
+	payee := b.Payers()[0].PayeeRecords()[1]
+	payee.PaymentAmount7 = 900
+	payee.Extension().(*subrecords.Sub1099MISC).StateIncomeTaxWithheld = 50
+
+	report, err := Diff(a, b)
+	c.Assert(err, check.IsNil)
+	c.Assert(len(report.Differences), check.Equals, 2)
+
+	diff := report.Differences[0]
+	c.Assert(diff.Kind, check.Equals, DiffChanged)
+	c.Assert(diff.RecordType, check.Equals, "T")
+	c.Assert(diff.Fields, check.DeepEquals, []FieldDiff{{Name: "TransmitterName", Old: a.TransmitterRecord().TransmitterName, New: "NEW TRANSMITTER"}})
+
+	diff = report.Differences[1]
+	c.Assert(diff.Kind, check.Equals, DiffChanged)
+	c.Assert(diff.RecordType, check.Equals, "B")
+	c.Assert(diff.PayeeTIN, check.Equals, payee.TIN)
+	c.Assert(diff.AccountNumber, check.Equals, "ACCOUNT2")
+	c.Assert(len(diff.Fields), check.Equals, 2)
+	c.Assert(diff.Fields[0].Name, check.Equals, "PaymentAmount7")
+	c.Assert(diff.Fields[0].New, check.Equals, 900)
+	c.Assert(diff.Fields[1].Name, check.Equals, "Extension.StateIncomeTaxWithheld")
+	c.Assert(diff.Fields[1].New, check.Equals, 50)
+
+	c.Assert(strings.Contains(report.String(), "changed B record"), check.Equals, true)
+	c.Assert(strings.Contains(report.String(), `PaymentAmount7: "`), check.Equals, true)
+
+	buf, err := json.Marshal(report)
+	c.Assert(err, check.IsNil)
+	c.Assert(strings.Contains(string(buf), `"name":"Extension.StateIncomeTaxWithheld"`), check.Equals, true)
+}
+
+func (t *FileTest) TestDiffAddedAndRemoved(c *check.C) {
+	a, b := t.correctionFiles(c)
+	payee := b.Payers()[0].PayeeRecords()[0]
+	payee.PayerAccountNumber = "ACCOUNT3"
+
+	report, err := Diff(a, b)
+	c.Assert(err, check.IsNil)
+	c.Assert(len(report.Differences), check.Equals, 2)
+	c.Assert(report.Differences[0].Kind, check.Equals, DiffRemoved)
+	c.Assert(report.Differences[0].AccountNumber, check.Equals, "ACCOUNT1")
+	c.Assert(report.Differences[1].Kind, check.Equals, DiffAdded)
+	c.Assert(report.Differences[1].AccountNumber, check.Equals, "ACCOUNT3")
+	c.Assert(report.Differences[1].Fields, check.IsNil)
+
+	// payers are matched by TIN and type of return
+	b.Payers()[0].PayerRecord().TIN = "111223333"
+	report, err = Diff(a, b)
+	c.Assert(err, check.IsNil)
+	kinds := make(map[string]int)
+	for _, diff := range report.Differences {
+		kinds[diff.Kind+" "+diff.RecordType]++
+	}
+	c.Assert(kinds, check.DeepEquals, map[string]int{
+		"removed A": 1, "removed B": 2, "removed C": 1, "removed K": 1,
+		"added A": 1, "added B": 2, "added C": 1, "added K": 1,
+	})
+
+	// removed state totals
+	b, err = CreateFile(t.oneTransactionJson)
+	c.Assert(err, check.IsNil)
+	person := b.Payers()[0]
+	person.States = nil
+	report, err = Diff(b, a)
+	c.Assert(err, check.IsNil)
+	c.Assert(len(report.Differences), check.Not(check.Equals), 0)
+	last := report.Differences[len(report.Differences)-1]
+	c.Assert(last.Kind, check.Equals, DiffAdded)
+	c.Assert(last.RecordType, check.Equals, "K")
+	c.Assert(last.StateCode, check.Equals, a.Payers()[0].StateRecords()[0].CombinedFederalStateCode)
+}
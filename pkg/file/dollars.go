@@ -0,0 +1,158 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package file
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/moov-io/irs/pkg/config"
+	"github.com/moov-io/irs/pkg/records"
+	"github.com/moov-io/irs/pkg/subrecords"
+	"github.com/moov-io/irs/pkg/utils"
+)
+
+// amountConverter converts the json value of an amount field, text is true for amounts of string fields
+type amountConverter func(name string, value interface{}, text bool) (interface{}, error)
+
+// CreateFileFromDollars attempts to parse raw irs file contents with json amounts in decimal dollars
+//
+// Amount fields of config.AmountFields are decimal strings or numbers like
+// "1234.56" instead of cents. Amounts are parsed exactly and sub-cent
+// precision is rejected. Fire ascii files are parsed like CreateFile.
+func CreateFileFromDollars(buf []byte) (File, error) {
+	if !json.Valid(buf) {
+		return CreateFile(buf)
+	}
+	converted, err := convertAmounts(buf, dollarsToCents)
+	if err != nil {
+		return nil, err
+	}
+	return CreateFile(converted)
+}
+
+// MarshalDollarJSON returns the json encoding of the file with amounts in decimal dollars like "1234.56"
+func MarshalDollarJSON(f File) ([]byte, error) {
+	if f == nil {
+		return nil, utils.ErrInvalidFile
+	}
+	buf, err := json.Marshal(f)
+	if err != nil {
+		return nil, err
+	}
+	return convertAmounts(buf, centsToDollars)
+}
+
+// convertAmounts converts the amount fields of “B”, “C” and “K” records of a json irs file
+func convertAmounts(buf []byte, convert amountConverter) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(buf))
+	decoder.UseNumber()
+	data := make(map[string]interface{})
+	if err := decoder.Decode(&data); err != nil {
+		return nil, err
+	}
+
+	persons, _ := data["payment_persons"].([]interface{})
+	for _, person := range persons {
+		person, ok := person.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if err := convertRecordAmounts(person["end_payer"], amountFields(&records.CRecord{}, config.CRecordType), convert); err != nil {
+			return nil, err
+		}
+		for _, state := range listOf(person["states"]) {
+			if err := convertRecordAmounts(state, amountFields(&records.KRecord{}, config.KRecordType), convert); err != nil {
+				return nil, err
+			}
+		}
+
+		fields := amountFields(&records.BRecord{}, config.BRecordType)
+		if payer, ok := person["payer"].(map[string]interface{}); ok {
+			typeOfReturn, _ := payer["type_of_return"].(string)
+			form := config.TypeOfReturns[typeOfReturn]
+			if extension, err := subrecords.NewSubRecord(form); err == nil {
+				for name, text := range amountFields(extension, form) {
+					fields[name] = text
+				}
+			}
+		}
+		for _, payee := range listOf(person["payees"]) {
+			if err := convertRecordAmounts(payee, fields, convert); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return json.Marshal(data)
+}
+
+func convertRecordAmounts(record interface{}, fields map[string]bool, convert amountConverter) error {
+	values, ok := record.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	for name, text := range fields {
+		value, ok := values[name]
+		if !ok || value == nil {
+			continue
+		}
+		converted, err := convert(name, value, text)
+		if err != nil {
+			return err
+		}
+		values[name] = converted
+	}
+	return nil
+}
+
+// amountFields returns the json names of the amount fields of the record, true for fields of type string
+func amountFields(record interface{}, recordType string) map[string]bool {
+	fields := make(map[string]bool)
+	recordValue := reflect.TypeOf(record).Elem()
+	for _, name := range config.AmountFields[recordType] {
+		field, ok := recordValue.FieldByName(name)
+		if !ok {
+			continue
+		}
+		jsonName := strings.Split(field.Tag.Get("json"), ",")[0]
+		fields[jsonName] = field.Type.Kind() == reflect.String
+	}
+	return fields
+}
+
+func centsToDollars(name string, value interface{}, text bool) (interface{}, error) {
+	cents, err := strconv.Atoi(strings.TrimSpace(fmt.Sprint(value)))
+	if err != nil {
+		// blank amounts of string fields stay blank
+		return value, nil
+	}
+	return utils.FormatDollars(cents), nil
+}
+
+func dollarsToCents(name string, value interface{}, text bool) (interface{}, error) {
+	amount := strings.TrimSpace(fmt.Sprint(value))
+	if len(amount) == 0 && text {
+		return amount, nil
+	} else if len(amount) == 0 {
+		return json.Number("0"), nil
+	}
+	cents, err := utils.ParseDollars(amount)
+	if err != nil {
+		return nil, fmt.Errorf("%s %w", name, err)
+	}
+	if text {
+		return strconv.Itoa(cents), nil
+	}
+	return json.Number(strconv.Itoa(cents)), nil
+}
+
+func listOf(value interface{}) []interface{} {
+	list, _ := value.([]interface{})
+	return list
+}
@@ -0,0 +1,69 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package file
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/check.v1"
+
+	"github.com/moov-io/irs/pkg/records"
+	"github.com/moov-io/irs/pkg/utils"
+)
+
+func (t *FileTest) TestCreateFileFromDollars(c *check.C) {
+	buf, err := os.ReadFile(filepath.Join("..", "..", "test", "testdata", "oneTransactionFileDollars.json"))
+	c.Assert(err, check.IsNil)
+	f, err := CreateFileFromDollars(buf)
+	c.Assert(err, check.IsNil)
+	c.Assert(f.Validate(), check.IsNil)
+
+	cents, err := CreateFile(t.oneTransactionJson)
+	c.Assert(err, check.IsNil)
+	c.Assert(string(f.Ascii()), check.Equals, string(cents.Ascii()))
+	payee := f.Payers()[0].PayeeRecords()[0]
+	c.Assert(payee.PaymentAmount7, check.Equals, 700)
+	state := f.Payers()[0].States[0].(*records.KRecord)
+	c.Assert(state.StateIncomeTaxWithheldTotal, check.Equals, "2")
+
+	// fire ascii files have amounts in cents
+	f, err = CreateFileFromDollars(cents.Ascii())
+	c.Assert(err, check.IsNil)
+	c.Assert(f.Payers()[0].PayeeRecords()[0].PaymentAmount7, check.Equals, 700)
+
+	// numbers are dollars as well
+	f, err = CreateFileFromDollars([]byte(strings.Replace(string(buf), `"payment_amount_7": "7.00"`, `"payment_amount_7": 7.5`, 1)))
+	c.Assert(err, check.IsNil)
+	c.Assert(f.Payers()[0].PayeeRecords()[0].PaymentAmount7, check.Equals, 750)
+
+	_, err = CreateFileFromDollars([]byte(strings.Replace(string(buf), `"7.00"`, `"7.001"`, 1)))
+	c.Assert(errors.Is(err, utils.ErrInvalidDollarAmount), check.Equals, true)
+	c.Assert(err.Error(), check.Equals, "payment_amount_7 is an invalid dollar amount")
+	_, err = CreateFileFromDollars([]byte(strings.Replace(string(buf), `"0.02"`, `"2 cents"`, 1)))
+	c.Assert(errors.Is(err, utils.ErrInvalidDollarAmount), check.Equals, true)
+}
+
+func (t *FileTest) TestMarshalDollarJSON(c *check.C) {
+	f, err := CreateFile(t.oneTransactionJson)
+	c.Assert(err, check.IsNil)
+	buf, err := MarshalDollarJSON(f)
+	c.Assert(err, check.IsNil)
+	output := string(buf)
+	c.Assert(strings.Contains(output, `"payment_amount_7":"7.00"`), check.Equals, true)
+	c.Assert(strings.Contains(output, `"control_total_7":"14.00"`), check.Equals, true)
+	c.Assert(strings.Contains(output, `"state_income_tax_withheld":"0.04"`), check.Equals, true)
+	c.Assert(strings.Contains(output, `"state_income_tax_withheld_total":"0.02"`), check.Equals, true)
+	c.Assert(strings.Contains(output, `"number_of_payees":2`), check.Equals, true)
+
+	dollars, err := CreateFileFromDollars(buf)
+	c.Assert(err, check.IsNil)
+	c.Assert(string(dollars.Ascii()), check.Equals, string(f.Ascii()))
+
+	_, err = MarshalDollarJSON(nil)
+	c.Assert(err, check.Equals, utils.ErrInvalidFile)
+}
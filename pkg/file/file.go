@@ -5,6 +5,7 @@
 package file
 
 import (
+	"bytes"
 	"encoding/json"
 
 	"github.com/moov-io/irs/pkg/records"
@@ -15,9 +16,18 @@ type File interface {
 	Parse([]byte) error
 	Ascii() []byte
 	Pdf() ([]byte, error)
+	Iris() ([]byte, error)
 	Validate() error
+	ValidateAll() *ValidationReport
+	ValidateRules(*Profile) *ValidationReport
+	ValidateProfile(*Profile) *ValidationReport
+	Finalize() error
 	SetTCC(string) error
 	TCC() (*string, error)
+	TransmitterRecord() *records.TRecord
+	EndTransmitterRecord() *records.FRecord
+	Payers() []*PaymentPerson
+	AddPayer(*PaymentPerson) error
 }
 
 // NewFile constructs a file template.
@@ -29,7 +39,13 @@ func NewFile() File {
 }
 
 // CreateFile attempts to parse raw irs file contents
+//
+// Contents are a json irs file, an IRIS intake transmission xml (see ImportIris)
+// or a fire ascii file.
 func CreateFile(buf []byte) (File, error) {
+	if bytes.HasPrefix(bytes.TrimSpace(buf), []byte("<")) {
+		return ImportIris(bytes.NewReader(buf))
+	}
 	var err error
 	f := NewFile()
 	if json.Valid(buf) {
@@ -52,7 +68,7 @@ func readJsonWithRecord(record records.Record, data interface{}) error {
 	return nil
 }
 
-func readJsonWithPerson(person *paymentPerson, data interface{}) error {
+func readJsonWithPerson(person *PaymentPerson, data interface{}) error {
 	buf, err := json.Marshal(data)
 	if err != nil {
 		return err
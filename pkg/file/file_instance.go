@@ -18,10 +18,36 @@ import (
 // File contains the structures of irs file.
 type fileInstance struct {
 	Transmitter    records.Record   `json:"transmitter"`
-	PaymentPersons []*paymentPerson `json:"payment_persons"`
+	PaymentPersons []*PaymentPerson `json:"payment_persons"`
 	EndTransmitter records.Record   `json:"end_transmitter"`
 }
 
+// TransmitterRecord returns transmitter “T” record
+func (f *fileInstance) TransmitterRecord() *records.TRecord {
+	tRecord, _ := f.Transmitter.(*records.TRecord)
+	return tRecord
+}
+
+// EndTransmitterRecord returns end of transmission “F” record
+func (f *fileInstance) EndTransmitterRecord() *records.FRecord {
+	fRecord, _ := f.EndTransmitter.(*records.FRecord)
+	return fRecord
+}
+
+// Payers returns all payment persons of the file
+func (f *fileInstance) Payers() []*PaymentPerson {
+	return f.PaymentPersons
+}
+
+// AddPayer appends payment person
+func (f *fileInstance) AddPayer(person *PaymentPerson) error {
+	if person == nil {
+		return utils.ErrNonExistPayer
+	}
+	f.PaymentPersons = append(f.PaymentPersons, person)
+	return nil
+}
+
 // SetTCC set transmitter control code
 func (f *fileInstance) SetTCC(code string) error {
 	tRecord, _, err := f.getRecords()
@@ -82,10 +108,10 @@ func (f *fileInstance) Parse(buf []byte) error {
 	}
 	readPtr += config.RecordLength
 
-	f.PaymentPersons = []*paymentPerson{}
+	f.PaymentPersons = []*PaymentPerson{}
 	for readPtr < bufSize && string(buf[readPtr]) == config.ARecordType {
-		currentPerson := &paymentPerson{}
-		readSize, err := currentPerson.Parse(buf[readPtr:])
+		currentPerson := &PaymentPerson{}
+		readSize, err := currentPerson.parse(buf[readPtr:], priorYearData(f.Transmitter))
 		if err != nil {
 			return err
 		}
@@ -103,6 +129,7 @@ func (f *fileInstance) Parse(buf []byte) error {
 	if f.EndTransmitter == nil {
 		f.EndTransmitter = records.NewFRecord()
 	}
+	setTaxYear(f.EndTransmitter, records.TaxYear(f.Transmitter))
 	err = f.EndTransmitter.Parse(buf[readPtr : readPtr+config.RecordLength])
 	if err != nil {
 		return err
@@ -171,9 +198,9 @@ func (f *fileInstance) UnmarshalJSON(data []byte) error {
 			if err != nil {
 				return nil
 			}
-			f.PaymentPersons = make([]*paymentPerson, 0)
+			f.PaymentPersons = make([]*PaymentPerson, 0)
 			for _, data := range list {
-				newRecord := &paymentPerson{}
+				newRecord := &PaymentPerson{}
 				err := readJsonWithPerson(newRecord, data)
 				if err != nil {
 					return err
@@ -206,9 +233,52 @@ func (f *fileInstance) UnmarshalJSON(data []byte) error {
 		})
 	}
 
+	f.syncTaxYear()
 	return nil
 }
 
+// taxYearSetter is implemented by records without payment year,
+// their layout revision follows the payer or the transmitter
+type taxYearSetter interface {
+	SetTaxYear(int)
+}
+
+func setTaxYear(record records.Record, year int) {
+	if setter, ok := record.(taxYearSetter); ok {
+		setter.SetTaxYear(year)
+	}
+}
+
+// priorYearDataSetter is implemented by “A” and “B” records,
+// their layout revision follows the prior year data indicator of the transmitter
+type priorYearDataSetter interface {
+	SetPriorYearData(bool)
+}
+
+func setPriorYearData(record records.Record, priorYearData bool) {
+	if setter, ok := record.(priorYearDataSetter); ok {
+		setter.SetPriorYearData(priorYearData)
+	}
+}
+
+// priorYearData returns true if the transmitter reports prior year data
+func priorYearData(transmitter records.Record) bool {
+	tRecord, ok := transmitter.(*records.TRecord)
+	return ok && tRecord.PriorYearDataIndicator == config.PriorYearDataIndicator
+}
+
+// syncTaxYear sets tax year of the transmitter to “F” record, prior year data of the transmitter
+// to payers and payees and tax year of payers to their records
+func (f *fileInstance) syncTaxYear() {
+	if f.Transmitter != nil {
+		setTaxYear(f.EndTransmitter, records.TaxYear(f.Transmitter))
+	}
+	for _, person := range f.PaymentPersons {
+		person.setPriorYearData(priorYearData(f.Transmitter))
+		person.syncTaxYear()
+	}
+}
+
 func (f *fileInstance) validateRecords() error {
 	if f.Transmitter == nil || f.EndTransmitter == nil {
 		return utils.ErrInvalidFile
@@ -274,6 +344,7 @@ func (f *fileInstance) validateRecordSequenceNumber() error {
 	return nil
 }
 
+// integrationCheck returns the first error of SeverityError of the checks between records of the file
 func (f *fileInstance) integrationCheck() error {
 	for _, person := range f.PaymentPersons {
 		if err := person.integrationCheck(); err != nil {
@@ -6,14 +6,18 @@ package file
 
 import (
 	"bytes"
+	"errors"
+	"io"
 	"strings"
 
 	"encoding/json"
 
 	"gopkg.in/check.v1"
 
+	"github.com/moov-io/irs/pkg/config"
 	PDF "github.com/moov-io/irs/pkg/pdf_generator"
 	"github.com/moov-io/irs/pkg/records"
+	"github.com/moov-io/irs/pkg/utils"
 )
 
 func (t *FileTest) TestParseWithOneTransactionJsonFile(c *check.C) {
@@ -43,7 +47,7 @@ func (t *FileTest) TestParseWithOneTransactionJsonFile(c *check.C) {
 	c.Assert(tcc, check.NotNil)
 	c.Assert(f1.SetTCC("123456"), check.NotNil)
 	c.Assert(f1.SetTCC("12345"), check.IsNil)
-	p := &paymentPerson{}
+	p := &PaymentPerson{}
 	c.Assert(p.Type(), check.Equals, "Person")
 }
 
@@ -64,7 +68,7 @@ func (t *FileTest) TestParseFailed(c *check.C) {
 	r := records.NewARecord()
 	err = readJsonWithRecord(r, t.fileWithTestOptionJson)
 	c.Assert(err, check.NotNil)
-	p := &paymentPerson{}
+	p := &PaymentPerson{}
 	err = readJsonWithPerson(p, t.fileWithTestOptionJson)
 	c.Assert(err, check.NotNil)
 
@@ -120,7 +124,7 @@ func (t *FileTest) TestParseFailed(c *check.C) {
 	_, err = person.Parse([]byte(endPayerStr))
 	c.Assert(err, check.NotNil)
 
-	person = &paymentPerson{}
+	person = &PaymentPerson{}
 	c.Assert(person.validateRecords(), check.NotNil)
 	c.Assert(person.validatePaymentCodes(), check.NotNil)
 	c.Assert(person.validateAmounts(), check.NotNil)
@@ -354,6 +358,59 @@ func (t *FileTest) TestSample1099PatrJson(c *check.C) {
 	c.Assert(err, check.IsNil)
 }
 
+func (t *FileTest) TestSample1099QaJson(c *check.C) {
+	f1, err := CreateFile(t.sample1099QaJson)
+	c.Assert(err, check.IsNil)
+	buf1, err := json.Marshal(f1)
+	c.Assert(err, check.IsNil)
+	var prettyJSON1 bytes.Buffer
+	json.Indent(&prettyJSON1, buf1, "", "  ")
+	ascii := f1.Ascii()
+	f2, err := CreateFile(ascii)
+	c.Assert(err, check.IsNil)
+	buf2, err := json.Marshal(f2)
+	c.Assert(err, check.IsNil)
+	var prettyJSON2 bytes.Buffer
+	json.Indent(&prettyJSON2, buf2, "", "  ")
+	c.Assert(prettyJSON1.String(), check.Equals, prettyJSON2.String())
+	err = f1.Validate()
+	c.Assert(err, check.IsNil)
+	err = f2.Validate()
+	c.Assert(err, check.IsNil)
+
+	// amount code 4 isn't a box of 1099-QA
+	payee := f1.Payers()[0].PayeeRecords()[0]
+	payee.PaymentAmount4 = 100
+	c.Assert(errors.Is(f1.Validate(), utils.ErrUnexpectedPaymentAmount), check.Equals, true)
+	f1.Payers()[0].PayerRecord().AmountCodes = "1234"
+	c.Assert(f1.Validate(), check.NotNil)
+}
+
+func (t *FileTest) TestSample5498QaJson(c *check.C) {
+	f1, err := CreateFile(t.sample5498QaJson)
+	c.Assert(err, check.IsNil)
+	buf1, err := json.Marshal(f1)
+	c.Assert(err, check.IsNil)
+	var prettyJSON1 bytes.Buffer
+	json.Indent(&prettyJSON1, buf1, "", "  ")
+	ascii := f1.Ascii()
+	f2, err := CreateFile(ascii)
+	c.Assert(err, check.IsNil)
+	buf2, err := json.Marshal(f2)
+	c.Assert(err, check.IsNil)
+	var prettyJSON2 bytes.Buffer
+	json.Indent(&prettyJSON2, buf2, "", "  ")
+	c.Assert(prettyJSON1.String(), check.Equals, prettyJSON2.String())
+	err = f1.Validate()
+	c.Assert(err, check.IsNil)
+	err = f2.Validate()
+	c.Assert(err, check.IsNil)
+
+	c.Assert(f1.Payers()[0].PayeeRecords()[0].Extension().Type(), check.Equals, config.Sub5498QaType)
+	f1.Payers()[0].PayerRecord().AmountCodes = "1345"
+	c.Assert(f1.Validate(), check.NotNil)
+}
+
 func (t *FileTest) TestOneTransactionFileWithoutKJson(c *check.C) {
 	f1, err := CreateFile(t.oneTransactionWithoutKJson)
 	c.Assert(err, check.IsNil)
@@ -369,3 +426,80 @@ func (t *FileTest) TestOneTransactionFileInvalidStateJson(c *check.C) {
 	c.Assert(err, check.NotNil)
 	c.Assert(err.Error(), check.Equals, "is invalid combined federal/tate code in K record")
 }
+
+func (t *FileTest) TestTaxYearOfRecords(c *check.C) {
+	for _, buf := range [][]byte{t.oneTransactionJson, t.oneTransactionAscii} {
+		f, err := CreateFile(buf)
+		c.Assert(err, check.IsNil)
+		year := f.TransmitterRecord().TaxYear()
+		c.Assert(year, check.Not(check.Equals), 0)
+		c.Assert(f.EndTransmitterRecord().TaxYear(), check.Equals, year)
+		person := f.Payers()[0]
+		c.Assert(person.EndPayerRecord().TaxYear(), check.Equals, person.PayerRecord().TaxYear())
+		c.Assert(person.StateRecords()[0].TaxYear(), check.Equals, person.PayerRecord().TaxYear())
+	}
+
+	reader := NewReader(bytes.NewReader(t.oneTransactionAscii))
+	for {
+		r, err := reader.Read()
+		if err != nil {
+			break
+		}
+		c.Assert(records.TaxYear(r), check.Not(check.Equals), 0)
+	}
+}
+
+func (t *FileTest) TestPriorYearDataLayout(c *check.C) {
+	defer config.UnregisterRevision(2099)
+
+	// revision moving total number of payees of “F” record
+	c.Assert(config.RegisterRevision(&config.Revision{
+		TaxYear: 2099,
+		Records: map[string]map[string]config.SpecField{
+			config.FRecordType: {
+				"RecordType":           {Start: 0, Length: 1, Type: config.Alphanumeric, Required: config.Required},
+				"NumberPayerRecords":   {Start: 1, Length: 8, Type: config.ZeroNumeric, Required: config.Required},
+				"Zero":                 {Start: 9, Length: 21, Type: config.ZeroNumeric, Required: config.Applicable},
+				"TotalNumberPayees":    {Start: 30, Length: 8, Type: config.ZeroNumeric, Required: config.Applicable},
+				"Blank2":               {Start: 38, Length: 461, Type: config.Alphanumeric, Required: config.Nullable},
+				"RecordSequenceNumber": {Start: 499, Length: 8, Type: config.ZeroNumeric, Required: config.Required},
+				"Blank4":               {Start: 507, Length: 243, Type: config.Alphanumeric, Required: config.Nullable},
+			},
+		},
+	}), check.IsNil)
+
+	// prior year data of 2017 is written with the current revision
+	f, err := CreateFile(t.oneTransactionJson)
+	c.Assert(err, check.IsNil)
+	instance := f.(*fileInstance)
+	person := instance.PaymentPersons[0]
+	c.Assert(records.TaxYear(person.Payer), check.Equals, 2099)
+	c.Assert(records.TaxYear(person.Payees[0]), check.Equals, 2099)
+	c.Assert(records.TaxYear(person.EndPayer), check.Equals, 2099)
+	c.Assert(f.Validate(), check.IsNil)
+
+	ascii := f.Ascii()
+	fRecord := ascii[len(ascii)-config.RecordLength:]
+	c.Assert(string(fRecord[30:38]), check.Equals, "00000002")
+
+	parsed, err := CreateFile(ascii)
+	c.Assert(err, check.IsNil)
+	c.Assert(parsed.Validate(), check.IsNil)
+	c.Assert(records.TaxYear(parsed.(*fileInstance).PaymentPersons[0].Payees[0]), check.Equals, 2099)
+	c.Assert(string(parsed.Ascii()), check.Equals, string(ascii))
+
+	reader := NewReader(bytes.NewReader(ascii))
+	for {
+		r, err := reader.Read()
+		if err != nil {
+			c.Assert(err, check.Equals, io.EOF)
+			break
+		}
+		c.Assert(records.TaxYear(r), check.Equals, 2099)
+	}
+
+	// payment year selects the revision of current year data
+	instance.Transmitter.(*records.TRecord).PriorYearDataIndicator = ""
+	instance.syncTaxYear()
+	c.Assert(records.TaxYear(person.Payees[0]), check.Equals, 2017)
+}
@@ -0,0 +1,149 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package file
+
+import (
+	"strconv"
+
+	"github.com/moov-io/irs/pkg/config"
+	"github.com/moov-io/irs/pkg/records"
+	"github.com/moov-io/irs/pkg/utils"
+)
+
+// amount codes of payment amount and control total fields
+var amountCodes = []string{"1", "2", "3", "4", "5", "6", "7", "8", "9", "A", "B", "C", "D", "E", "F", "G", "H", "J"}
+
+// Finalize recomputes record counts, control totals and record sequence numbers
+//
+// Number of payees and control totals of “C” and “K” records are accumulated
+// from the “B” records of each payer, counts of “T” and “F” records from all payers,
+// and every record sequence number is assigned in file order. Blank name controls
+// of “A” and “B” records are derived from the first name lines and blank types of
+// TIN of “B” records are inferred from the TIN and name where possible.
+func (f *fileInstance) Finalize() error {
+	tRecord, fRecord, err := f.getRecords()
+	if err != nil {
+		return err
+	}
+
+	for _, person := range f.PaymentPersons {
+		person.fillTypesOfTIN()
+		person.fillNameControls()
+		if err = person.finalize(); err != nil {
+			return err
+		}
+	}
+
+	numberPayees := f.getNumberPayees()
+	tRecord.TotalNumberPayees = numberPayees
+	fRecord.NumberPayerRecords = len(f.PaymentPersons)
+	fRecord.TotalNumberPayees = numberPayees
+
+	f.finalizeSequenceNumbers()
+	f.syncTaxYear()
+	return nil
+}
+
+func (f *fileInstance) finalizeSequenceNumbers() {
+	sequenceNumber := 1
+	f.Transmitter.SetSequenceNumber(sequenceNumber)
+	for _, person := range f.PaymentPersons {
+		for _, record := range person.records() {
+			sequenceNumber++
+			record.SetSequenceNumber(sequenceNumber)
+		}
+	}
+	sequenceNumber++
+	f.EndTransmitter.SetSequenceNumber(sequenceNumber)
+}
+
+// finalize recomputes totals of “C” and “K” records from payees
+func (p *PaymentPerson) finalize() error {
+	if err := p.validateRecords(); err != nil {
+		return err
+	}
+	_, cRecord, err := p.getRecords()
+	if err != nil {
+		return err
+	}
+
+	payees := make([]*records.BRecord, 0, len(p.Payees))
+	for _, payee := range p.Payees {
+		bRecord, ok := payee.(*records.BRecord)
+		if !ok {
+			return utils.NewErrUnexpectedRecord("payee", payee)
+		}
+		payees = append(payees, bRecord)
+	}
+
+	cRecord.NumberPayees = len(payees)
+	if err = setControlTotals(cRecord, payees); err != nil {
+		return err
+	}
+
+	for _, state := range p.States {
+		kRecord, ok := state.(*records.KRecord)
+		if !ok {
+			return utils.NewErrUnexpectedRecord("state", state)
+		}
+		if err = finalizeState(kRecord, payees); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// finalizeState recomputes totals of the “K” record from payees of its state
+func finalizeState(kRecord *records.KRecord, payees []*records.BRecord) error {
+	state, exited := config.StateAbbreviationCodes[kRecord.CombinedFederalStateCode]
+	if !exited {
+		return utils.NewErrValidValue("combined federal state code")
+	}
+
+	statePayees := make([]*records.BRecord, 0)
+	stateTax, localTax, withheld := 0, 0, false
+	for _, payee := range payees {
+		if config.ParticipateStateCodes[payee.FederalState()] != state {
+			continue
+		}
+		statePayees = append(statePayees, payee)
+		if stateAmount, localAmount, err := payee.IncomeTax(); err == nil {
+			stateTax += stateAmount
+			localTax += localAmount
+			withheld = true
+		}
+	}
+
+	// totals of payees without state or local income tax are zero, not the totals before finalizing
+	if !withheld {
+		stateTax, localTax = 0, 0
+	}
+	kRecord.NumberPayees = len(statePayees)
+	kRecord.StateIncomeTaxWithheldTotal = strconv.Itoa(stateTax)
+	kRecord.LocalIncomeTaxWithheldTotal = strconv.Itoa(localTax)
+	return setControlTotals(kRecord, statePayees)
+}
+
+type controlTotaler interface {
+	SetControlTotal(string, int) error
+}
+
+func setControlTotals(record controlTotaler, payees []*records.BRecord) error {
+	for _, code := range amountCodes {
+		total := 0
+		for _, payee := range payees {
+			amount, err := payee.PaymentAmount(code)
+			if err != nil {
+				return err
+			}
+			total += amount
+		}
+		if err := record.SetControlTotal(code, total); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,157 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package file
+
+import (
+	"gopkg.in/check.v1"
+
+	"github.com/moov-io/irs/pkg/records"
+	"github.com/moov-io/irs/pkg/subrecords"
+	"github.com/moov-io/irs/pkg/utils"
+)
+
+func (t *FileTest) TestFinalize(c *check.C) {
+	f, err := CreateFile(t.oneTransactionJson)
+	c.Assert(err, check.IsNil)
+	instance := f.(*fileInstance)
+
+	person := instance.PaymentPersons[0]
+	payee := person.Payees[0].(*records.BRecord)
+	payee.PaymentAmount1 = 2500
+	for _, record := range person.records() {
+		record.SetSequenceNumber(0)
+	}
+	cRecord := person.EndPayer.(*records.CRecord)
+	cRecord.NumberPayees = 0
+	cRecord.ControlTotal7 = 0
+	kRecord := person.States[0].(*records.KRecord)
+	kRecord.NumberPayees = 0
+	kRecord.ControlTotal7 = 0
+	tRecord := instance.Transmitter.(*records.TRecord)
+	tRecord.TotalNumberPayees = 0
+	fRecord := instance.EndTransmitter.(*records.FRecord)
+	fRecord.NumberPayerRecords = 0
+	fRecord.TotalNumberPayees = 0
+	fRecord.SetSequenceNumber(0)
+
+	c.Assert(f.Validate(), check.NotNil)
+	payer := person.Payer.(*records.ARecord)
+	payer.AmountCodes = "17"
+	c.Assert(f.Finalize(), check.IsNil)
+	c.Assert(f.Validate(), check.IsNil)
+
+	c.Assert(cRecord.NumberPayees, check.Equals, 2)
+	c.Assert(cRecord.ControlTotal1, check.Equals, 2500)
+	c.Assert(cRecord.ControlTotal7, check.Equals, 1400)
+	c.Assert(kRecord.NumberPayees, check.Equals, 2)
+	c.Assert(kRecord.ControlTotal1, check.Equals, 2500)
+	c.Assert(kRecord.StateIncomeTaxWithheldTotal, check.Equals, "4")
+	c.Assert(kRecord.LocalIncomeTaxWithheldTotal, check.Equals, "3")
+	c.Assert(tRecord.TotalNumberPayees, check.Equals, 2)
+	c.Assert(fRecord.NumberPayerRecords, check.Equals, 1)
+	c.Assert(fRecord.TotalNumberPayees, check.Equals, 2)
+	c.Assert(person.EndPayer.SequenceNumber(), check.Equals, 5)
+	c.Assert(fRecord.SequenceNumber(), check.Equals, 7)
+}
+
+func (t *FileTest) TestFinalizeStateWithoutWithholding(c *check.C) {
+	f, err := CreateFile(t.oneTransactionJson)
+	c.Assert(err, check.IsNil)
+	instance := f.(*fileInstance)
+	kRecord := instance.PaymentPersons[0].States[0].(*records.KRecord)
+	c.Assert(f.Finalize(), check.IsNil)
+	c.Assert(kRecord.StateIncomeTaxWithheldTotal, check.Not(check.Equals), "0")
+
+	// totals of a state without payees are reset
+	c.Assert(finalizeState(kRecord, nil), check.IsNil)
+	c.Assert(kRecord.NumberPayees, check.Equals, 0)
+	c.Assert(kRecord.StateIncomeTaxWithheldTotal, check.Equals, "0")
+	c.Assert(kRecord.LocalIncomeTaxWithheldTotal, check.Equals, "0")
+	c.Assert(kRecord.ControlTotal1, check.Equals, 0)
+}
+
+func (t *FileTest) TestFinalizeStateOfKansas(c *check.C) {
+	f, err := CreateFile(t.oneTransactionJson)
+	c.Assert(err, check.IsNil)
+	instance := f.(*fileInstance)
+	for _, payee := range f.Payers()[0].PayeeRecords() {
+		payee.Extension().(*subrecords.Sub1099MISC).CombinedFSCode = 20
+	}
+	kRecord := instance.PaymentPersons[0].States[0].(*records.KRecord)
+	kRecord.CombinedFederalStateCode = "KS"
+
+	// payees of Kansas belong to the “K” record of KS
+	c.Assert(f.Finalize(), check.IsNil)
+	c.Assert(kRecord.NumberPayees, check.Equals, 2)
+	c.Assert(kRecord.StateIncomeTaxWithheldTotal, check.Equals, "4")
+	c.Assert(kRecord.LocalIncomeTaxWithheldTotal, check.Equals, "3")
+	c.Assert(f.Validate(), check.IsNil)
+}
+
+func (t *FileTest) TestFinalizeWithError(c *check.C) {
+	c.Assert((&fileInstance{}).Finalize(), check.NotNil)
+
+	f, err := CreateFile(t.oneTransactionJson)
+	c.Assert(err, check.IsNil)
+	instance := f.(*fileInstance)
+	kRecord := instance.PaymentPersons[0].States[0].(*records.KRecord)
+	kRecord.CombinedFederalStateCode = "??"
+	c.Assert(f.Finalize(), check.NotNil)
+
+	instance.PaymentPersons[0].EndPayer = nil
+	c.Assert(f.Finalize(), check.NotNil)
+}
+
+func (t *FileTest) TestFinalizeSignedAmounts(c *check.C) {
+	f, err := CreateFile(t.jsonWith1099BLosses)
+	c.Assert(err, check.IsNil)
+	person := f.Payers()[0]
+	payee := person.PayeeRecords()[0]
+	payee.PaymentAmount9 = -70000
+	person.States = nil
+	c.Assert(f.Finalize(), check.IsNil)
+	c.Assert(f.Validate(), check.IsNil)
+	cRecord := person.EndPayer.(*records.CRecord)
+	c.Assert(cRecord.ControlTotal9, check.Equals, -50000)
+
+	// negative amounts are written with sign and read back
+	parsed, err := CreateFile(f.Ascii())
+	c.Assert(err, check.IsNil)
+	c.Assert(parsed.Validate(), check.IsNil)
+	c.Assert(parsed.Payers()[0].PayeeRecords()[0].PaymentAmount9, check.Equals, -70000)
+
+	// state totals are within the losses and gains of payees
+	kRecord := &records.KRecord{ControlTotal9: -20000}
+	person.States = append(person.States, kRecord)
+	c.Assert(person.validateAmounts(), check.IsNil)
+	kRecord.ControlTotal9 = -90000
+	c.Assert(person.validateAmounts(), check.Equals, utils.ErrInvalidTotalAmounts)
+	kRecord.ControlTotal9 = 30000
+	c.Assert(person.validateAmounts(), check.Equals, utils.ErrInvalidTotalAmounts)
+	person.States = nil
+
+	// amounts of 1099-OID may be negative
+	f, err = CreateFile(t.sample1099OidJson)
+	c.Assert(err, check.IsNil)
+	person = f.Payers()[0]
+	person.PayeeRecords()[0].PaymentAmount7 = -700
+	c.Assert(f.Finalize(), check.IsNil)
+	c.Assert(f.Validate(), check.IsNil)
+	c.Assert(person.EndPayer.(*records.CRecord).ControlTotal7, check.Equals, -700)
+	parsed, err = CreateFile(f.Ascii())
+	c.Assert(err, check.IsNil)
+	c.Assert(parsed.Validate(), check.IsNil)
+	c.Assert(parsed.Payers()[0].PayeeRecords()[0].PaymentAmount7, check.Equals, -700)
+
+	f, err = CreateFile(t.oneTransactionJson)
+	c.Assert(err, check.IsNil)
+	person = f.Payers()[0]
+	person.States[0].(*records.KRecord).ControlTotal7 = -1
+	c.Assert(utils.ErrorCode(person.validateAmounts()), check.Equals, utils.CodeNegativeAmount)
+	person.PayeeRecords()[0].PaymentAmount7 = -700
+	c.Assert(f.Finalize(), check.IsNil)
+	err = f.Validate()
+	c.Assert(utils.ErrorCode(err), check.Equals, utils.CodeNegativeAmount)
+}
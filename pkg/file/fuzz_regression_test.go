@@ -32,7 +32,7 @@ func TestCreateFile_EmptyNoPanic(t *testing.T) {
 	})
 }
 
-// Truncated file ending mid C-record previously panicked in paymentPerson.Parse
+// Truncated file ending mid C-record previously panicked in PaymentPerson.Parse
 // (slice bounds out of range). See corpus test/fuzz/testdata/fuzz/FuzzCreateFile/292f0f96f8173139
 func TestCreateFile_TruncatedCRecordNoPanic(t *testing.T) {
 	// T + A + two full B records + single trailing "C" byte (no full 750-byte C record)
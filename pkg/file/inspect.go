@@ -0,0 +1,321 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package file
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/moov-io/irs/pkg/config"
+	"github.com/moov-io/irs/pkg/records"
+	"github.com/moov-io/irs/pkg/subrecords"
+	"github.com/moov-io/irs/pkg/utils"
+)
+
+// InspectionProblem is a parse or validation error of a record or field
+type InspectionProblem struct {
+	// Severity of the problem
+	Severity string `json:"severity"`
+	// Code is a stable error code
+	Code string `json:"code"`
+	// Message describes the problem
+	Message string `json:"message"`
+}
+
+// FieldInspection is a field of a fire ascii record with its raw and parsed value
+type FieldInspection struct {
+	// Name is the name of the field
+	Name string `json:"name"`
+	// StartPosition is the Pub 1220 start position of the field (one-based)
+	StartPosition int `json:"start_position"`
+	// EndPosition is the Pub 1220 end position of the field (one-based)
+	EndPosition int `json:"end_position"`
+	// Raw is the field as it appears in the record
+	Raw string `json:"raw"`
+	// Value is the parsed value, nil if the field can't be parsed
+	Value interface{} `json:"value"`
+	// Problems are the parse and validation errors of the field
+	Problems []InspectionProblem `json:"problems,omitempty"`
+}
+
+// RecordInspection is a record of a fire ascii file with all fields of its layout
+type RecordInspection struct {
+	// Position is the one-based position of the record in the file,
+	// it is the record sequence number of valid files
+	Position int `json:"position"`
+	// SequenceNumber is the parsed record sequence number, zero if it can't be parsed
+	SequenceNumber int `json:"sequence_number"`
+	// RecordType is the type of the record (T, A, B, C, K, F)
+	RecordType string `json:"record_type"`
+	// Extension is the type of the extension block of “B” records
+	Extension string `json:"extension,omitempty"`
+	// TaxYear is the tax year that selects the layout revision
+	TaxYear int `json:"tax_year,omitempty"`
+	// Fields are the fields of the layout ordered by position
+	Fields []*FieldInspection `json:"fields"`
+	// Problems are the errors of the record that don't belong to a field
+	Problems []InspectionProblem `json:"problems,omitempty"`
+}
+
+// Valid returns true if neither the record nor its fields have problems of error severity
+func (r *RecordInspection) Valid() bool {
+	if hasErrorProblem(r.Problems) {
+		return false
+	}
+	for _, field := range r.Fields {
+		if hasErrorProblem(field.Problems) {
+			return false
+		}
+	}
+	return true
+}
+
+func hasErrorProblem(problems []InspectionProblem) bool {
+	for _, problem := range problems {
+		if problem.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// String returns a human-readable form of the record, fields with problems are marked with “!”
+func (r *RecordInspection) String() string {
+	var buf strings.Builder
+	buf.WriteString(fmt.Sprintf("record %d: %s", r.Position, r.RecordType))
+	if r.SequenceNumber != r.Position {
+		buf.WriteString(fmt.Sprintf(" sequence number %d", r.SequenceNumber))
+	}
+	if len(r.Extension) > 0 {
+		buf.WriteString(fmt.Sprintf(" (%s)", r.Extension))
+	}
+	if r.TaxYear > 0 {
+		buf.WriteString(fmt.Sprintf(" tax year %d", r.TaxYear))
+	}
+	buf.WriteString("\n")
+	for _, problem := range r.Problems {
+		buf.WriteString(fmt.Sprintf("! %s %s (%s)\n", problem.Severity, problem.Message, problem.Code))
+	}
+	for _, field := range r.Fields {
+		marker := " "
+		if len(field.Problems) > 0 {
+			marker = "!"
+		}
+		value := ""
+		if field.Value != nil {
+			value = fmt.Sprint(field.Value)
+		}
+		line := fmt.Sprintf("%s %3d-%-3d %-40s %q %s", marker, field.StartPosition, field.EndPosition, field.Name, field.Raw, value)
+		buf.WriteString(strings.TrimRight(line, " ") + "\n")
+		for _, problem := range field.Problems {
+			buf.WriteString(fmt.Sprintf("      %s %s (%s)\n", problem.Severity, problem.Message, problem.Code))
+		}
+	}
+	return buf.String()
+}
+
+// Inspect walks the records of a fire ascii file and returns every field of every record
+//
+// Unlike CreateFile, Inspect doesn't stop at the first problem: fields that
+// can't be parsed and fields that fail validation are reported with the
+// record, and records out of order are inspected as they appear. Checks
+// across records like control totals aren't performed.
+func Inspect(buf []byte) []*RecordInspection {
+	data := strings.TrimRight(string(buf), "\r\n")
+	var inspections []*RecordInspection
+	var transmitter, payer records.Record
+	typeOfReturn := ""
+	for offset := 0; offset < len(data); offset += config.RecordLength {
+		end := offset + config.RecordLength
+		if end > len(data) {
+			end = len(data)
+		}
+		inspection, record := inspectRecord(data[offset:end], typeOfReturn, transmitter, payer)
+		inspection.Position = len(inspections) + 1
+		inspections = append(inspections, inspection)
+
+		switch r := record.(type) {
+		case *records.TRecord:
+			transmitter = r
+		case *records.ARecord:
+			payer = r
+			typeOfReturn = config.TypeOfReturns[r.TypeOfReturn]
+		}
+	}
+	return inspections
+}
+
+// inspectRecord parses the record tolerantly and returns its inspection with the parsed record
+func inspectRecord(data, typeOfReturn string, transmitter, payer records.Record) (*RecordInspection, records.Record) {
+	inspection := &RecordInspection{RecordType: data[:1]}
+
+	var record records.Record
+	var err error
+	year := 0
+	switch inspection.RecordType {
+	case config.TRecordType:
+		// prior year data indicator is position 6 in every layout revision
+		prior := len(data) > 5 && data[5:6] == config.PriorYearDataIndicator
+		record, year = records.NewTRecord(), config.LayoutYear(inspectYear(data), prior)
+	case config.ARecordType:
+		record, year = records.NewARecord(), config.LayoutYear(inspectYear(data), priorYearData(transmitter))
+		setPriorYearData(record, priorYearData(transmitter))
+	case config.BRecordType:
+		record, err = records.NewBRecord(typeOfReturn)
+		year = config.LayoutYear(inspectYear(data), priorYearData(transmitter))
+		setPriorYearData(record, priorYearData(transmitter))
+	case config.CRecordType, config.KRecordType:
+		record = &records.CRecord{}
+		if inspection.RecordType == config.KRecordType {
+			record = &records.KRecord{}
+		}
+		if payer != nil {
+			year = records.TaxYear(payer)
+		}
+		setTaxYear(record, year)
+	case config.FRecordType:
+		record = records.NewFRecord()
+		if transmitter != nil {
+			year = records.TaxYear(transmitter)
+		}
+		setTaxYear(record, year)
+	default:
+		inspection.Problems = append(inspection.Problems, newInspectionProblem(SeverityError, fmt.Errorf("record type %w", utils.ErrInvalidAscii)))
+		return inspection, nil
+	}
+	if err != nil {
+		inspection.Problems = append(inspection.Problems, newInspectionProblem(SeverityError, fmt.Errorf("type of return %w", err)))
+	}
+	if len(data) != config.RecordLength {
+		inspection.Problems = append(inspection.Problems, newInspectionProblem(SeverityError, fmt.Errorf("record %w", utils.ErrRecordLength)))
+	}
+
+	fields := reflect.ValueOf(record).Elem()
+	layout := config.RecordLayout(year, inspection.RecordType)
+	errs := utils.ParseFields(fields, layout, data)
+	inspection.Fields = inspectFields(fields, layout, data, 0)
+
+	if payee, ok := record.(*records.BRecord); ok && payee.Extension() != nil {
+		extension := payee.Extension()
+		subrecords.SetTaxYear(extension, year)
+		inspection.Extension = extension.Type()
+
+		offset := config.RecordLength - config.SubRecordLength
+		extData := ""
+		if len(data) > offset {
+			extData = data[offset:]
+		}
+		extFields := reflect.ValueOf(extension).Elem()
+		extLayout := config.SubRecordLayout(year, extension.Type())
+		for _, err := range utils.ParseFields(extFields, extLayout, extData) {
+			err.Start += offset
+			errs = append(errs, err)
+		}
+		// fields of the extension block replace the reserved field of the “B” record layout
+		fields := make([]*FieldInspection, 0, len(inspection.Fields))
+		for _, field := range inspection.Fields {
+			if field.StartPosition <= offset {
+				fields = append(fields, field)
+			}
+		}
+		inspection.Fields = append(fields, inspectFields(extFields, extLayout, extData, offset)...)
+	}
+	inspection.TaxYear = records.TaxYear(record)
+
+	// fields that can't be parsed have no value and their validation errors are left out
+	failed := make(map[int]bool)
+	for _, err := range errs {
+		failed[err.Start] = true
+		if field := inspection.field(err.Start + 1); field != nil {
+			field.Value = nil
+		}
+	}
+	for _, err := range records.ValidateFields(record) {
+		if !failed[err.Start] {
+			errs = append(errs, err)
+		}
+	}
+	inspection.addProblems(errs)
+
+	for _, field := range inspection.Fields {
+		if field.Name == "RecordSequenceNumber" && field.Value != nil {
+			inspection.SequenceNumber = record.SequenceNumber()
+		}
+	}
+
+	return inspection, record
+}
+
+// inspectFields returns the fields of the layout with the values parsed into fields
+func inspectFields(fields reflect.Value, layout map[string]config.SpecField, data string, offset int) []*FieldInspection {
+	var list []*FieldInspection
+	for _, spec := range config.ToSpecifications(layout) {
+		field := &FieldInspection{
+			Name:          spec.Name,
+			StartPosition: offset + spec.Field.Start + 1,
+			EndPosition:   offset + spec.Field.Start + spec.Field.Length,
+		}
+		if spec.Field.Start < len(data) {
+			end := spec.Field.Start + spec.Field.Length
+			if end > len(data) {
+				end = len(data)
+			}
+			field.Raw = data[spec.Field.Start:end]
+		}
+		if value := fields.FieldByName(spec.Name); value.IsValid() && value.CanInterface() {
+			field.Value = value.Interface()
+			if date, ok := field.Value.(time.Time); ok {
+				field.Value = nil
+				if !date.IsZero() {
+					field.Value = date.Format(config.DateFormat)
+				}
+			}
+		}
+		list = append(list, field)
+	}
+	return list
+}
+
+// addProblems adds the field errors to the fields at their positions, the others to the record
+func (r *RecordInspection) addProblems(errs []*utils.FieldError) {
+	for _, err := range errs {
+		problem := newInspectionProblem(err.Severity, err.Err)
+		field := r.field(err.Start + 1)
+		if field == nil {
+			r.Problems = append(r.Problems, problem)
+			continue
+		}
+		field.Problems = append(field.Problems, problem)
+	}
+}
+
+func (r *RecordInspection) field(startPosition int) *FieldInspection {
+	for _, field := range r.Fields {
+		if field.StartPosition == startPosition {
+			return field
+		}
+	}
+	return nil
+}
+
+func newInspectionProblem(severity string, err error) InspectionProblem {
+	return InspectionProblem{
+		Severity: severity,
+		Code:     utils.ErrorCode(err),
+		Message:  err.Error(),
+	}
+}
+
+// inspectYear returns the payment year of “T”, “A” and “B” records
+func inspectYear(data string) int {
+	if len(data) < 5 {
+		return 0
+	}
+	year, _ := strconv.Atoi(strings.TrimSpace(data[1:5]))
+	return year
+}
@@ -0,0 +1,112 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package file
+
+import (
+	"encoding/json"
+	"strings"
+
+	"gopkg.in/check.v1"
+
+	"github.com/moov-io/irs/pkg/config"
+	"github.com/moov-io/irs/pkg/utils"
+)
+
+func (t *FileTest) TestInspect(c *check.C) {
+	inspections := Inspect(t.oneTransactionAscii)
+	c.Assert(len(inspections), check.Equals, len(t.oneTransactionAscii)/config.RecordLength)
+	for index, inspection := range inspections {
+		c.Assert(inspection.Position, check.Equals, index+1)
+		c.Assert(inspection.SequenceNumber, check.Equals, index+1)
+		c.Assert(inspection.Valid(), check.Equals, true)
+	}
+	c.Assert(inspections[0].RecordType, check.Equals, config.TRecordType)
+	c.Assert(inspections[len(inspections)-1].RecordType, check.Equals, config.FRecordType)
+
+	payee := inspections[2]
+	c.Assert(payee.RecordType, check.Equals, config.BRecordType)
+	c.Assert(payee.Extension, check.Equals, config.Sub1099MiscType)
+	// prior year data of 2017 uses the current layout revision
+	c.Assert(payee.TaxYear, check.Equals, config.DefaultTaxYear)
+
+	field := payee.Fields[0]
+	c.Assert(field.Name, check.Equals, "RecordType")
+	c.Assert(field.StartPosition, check.Equals, 1)
+	c.Assert(field.EndPosition, check.Equals, 1)
+	c.Assert(field.Raw, check.Equals, "B")
+	c.Assert(field.Value, check.Equals, "B")
+
+	// fields of the extension block are placed at their positions in the record
+	last := payee.Fields[len(payee.Fields)-1]
+	c.Assert(last.EndPosition, check.Equals, config.RecordLength)
+	for index := 1; index < len(payee.Fields); index++ {
+		c.Assert(payee.Fields[index].StartPosition, check.Equals, payee.Fields[index-1].EndPosition+1)
+	}
+	c.Assert(payee.field(723).Name, check.Equals, "StateIncomeTaxWithheld")
+	c.Assert(payee.field(723).Value, check.Equals, 4)
+
+	buf, err := json.Marshal(inspections)
+	c.Assert(err, check.IsNil)
+	c.Assert(strings.Contains(string(buf), `"name":"PaymentAmount7","start_position":127,"end_position":138,"raw":"000000000700","value":700`), check.Equals, true)
+}
+
+func (t *FileTest) TestInspectProblems(c *check.C) {
+	data := []byte(string(t.oneTransactionAscii) + "\r\n")
+	payee := 2 * config.RecordLength
+	data[payee+130] = 'X'
+	data[payee+488] = 'Z'
+
+	inspections := Inspect(data)
+	c.Assert(len(inspections), check.Equals, len(t.oneTransactionAscii)/config.RecordLength)
+	c.Assert(inspections[1].Valid(), check.Equals, true)
+	c.Assert(inspections[2].Valid(), check.Equals, false)
+
+	// fields that can't be parsed have no value
+	amount := inspections[2].field(127)
+	c.Assert(amount.Name, check.Equals, "PaymentAmount7")
+	c.Assert(amount.Raw, check.Equals, "0000X0000700")
+	c.Assert(amount.Value, check.IsNil)
+	c.Assert(len(amount.Problems), check.Equals, 1)
+	c.Assert(amount.Problems[0].Code, check.Equals, utils.ErrorCode(utils.ErrNumeric))
+
+	// fields that fail validation keep their value
+	state := inspections[2].field(488)
+	c.Assert(state.Name, check.Equals, "PayeeState")
+	c.Assert(state.Value, check.Equals, "CZ")
+	c.Assert(len(state.Problems), check.Equals, 1)
+	c.Assert(state.Problems[0].Severity, check.Equals, SeverityError)
+
+	output := inspections[2].String()
+	c.Assert(strings.Contains(output, "record 3: B (1099-MISC) tax year 2020"), check.Equals, true)
+	c.Assert(strings.Contains(output, `! 127-138 PaymentAmount7`), check.Equals, true)
+	c.Assert(strings.Contains(output, `! 488-489 PayeeState`), check.Equals, true)
+	c.Assert(strings.Contains(output, `    1-1   RecordType`), check.Equals, true)
+}
+
+func (t *FileTest) TestInspectRecords(c *check.C) {
+	// unknown record types and short records are inspected as they appear
+	data := []byte(string(t.oneTransactionAscii[:config.RecordLength]) + strings.Repeat("X", config.RecordLength) + "F0001")
+	inspections := Inspect(data)
+	c.Assert(len(inspections), check.Equals, 3)
+	c.Assert(inspections[0].Valid(), check.Equals, true)
+
+	c.Assert(inspections[1].RecordType, check.Equals, "X")
+	c.Assert(len(inspections[1].Fields), check.Equals, 0)
+	c.Assert(inspections[1].Problems[0].Code, check.Equals, utils.ErrorCode(utils.ErrInvalidAscii))
+
+	short := inspections[2]
+	c.Assert(short.RecordType, check.Equals, config.FRecordType)
+	c.Assert(short.TaxYear, check.Equals, config.DefaultTaxYear)
+	c.Assert(short.Problems[0].Message, check.Equals, "record "+utils.ErrRecordLength.Error())
+	c.Assert(short.Fields[1].Raw, check.Equals, "0001")
+	c.Assert(short.Fields[1].Problems[0].Message, check.Equals, utils.ErrShortRecord.Error())
+
+	// “B” records without a payer have no extension block
+	data = append(append([]byte{}, t.oneTransactionAscii[:config.RecordLength]...), t.oneTransactionAscii[2*config.RecordLength:3*config.RecordLength]...)
+	inspections = Inspect(data)
+	c.Assert(inspections[1].Extension, check.Equals, "")
+	c.Assert(inspections[1].Valid(), check.Equals, false)
+	c.Assert(inspections[1].field(config.RecordLength - config.SubRecordLength + 1).Problems[0].Message, check.Equals, utils.ErrPayeeExtBlock.Error())
+}
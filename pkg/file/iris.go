@@ -0,0 +1,827 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package file
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/moov-io/irs/pkg/config"
+	"github.com/moov-io/irs/pkg/records"
+	"github.com/moov-io/irs/pkg/utils"
+)
+
+// TIN types of IRIS transmissions
+const (
+	irisBusinessTIN   = "BUSINESS_TIN"
+	irisIndividualTIN = "INDIVIDUAL_TIN"
+	irisUnknownTIN    = "UNKNOWN"
+)
+
+// irisTransmission is an IRIS intake transmission with a submission per payer
+type irisTransmission struct {
+	XMLName     xml.Name         `xml:"IRTransmission"`
+	Namespace   string           `xml:"xmlns,attr"`
+	Manifest    irisManifest     `xml:"IRTransmissionManifest"`
+	Submissions []irisSubmission `xml:"IRSubmission1Grp"`
+}
+
+type irisManifest struct {
+	SchemaVersionNum            string          `xml:"SchemaVersionNum"`
+	UniqueTransmissionId        string          `xml:"UniqueTransmissionId"`
+	TaxYr                       int             `xml:"TaxYr"`
+	PriorYearDataInd            string          `xml:"PriorYearDataInd"`
+	TransmissionTypeCd          string          `xml:"TransmissionTypeCd"`
+	TestCd                      string          `xml:"TestCd"`
+	TransmitterGrp              irisTransmitter `xml:"TransmitterGrp"`
+	VendorCd                    string          `xml:"VendorCd"`
+	VendorGrp                   *irisVendor     `xml:"VendorGrp,omitempty"`
+	TotalIssuerFormCnt          int             `xml:"TotalIssuerFormCnt"`
+	TotalRecipientFormCnt       int             `xml:"TotalRecipientFormCnt"`
+	PaperSubmissionInd          string          `xml:"PaperSubmissionInd"`
+	SubmissionChannelCd         string          `xml:"SubmissionChannelCd"`
+	ContactPersonInformationGrp irisContact     `xml:"ContactPersonInformationGrp"`
+}
+
+type irisTransmitter struct {
+	TIN                  string             `xml:"TIN"`
+	TINSubmittedTypeCd   string             `xml:"TINSubmittedTypeCd"`
+	TransmitterControlCd string             `xml:"TransmitterControlCd"`
+	ForeignEntityInd     string             `xml:"ForeignEntityInd"`
+	BusinessName         irisBusinessName   `xml:"BusinessName"`
+	CompanyName          irisBusinessName   `xml:"CompanyGrp>BusinessName"`
+	CompanyAddress       irisMailingAddress `xml:"CompanyGrp>MailingAddressGrp"`
+}
+
+type irisVendor struct {
+	BusinessName      irisBusinessName   `xml:"BusinessName"`
+	MailingAddressGrp irisMailingAddress `xml:"MailingAddressGrp"`
+	ContactPersonNm   string             `xml:"ContactPersonNm,omitempty"`
+	ContactPhoneNum   string             `xml:"ContactPhoneNum,omitempty"`
+	ForeignEntityInd  string             `xml:"ForeignEntityInd"`
+}
+
+type irisContact struct {
+	ContactPersonNm        string `xml:"ContactPersonNm"`
+	ContactPhoneNum        string `xml:"ContactPhoneNum"`
+	ContactEmailAddressTxt string `xml:"ContactEmailAddressTxt,omitempty"`
+}
+
+type irisBusinessName struct {
+	BusinessNameLine1Txt string `xml:"BusinessNameLine1Txt"`
+	BusinessNameLine2Txt string `xml:"BusinessNameLine2Txt,omitempty"`
+}
+
+type irisMailingAddress struct {
+	USAddress      *irisUSAddress      `xml:"USAddress,omitempty"`
+	ForeignAddress *irisForeignAddress `xml:"ForeignAddress,omitempty"`
+}
+
+type irisUSAddress struct {
+	AddressLine1Txt     string `xml:"AddressLine1Txt"`
+	CityNm              string `xml:"CityNm"`
+	StateAbbreviationCd string `xml:"StateAbbreviationCd"`
+	ZIPCd               string `xml:"ZIPCd"`
+}
+
+// irisForeignAddress carries the foreign address of Pub 1220 records, city, province,
+// postal code and country are a single line there
+type irisForeignAddress struct {
+	AddressLine1Txt string `xml:"AddressLine1Txt"`
+	CityNm          string `xml:"CityNm"`
+}
+
+type irisSubmission struct {
+	Header irisSubmissionHeader `xml:"IRSubmission1Header"`
+	Detail irisSubmissionDetail `xml:"IRSubmission1Detail"`
+}
+
+type irisSubmissionHeader struct {
+	SubmissionId              int        `xml:"SubmissionId"`
+	TaxYr                     int        `xml:"TaxYr"`
+	IssuerDetail              irisIssuer `xml:"IssuerDetail"`
+	FormTypeCd                string     `xml:"FormTypeCd"`
+	ParentFormTypeCd          string     `xml:"ParentFormTypeCd"`
+	CFSFElectionInd           string     `xml:"CFSFElectionInd"`
+	TotalReportedRcpntFormCnt int        `xml:"TotalReportedRcpntFormCnt"`
+}
+
+type irisIssuer struct {
+	ForeignEntityInd       string             `xml:"ForeignEntityInd"`
+	TIN                    string             `xml:"TIN"`
+	TINSubmittedTypeCd     string             `xml:"TINSubmittedTypeCd"`
+	BusinessName           irisBusinessName   `xml:"BusinessName"`
+	BusinessNameControlTxt string             `xml:"BusinessNameControlTxt,omitempty"`
+	MailingAddressGrp      irisMailingAddress `xml:"MailingAddressGrp"`
+	PhoneNum               string             `xml:"PhoneNum,omitempty"`
+	LastFilingInd          string             `xml:"LastFilingInd"`
+	TransferAgentInd       string             `xml:"TransferAgentInd"`
+}
+
+type irisSubmissionDetail struct {
+	Forms []irisForm `xml:",any"`
+}
+
+// irisForm is the form detail of a payee, the element name is Form<form type>Detail
+type irisForm struct {
+	XMLName          xml.Name
+	TaxYr            int                 `xml:"TaxYr"`
+	RecordId         int                 `xml:"RecordId"`
+	RecipientDetail  irisRecipient       `xml:"RecipientDetail"`
+	Elements         []irisElement       `xml:",any"`
+	StateLocalTaxGrp []irisStateLocalTax `xml:"StateLocalTaxGrp,omitempty"`
+}
+
+type irisRecipient struct {
+	TIN                string             `xml:"TIN"`
+	TINSubmittedTypeCd string             `xml:"TINSubmittedTypeCd"`
+	NameControlTxt     string             `xml:"NameControlTxt,omitempty"`
+	BusinessName       irisBusinessName   `xml:"BusinessName"`
+	MailingAddressGrp  irisMailingAddress `xml:"MailingAddressGrp"`
+	AccountNum         string             `xml:"AccountNum,omitempty"`
+	OfficeCd           string             `xml:"OfficeCd,omitempty"`
+}
+
+// irisElement is an amount or indicator element of form details
+type irisElement struct {
+	XMLName xml.Name
+	Value   string `xml:",chardata"`
+}
+
+type irisStateLocalTax struct {
+	StateAbbreviationCd string `xml:"StateTaxGrp>StateAbbreviationCd"`
+	StateTaxWithheldAmt string `xml:"StateTaxGrp>StateTaxWithheldAmt,omitempty"`
+	LocalTaxWithheldAmt string `xml:"StateTaxGrp>LocalTaxGrp>LocalTaxWithheldAmt,omitempty"`
+}
+
+// Iris returns the file as IRIS intake transmission xml
+//
+// The “T” record is the transmission manifest and every payer with its payees
+// is a submission of the form of its type of return. Amounts are decimal
+// dollars. Types of return and amount codes without IRIS elements of
+// config.IrisFormTypes and config.IrisAmountElements, and corrected returns,
+// are rejected with utils.ErrUnsupportedIris.
+func (f *fileInstance) Iris() ([]byte, error) {
+	transmitter := f.TransmitterRecord()
+	if transmitter == nil {
+		return nil, utils.ErrInvalidFile
+	}
+
+	transmission := irisTransmission{
+		Namespace: config.IrisNamespace,
+		Manifest:  newIrisManifest(transmitter),
+	}
+	for index, person := range f.PaymentPersons {
+		if person == nil {
+			continue
+		}
+		submission, err := person.irisSubmission(index + 1)
+		if err != nil {
+			return nil, fmt.Errorf("payer %d: %w", index+1, err)
+		}
+		transmission.Submissions = append(transmission.Submissions, *submission)
+		transmission.Manifest.TotalIssuerFormCnt++
+		transmission.Manifest.TotalRecipientFormCnt += submission.Header.TotalReportedRcpntFormCnt
+	}
+	transmission.Manifest.UniqueTransmissionId = irisTransmissionId(f.Ascii(), transmitter.TCC)
+
+	buf, err := xml.MarshalIndent(transmission, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), buf...), nil
+}
+
+func newIrisManifest(transmitter *records.TRecord) irisManifest {
+	manifest := irisManifest{
+		SchemaVersionNum:    config.IrisSchemaVersion,
+		TaxYr:               transmitter.PaymentYear,
+		PriorYearDataInd:    irisIndicator(transmitter.PriorYearDataIndicator == config.PriorYearDataIndicator),
+		TransmissionTypeCd:  "O",
+		TestCd:              "P",
+		VendorCd:            transmitter.VendorIndicator,
+		PaperSubmissionInd:  "0",
+		SubmissionChannelCd: "A2A",
+		TransmitterGrp: irisTransmitter{
+			TIN:                  transmitter.TIN,
+			TINSubmittedTypeCd:   irisBusinessTIN,
+			TransmitterControlCd: transmitter.TCC,
+			ForeignEntityInd:     irisIndicator(transmitter.ForeignEntityIndicator == config.ForeignEntityIndicator),
+			BusinessName:         irisBusinessName{transmitter.TransmitterName, transmitter.TransmitterNameContinuation},
+			CompanyName:          irisBusinessName{transmitter.CompanyName, transmitter.CompanyNameContinuation},
+			CompanyAddress:       newIrisAddress(false, transmitter.CompanyMailingAddress, transmitter.CompanyCity, transmitter.CompanyState, transmitter.CompanyZipCode),
+		},
+		ContactPersonInformationGrp: irisContact{
+			ContactPersonNm:        transmitter.ContactName,
+			ContactPhoneNum:        transmitter.ContactTelephoneNumber,
+			ContactEmailAddressTxt: transmitter.ContactEmailAddress,
+		},
+	}
+	if transmitter.TestFileIndicator == config.TestFileIndicator {
+		manifest.TestCd = "T"
+	}
+	if transmitter.VendorIndicator == config.VendorIndicatorPurchased {
+		manifest.VendorGrp = &irisVendor{
+			BusinessName:      irisBusinessName{BusinessNameLine1Txt: transmitter.VendorName},
+			MailingAddressGrp: newIrisAddress(false, transmitter.VendorMailingAddress, transmitter.VendorCity, transmitter.VendorState, transmitter.VendorZipCode),
+			ContactPersonNm:   transmitter.VendorContactName,
+			ContactPhoneNum:   transmitter.VendorContactTelephoneNumber,
+			ForeignEntityInd:  irisIndicator(transmitter.VendorForeignEntityIndicator == config.ForeignEntityIndicator),
+		}
+	}
+	return manifest
+}
+
+// irisSubmission returns the submission of the payer with a form detail per payee
+func (p *PaymentPerson) irisSubmission(id int) (*irisSubmission, error) {
+	payer, _, err := p.getRecords()
+	if err != nil {
+		return nil, err
+	}
+	typeOfReturn, err := p.getTypeOfReturn()
+	if err != nil {
+		return nil, err
+	}
+	formType, ok := config.IrisFormTypes[typeOfReturn]
+	if !ok {
+		return nil, fmt.Errorf("type of return %s %w", typeOfReturn, utils.ErrUnsupportedIris)
+	}
+
+	submission := &irisSubmission{
+		Header: irisSubmissionHeader{
+			SubmissionId: id,
+			TaxYr:        payer.PaymentYear,
+			IssuerDetail: irisIssuer{
+				ForeignEntityInd:       irisIndicator(payer.ForeignEntityIndicator == config.ForeignEntityIndicator),
+				TIN:                    payer.TIN,
+				TINSubmittedTypeCd:     irisBusinessTIN,
+				BusinessName:           irisBusinessName{payer.FirstPayerNameLine, payer.SecondPayerNameLine},
+				BusinessNameControlTxt: payer.PayerNameControl,
+				MailingAddressGrp:      newIrisAddress(payer.ForeignEntityIndicator == config.ForeignEntityIndicator, payer.PayerShippingAddress, payer.PayerCity, payer.PayerState, payer.PayerZipCode),
+				PhoneNum:               payer.PayerTelephoneNumber,
+				LastFilingInd:          irisIndicator(payer.LastFilingIndicator == config.LastFilingIndicator),
+				TransferAgentInd:       irisIndicator(payer.TransferAgentIndicator == config.TransferAgentIndicator),
+			},
+			FormTypeCd:       formType,
+			ParentFormTypeCd: config.IrisParentFormType,
+			CFSFElectionInd:  irisIndicator(payer.CombinedFSFilingProgram == config.FSFilingProgramApproved),
+		},
+	}
+
+	for _, payee := range p.PayeeRecords() {
+		form, err := newIrisForm(payee, formType, typeOfReturn)
+		if err != nil {
+			return nil, fmt.Errorf("payee %d: %w", payee.RecordSequenceNumber, err)
+		}
+		form.RecordId = len(submission.Detail.Forms) + 1
+		submission.Detail.Forms = append(submission.Detail.Forms, *form)
+	}
+	submission.Header.TotalReportedRcpntFormCnt = len(submission.Detail.Forms)
+	return submission, nil
+}
+
+// newIrisForm returns the form detail of the payee with the amounts and indicators of the type of return
+func newIrisForm(payee *records.BRecord, formType, typeOfReturn string) (*irisForm, error) {
+	if len(payee.CorrectedReturnIndicator) > 0 {
+		return nil, fmt.Errorf("corrected return %w", utils.ErrUnsupportedIris)
+	}
+
+	form := &irisForm{
+		XMLName: xml.Name{Local: "Form" + formType + "Detail"},
+		TaxYr:   payee.PaymentYear,
+		RecipientDetail: irisRecipient{
+			TIN:                payee.TIN,
+			TINSubmittedTypeCd: irisTINType(payee.TypeOfTIN),
+			NameControlTxt:     payee.NameControl,
+			BusinessName:       irisBusinessName{payee.FirstPayeeNameLine, payee.SecondPayeeNameLine},
+			MailingAddressGrp:  newIrisAddress(payee.ForeignCountryIndicator == config.ForeignCountryIndicator, payee.PayeeMailingAddress, payee.PayeeCity, payee.PayeeState, payee.PayeeZipCode),
+			AccountNum:         payee.PayerAccountNumber,
+			OfficeCd:           payee.PayerOfficeCode,
+		},
+	}
+
+	mapped := make(map[string]bool)
+	for _, amount := range config.IrisAmountElements[typeOfReturn] {
+		mapped[amount.Code] = true
+		value, err := payee.PaymentAmount(amount.Code)
+		if err != nil || value == 0 {
+			continue
+		}
+		form.Elements = append(form.Elements, newIrisElement(amount.Element, utils.FormatDollars(value)))
+	}
+	for _, code := range strings.Split(payee.PaymentCodes(), "") {
+		if len(code) > 0 && !mapped[code] {
+			return nil, fmt.Errorf("payment amount %s %w", code, utils.ErrUnsupportedIris)
+		}
+	}
+
+	extension := payee.Extension()
+	if extension == nil {
+		return nil, utils.ErrPayeeExtBlock
+	}
+	for _, indicator := range config.IrisIndicatorElements {
+		if field, err := utils.GetField(extension, indicator.Field); err == nil {
+			form.Elements = append(form.Elements, newIrisElement(indicator.Element, irisIndicator(len(strings.TrimSpace(field.String())) > 0)))
+		}
+	}
+
+	state := irisStateLocalTax{}
+	if field, err := utils.GetField(extension, "StateIncomeTaxWithheld"); err == nil && field.Int() != 0 {
+		state.StateTaxWithheldAmt = utils.FormatDollars(int(field.Int()))
+	}
+	if field, err := utils.GetField(extension, "LocalIncomeTaxWithheld"); err == nil && field.Int() != 0 {
+		state.LocalTaxWithheldAmt = utils.FormatDollars(int(field.Int()))
+	}
+	if code := extension.FederalState(); code > 0 {
+		state.StateAbbreviationCd = utils.StateAbbreviation(config.ParticipateStateCodes[code])
+	}
+	if len(state.StateAbbreviationCd) > 0 || len(state.StateTaxWithheldAmt) > 0 || len(state.LocalTaxWithheldAmt) > 0 {
+		if len(state.StateAbbreviationCd) == 0 {
+			state.StateAbbreviationCd = payee.PayeeState
+		}
+		form.StateLocalTaxGrp = append(form.StateLocalTaxGrp, state)
+	}
+	return form, nil
+}
+
+func newIrisElement(name, value string) irisElement {
+	return irisElement{XMLName: xml.Name{Local: name}, Value: value}
+}
+
+func newIrisAddress(foreign bool, address, city, state, zipCode string) irisMailingAddress {
+	if foreign {
+		return irisMailingAddress{ForeignAddress: &irisForeignAddress{AddressLine1Txt: address, CityNm: city}}
+	}
+	return irisMailingAddress{USAddress: &irisUSAddress{
+		AddressLine1Txt:     address,
+		CityNm:              city,
+		StateAbbreviationCd: state,
+		ZIPCd:               zipCode,
+	}}
+}
+
+func irisIndicator(set bool) string {
+	if set {
+		return "1"
+	}
+	return "0"
+}
+
+func irisTINType(typeOfTIN string) string {
+	switch typeOfTIN {
+	case config.TinType1:
+		return irisBusinessTIN
+	case config.TinType2:
+		return irisIndividualTIN
+	}
+	return irisUnknownTIN
+}
+
+// irisTransmissionId returns the unique transmission id of the contents, a uuid derived
+// from the fire ascii of the file so the same file always has the same id
+func irisTransmissionId(ascii []byte, tcc string) string {
+	sum := sha256.Sum256(ascii)
+	id := hex.EncodeToString(sum[:16])
+	var buf bytes.Buffer
+	for index, part := range []int{8, 4, 4, 4, 12} {
+		if index > 0 {
+			buf.WriteString("-")
+		}
+		buf.WriteString(id[:part])
+		id = id[part:]
+	}
+	return fmt.Sprintf("%s:IRIS:%s::A", buf.String(), tcc)
+}
+
+// IrisError is an element of an imported IRIS transmission that can't be read into records
+type IrisError struct {
+	// Submission is the one-based number of the submission, zero for the manifest
+	Submission int
+	// Form is the one-based number of the form detail of the submission, zero for the submission header
+	Form int
+	// Element is the path of the element in the form detail, submission or transmission
+	Element string
+	// Err is the error of the element
+	Err error
+}
+
+func (e *IrisError) Error() string {
+	switch {
+	case e.Form > 0:
+		return fmt.Sprintf("submission %d form %d element %s: %v", e.Submission, e.Form, e.Element, e.Err)
+	case e.Submission > 0:
+		return fmt.Sprintf("submission %d element %s: %v", e.Submission, e.Element, e.Err)
+	}
+	return fmt.Sprintf("element %s: %v", e.Element, e.Err)
+}
+
+func (e *IrisError) Unwrap() error {
+	return e.Err
+}
+
+// IrisErrors collects errors of all elements of the imported IRIS transmission
+type IrisErrors []*IrisError
+
+// Error returns all errors, one error per line
+func (e IrisErrors) Error() string {
+	lines := make([]string, 0, len(e))
+	for _, err := range e {
+		lines = append(lines, err.Error())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// irisNode is an element of any IRIS transmission, used to find elements without records fields
+type irisNode struct {
+	XMLName xml.Name
+	Text    string     `xml:",chardata"`
+	Nodes   []irisNode `xml:",any"`
+}
+
+// irisLeaf is an element with text of an IRIS transmission
+type irisLeaf struct {
+	// path is the path of the element from the root without numbers
+	path       string
+	element    string
+	submission int
+	form       int
+}
+
+// ImportIris returns a file with payers and payees of an IRIS intake transmission
+//
+// The manifest is the transmitter “T” record, every submission is a payer “A”
+// record with a payee “B” record per form detail, and payers of the combined
+// federal/state filing program get a state totals “K” record per state.
+// Elements without a place in Pub 1220 records, amounts without an amount code
+// of the form and values that can't be converted are returned as IrisErrors
+// instead of being dropped. The file is finalized and validated.
+func ImportIris(r io.Reader) (File, error) {
+	buf, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	transmission := irisTransmission{}
+	if err = xml.Unmarshal(buf, &transmission); err != nil {
+		return nil, err
+	}
+	errs, err := irisUnsupportedElements(buf, &transmission)
+	if err != nil {
+		return nil, err
+	}
+
+	builder := NewBuilder()
+	transmitter, manifestErrs := transmission.Manifest.transmitter()
+	errs = append(errs, manifestErrs...)
+	builder.Transmitter(transmitter)
+	for index, submission := range transmission.Submissions {
+		payer, payees, submissionErrs := submission.records(index + 1)
+		errs = append(errs, submissionErrs...)
+		if len(submissionErrs) > 0 {
+			continue
+		}
+		builder.AddPayer(payer)
+
+		states := make([]string, 0)
+		existed := make(map[string]bool)
+		for _, payee := range payees {
+			builder.AddPayee(payee)
+			if state, ok := stateAbbreviation(payee.FederalState()); ok && !existed[state] {
+				existed[state] = true
+				states = append(states, state)
+			}
+		}
+
+		// state totals of the combined federal/state filing program
+		if payer.CombinedFSFilingProgram == config.FSFilingProgramApproved {
+			for _, state := range states {
+				builder.AddState(&records.KRecord{CombinedFederalStateCode: state})
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errs
+	}
+	return builder.Build()
+}
+
+// irisUnsupportedElements returns errors of the elements with text of the transmission
+// that aren't elements of the IRIS structures of the file package
+func irisUnsupportedElements(buf []byte, transmission *irisTransmission) (IrisErrors, error) {
+	supported, err := xml.Marshal(transmission)
+	if err != nil {
+		return nil, err
+	}
+	known := make(map[string]bool)
+	leaves, err := irisLeaves(supported)
+	if err != nil {
+		return nil, err
+	}
+	for _, leaf := range leaves {
+		known[leaf.path] = true
+	}
+
+	leaves, err = irisLeaves(buf)
+	if err != nil {
+		return nil, err
+	}
+	var errs IrisErrors
+	for _, leaf := range leaves {
+		if !known[leaf.path] {
+			errs = append(errs, &IrisError{Submission: leaf.submission, Form: leaf.form, Element: leaf.element, Err: utils.ErrUnsupportedIris})
+		}
+	}
+	return errs, nil
+}
+
+// irisLeaves returns the elements with text of the xml document
+func irisLeaves(buf []byte) ([]irisLeaf, error) {
+	root := irisNode{}
+	if err := xml.Unmarshal(buf, &root); err != nil {
+		return nil, err
+	}
+
+	var leaves []irisLeaf
+	var walk func(node irisNode, path []string, leaf irisLeaf, start int)
+	walk = func(node irisNode, path []string, leaf irisLeaf, start int) {
+		path = append(path[:len(path):len(path)], node.XMLName.Local)
+		if len(node.Nodes) == 0 {
+			if len(strings.TrimSpace(node.Text)) > 0 {
+				leaf.path = strings.Join(path, "/")
+				leaf.element = strings.Join(path[start:], "/")
+				leaves = append(leaves, leaf)
+			}
+			return
+		}
+		count := 0
+		for _, child := range node.Nodes {
+			childLeaf, childStart := leaf, start
+			switch {
+			case len(path) == 1 && child.XMLName.Local == "IRSubmission1Grp":
+				count++
+				childLeaf.submission, childStart = count, len(path)+1
+			case len(path) == 3 && node.XMLName.Local == "IRSubmission1Detail":
+				count++
+				childLeaf.form, childStart = count, len(path)+1
+			}
+			walk(child, path, childLeaf, childStart)
+		}
+	}
+	walk(root, nil, irisLeaf{}, 1)
+	return leaves, nil
+}
+
+// transmitter returns the transmitter “T” record of the manifest
+func (m *irisManifest) transmitter() (*records.TRecord, IrisErrors) {
+	var errs IrisErrors
+	if m.TransmissionTypeCd != "O" {
+		errs = append(errs, &IrisError{Element: "IRTransmissionManifest/TransmissionTypeCd", Err: utils.ErrUnsupportedIris})
+	}
+
+	transmitter := &records.TRecord{
+		PaymentYear:                 m.TaxYr,
+		TIN:                         m.TransmitterGrp.TIN,
+		TCC:                         m.TransmitterGrp.TransmitterControlCd,
+		TransmitterName:             m.TransmitterGrp.BusinessName.BusinessNameLine1Txt,
+		TransmitterNameContinuation: m.TransmitterGrp.BusinessName.BusinessNameLine2Txt,
+		CompanyName:                 m.TransmitterGrp.CompanyName.BusinessNameLine1Txt,
+		CompanyNameContinuation:     m.TransmitterGrp.CompanyName.BusinessNameLine2Txt,
+		ContactName:                 m.ContactPersonInformationGrp.ContactPersonNm,
+		ContactTelephoneNumber:      m.ContactPersonInformationGrp.ContactPhoneNum,
+		ContactEmailAddress:         m.ContactPersonInformationGrp.ContactEmailAddressTxt,
+		VendorIndicator:             m.VendorCd,
+		PriorYearDataIndicator:      irisValue(m.PriorYearDataInd, config.PriorYearDataIndicator),
+		ForeignEntityIndicator:      irisValue(m.TransmitterGrp.ForeignEntityInd, config.ForeignEntityIndicator),
+	}
+	transmitter.CompanyMailingAddress, transmitter.CompanyCity, transmitter.CompanyState, transmitter.CompanyZipCode, _ = m.TransmitterGrp.CompanyAddress.fields()
+	if m.TestCd == "T" {
+		transmitter.TestFileIndicator = config.TestFileIndicator
+	}
+	if vendor := m.VendorGrp; vendor != nil {
+		transmitter.VendorName = vendor.BusinessName.BusinessNameLine1Txt
+		transmitter.VendorMailingAddress, transmitter.VendorCity, transmitter.VendorState, transmitter.VendorZipCode, _ = vendor.MailingAddressGrp.fields()
+		transmitter.VendorContactName = vendor.ContactPersonNm
+		transmitter.VendorContactTelephoneNumber = vendor.ContactPhoneNum
+		transmitter.VendorForeignEntityIndicator = irisValue(vendor.ForeignEntityInd, config.ForeignEntityIndicator)
+	}
+	return transmitter, errs
+}
+
+// records returns the payer “A” record and payee “B” records of the submission
+func (s *irisSubmission) records(id int) (*records.ARecord, []*records.BRecord, IrisErrors) {
+	header := s.Header
+	issuer := header.IssuerDetail
+
+	form, code := "", ""
+	for typeOfReturn, formType := range config.IrisFormTypes {
+		if formType == header.FormTypeCd {
+			form = typeOfReturn
+		}
+	}
+	for typeOfReturnCode, typeOfReturn := range config.TypeOfReturns {
+		if len(form) > 0 && typeOfReturn == form {
+			code = typeOfReturnCode
+		}
+	}
+	if len(code) == 0 {
+		return nil, nil, IrisErrors{{Submission: id, Element: "IRSubmission1Header/FormTypeCd", Err: utils.ErrUnsupportedIris}}
+	}
+
+	payer := &records.ARecord{
+		PaymentYear:             header.TaxYr,
+		TIN:                     issuer.TIN,
+		PayerNameControl:        issuer.BusinessNameControlTxt,
+		TypeOfReturn:            code,
+		FirstPayerNameLine:      issuer.BusinessName.BusinessNameLine1Txt,
+		SecondPayerNameLine:     issuer.BusinessName.BusinessNameLine2Txt,
+		PayerTelephoneNumber:    issuer.PhoneNum,
+		CombinedFSFilingProgram: irisValue(header.CFSFElectionInd, config.FSFilingProgramApproved),
+		ForeignEntityIndicator:  irisValue(issuer.ForeignEntityInd, config.ForeignEntityIndicator),
+		LastFilingIndicator:     irisValue(issuer.LastFilingInd, config.LastFilingIndicator),
+		TransferAgentIndicator:  config.NotTransferAgentIndicator,
+	}
+	if issuer.TransferAgentInd == "1" {
+		payer.TransferAgentIndicator = config.TransferAgentIndicator
+	}
+	payer.PayerShippingAddress, payer.PayerCity, payer.PayerState, payer.PayerZipCode, _ = issuer.MailingAddressGrp.fields()
+
+	var payees []*records.BRecord
+	var errs IrisErrors
+	codes := make(map[string]bool)
+	for index := range s.Detail.Forms {
+		payee, formErrs := s.Detail.Forms[index].payee(form, header)
+		for _, err := range formErrs {
+			err.Submission, err.Form = id, index+1
+		}
+		errs = append(errs, formErrs...)
+		if payee != nil {
+			merge(codes, payee.PaymentCodes())
+			payees = append(payees, payee)
+		}
+	}
+	for _, amountCode := range amountCodes {
+		if codes[amountCode] {
+			payer.AmountCodes += amountCode
+		}
+	}
+	return payer, payees, errs
+}
+
+// payee returns the payee “B” record of the form detail, errors have the element of the form only
+func (d *irisForm) payee(form string, header irisSubmissionHeader) (*records.BRecord, IrisErrors) {
+	if d.XMLName.Local != "Form"+header.FormTypeCd+"Detail" {
+		return nil, IrisErrors{{Element: d.XMLName.Local, Err: utils.ErrUnsupportedIris}}
+	}
+
+	recipient := d.RecipientDetail
+	payee := &records.BRecord{
+		PaymentYear:         d.TaxYr,
+		TIN:                 recipient.TIN,
+		NameControl:         recipient.NameControlTxt,
+		FirstPayeeNameLine:  recipient.BusinessName.BusinessNameLine1Txt,
+		SecondPayeeNameLine: recipient.BusinessName.BusinessNameLine2Txt,
+		PayerAccountNumber:  recipient.AccountNum,
+		PayerOfficeCode:     recipient.OfficeCd,
+	}
+	if payee.PaymentYear == 0 {
+		payee.PaymentYear = header.TaxYr
+	}
+	switch recipient.TINSubmittedTypeCd {
+	case irisBusinessTIN:
+		payee.TypeOfTIN = config.TinType1
+	case irisIndividualTIN:
+		payee.TypeOfTIN = config.TinType2
+	}
+	var foreign bool
+	payee.PayeeMailingAddress, payee.PayeeCity, payee.PayeeState, payee.PayeeZipCode, foreign = recipient.MailingAddressGrp.fields()
+	if foreign {
+		payee.ForeignCountryIndicator = config.ForeignCountryIndicator
+	}
+	if err := payee.SetTypeOfReturn(form); err != nil {
+		return nil, IrisErrors{{Element: d.XMLName.Local, Err: err}}
+	}
+	extension := payee.Extension()
+
+	var errs IrisErrors
+	for _, element := range d.Elements {
+		name := element.XMLName.Local
+		if field, ok := irisAmountField(form, name); ok {
+			value, err := utils.ParseDollars(strings.TrimSpace(element.Value))
+			if err != nil {
+				errs = append(errs, &IrisError{Element: name, Err: err})
+				continue
+			}
+			amount, _ := utils.GetField(payee, field)
+			amount.SetInt(int64(value))
+			continue
+		}
+		if indicator, ok := irisIndicatorField(name); ok {
+			set := strings.TrimSpace(element.Value) == "1"
+			field, err := utils.GetField(extension, indicator.Field)
+			if err == nil && set {
+				field.SetString(indicator.Value)
+			} else if set {
+				errs = append(errs, &IrisError{Element: name, Err: utils.ErrUnsupportedIris})
+			}
+			continue
+		}
+		errs = append(errs, &IrisError{Element: name, Err: utils.ErrUnsupportedIris})
+	}
+
+	for index, state := range d.StateLocalTaxGrp {
+		if index > 0 {
+			errs = append(errs, &IrisError{Element: "StateLocalTaxGrp", Err: utils.ErrUnsupportedIris})
+			break
+		}
+		for _, tax := range []struct{ element, field, value string }{
+			{"StateLocalTaxGrp/StateTaxGrp/StateTaxWithheldAmt", "StateIncomeTaxWithheld", state.StateTaxWithheldAmt},
+			{"StateLocalTaxGrp/StateTaxGrp/LocalTaxGrp/LocalTaxWithheldAmt", "LocalIncomeTaxWithheld", state.LocalTaxWithheldAmt},
+		} {
+			if len(strings.TrimSpace(tax.value)) == 0 {
+				continue
+			}
+			value, err := utils.ParseDollars(strings.TrimSpace(tax.value))
+			if err != nil {
+				errs = append(errs, &IrisError{Element: tax.element, Err: err})
+				continue
+			}
+			field, err := utils.GetField(extension, tax.field)
+			if err != nil {
+				errs = append(errs, &IrisError{Element: tax.element, Err: utils.ErrUnsupportedIris})
+				continue
+			}
+			field.SetInt(int64(value))
+		}
+
+		// the state of combined federal/state payers is the CF/SF code, otherwise the state of the payee
+		abbreviation := state.StateAbbreviationCd
+		if code, ok := irisFederalStateCode(abbreviation); ok && header.CFSFElectionInd == "1" {
+			if field, err := utils.GetField(extension, "CombinedFSCode"); err == nil {
+				field.SetInt(int64(code))
+				continue
+			}
+		}
+		if len(abbreviation) > 0 && abbreviation != payee.PayeeState {
+			errs = append(errs, &IrisError{Element: "StateLocalTaxGrp/StateTaxGrp/StateAbbreviationCd", Err: utils.ErrUnsupportedIris})
+		}
+	}
+	return payee, errs
+}
+
+// fields returns mailing address, city, state and ZIP Code of the address and true for foreign addresses
+func (a irisMailingAddress) fields() (string, string, string, string, bool) {
+	if a.ForeignAddress != nil {
+		return a.ForeignAddress.AddressLine1Txt, a.ForeignAddress.CityNm, "", "", true
+	}
+	if a.USAddress != nil {
+		return a.USAddress.AddressLine1Txt, a.USAddress.CityNm, a.USAddress.StateAbbreviationCd, a.USAddress.ZIPCd, false
+	}
+	return "", "", "", "", false
+}
+
+// irisAmountField returns the payment amount field of the IRIS amount element of the form
+func irisAmountField(form, element string) (string, bool) {
+	for _, amount := range config.IrisAmountElements[form] {
+		if amount.Element == element {
+			return "PaymentAmount" + amount.Code, true
+		}
+	}
+	return "", false
+}
+
+func irisIndicatorField(element string) (config.IrisIndicator, bool) {
+	for _, indicator := range config.IrisIndicatorElements {
+		if indicator.Element == element {
+			return indicator, true
+		}
+	}
+	return config.IrisIndicator{}, false
+}
+
+// irisFederalStateCode returns the CF/SF code of the state abbreviation
+func irisFederalStateCode(abbreviation string) (int, bool) {
+	name, ok := config.StateAbbreviationCodes[abbreviation]
+	if !ok {
+		return 0, false
+	}
+	for code, state := range config.ParticipateStateCodes {
+		if state == name {
+			return code, true
+		}
+	}
+	return 0, false
+}
+
+// irisValue returns the Pub 1220 value of an IRIS indicator, blank if the indicator isn't set
+func irisValue(indicator, value string) string {
+	if indicator == "1" {
+		return value
+	}
+	return ""
+}
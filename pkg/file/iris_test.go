@@ -0,0 +1,190 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package file
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"strings"
+
+	"gopkg.in/check.v1"
+
+	"github.com/moov-io/irs/pkg/config"
+	"github.com/moov-io/irs/pkg/utils"
+)
+
+func (t *FileTest) TestIris(c *check.C) {
+	f, err := CreateFile(t.oneTransactionIrisJson)
+	c.Assert(err, check.IsNil)
+
+	buf, err := f.Iris()
+	c.Assert(err, check.IsNil)
+	c.Assert(strings.HasPrefix(string(buf), xml.Header), check.Equals, true)
+	c.Assert(strings.Contains(string(buf), `<IRTransmission xmlns="urn:us:gov:treasury:irs:ir">`), check.Equals, true)
+
+	transmission := irisTransmission{}
+	c.Assert(xml.Unmarshal(buf, &transmission), check.IsNil)
+
+	manifest := transmission.Manifest
+	c.Assert(manifest.TaxYr, check.Equals, 2017)
+	c.Assert(manifest.TestCd, check.Equals, "T")
+	c.Assert(manifest.PriorYearDataInd, check.Equals, "1")
+	c.Assert(manifest.TransmitterGrp.TransmitterControlCd, check.Equals, "55AA5")
+	c.Assert(manifest.TransmitterGrp.CompanyAddress.USAddress.StateAbbreviationCd, check.Equals, "NY")
+	c.Assert(manifest.VendorGrp.BusinessName.BusinessNameLine1Txt, check.Equals, "GSG CORP")
+	c.Assert(manifest.TotalIssuerFormCnt, check.Equals, 1)
+	c.Assert(manifest.TotalRecipientFormCnt, check.Equals, 2)
+	c.Assert(strings.HasSuffix(manifest.UniqueTransmissionId, ":IRIS:55AA5::A"), check.Equals, true)
+
+	// the transmission id only changes with the contents
+	again, err := f.Iris()
+	c.Assert(err, check.IsNil)
+	c.Assert(string(again), check.Equals, string(buf))
+
+	c.Assert(len(transmission.Submissions), check.Equals, 1)
+	header := transmission.Submissions[0].Header
+	c.Assert(header.SubmissionId, check.Equals, 1)
+	c.Assert(header.FormTypeCd, check.Equals, "1099MISC")
+	c.Assert(header.ParentFormTypeCd, check.Equals, config.IrisParentFormType)
+	c.Assert(header.IssuerDetail.TIN, check.Equals, "123456789")
+	c.Assert(header.IssuerDetail.BusinessName.BusinessNameLine1Txt, check.Equals, "ASDF GLOBAL INC")
+	c.Assert(header.TotalReportedRcpntFormCnt, check.Equals, 2)
+
+	forms := transmission.Submissions[0].Detail.Forms
+	c.Assert(len(forms), check.Equals, 2)
+	form := forms[0]
+	c.Assert(form.XMLName.Local, check.Equals, "Form1099MISCDetail")
+	c.Assert(form.RecordId, check.Equals, 1)
+	c.Assert(form.RecipientDetail.TIN, check.Equals, "987654321")
+	c.Assert(form.RecipientDetail.TINSubmittedTypeCd, check.Equals, irisBusinessTIN)
+	c.Assert(form.RecipientDetail.MailingAddressGrp.USAddress.CityNm, check.Equals, "MOON")
+	c.Assert(form.Elements[0].XMLName.Local, check.Equals, "OtherIncomeAmt")
+	c.Assert(form.Elements[0].Value, check.Equals, "7.00")
+	c.Assert(form.Elements[1].XMLName.Local, check.Equals, "SecondTINNoticeInd")
+	c.Assert(form.Elements[1].Value, check.Equals, "1")
+	c.Assert(len(form.StateLocalTaxGrp), check.Equals, 1)
+	c.Assert(form.StateLocalTaxGrp[0].StateAbbreviationCd, check.Equals, "AL")
+	c.Assert(form.StateLocalTaxGrp[0].StateTaxWithheldAmt, check.Equals, "0.04")
+	c.Assert(form.StateLocalTaxGrp[0].LocalTaxWithheldAmt, check.Equals, "0.02")
+	c.Assert(forms[1].RecordId, check.Equals, 2)
+	c.Assert(forms[1].Elements[1].Value, check.Equals, "0")
+}
+
+func (t *FileTest) TestIrisUnsupported(c *check.C) {
+	// amount code 7 of 1099-MISC has no IRIS element
+	f, err := CreateFile(t.oneTransactionJson)
+	c.Assert(err, check.IsNil)
+	_, err = f.Iris()
+	c.Assert(errors.Is(err, utils.ErrUnsupportedIris), check.Equals, true)
+	c.Assert(err.Error(), check.Equals, "payer 1: payee 3: payment amount 7 "+utils.ErrUnsupportedIris.Error())
+
+	f, err = CreateFile(t.oneTransactionIrisJson)
+	c.Assert(err, check.IsNil)
+	f.Payers()[0].PayeeRecords()[1].CorrectedReturnIndicator = config.CorrectedReturnIndicatorG
+	_, err = f.Iris()
+	c.Assert(errors.Is(err, utils.ErrUnsupportedIris), check.Equals, true)
+
+	f, err = CreateFile(t.oneTransactionIrisJson)
+	c.Assert(err, check.IsNil)
+	f.Payers()[0].PayerRecord().TypeOfReturn = "ZZ"
+	_, err = f.Iris()
+	c.Assert(errors.Is(err, utils.ErrInvalidTypeOfReturn), check.Equals, true)
+
+	f, err = CreateFile(t.sample1099PatrJson)
+	c.Assert(err, check.IsNil)
+	_, err = f.Iris()
+	c.Assert(errors.Is(err, utils.ErrUnsupportedIris), check.Equals, true)
+}
+
+func (t *FileTest) TestImportIris(c *check.C) {
+	f, err := CreateFile(t.oneTransactionIrisJson)
+	c.Assert(err, check.IsNil)
+	buf, err := f.Iris()
+	c.Assert(err, check.IsNil)
+
+	imported, err := ImportIris(bytes.NewReader(buf))
+	c.Assert(err, check.IsNil)
+	c.Assert(imported.Validate(), check.IsNil)
+
+	transmitter := imported.TransmitterRecord()
+	c.Assert(transmitter.TCC, check.Equals, "55AA5")
+	c.Assert(transmitter.PriorYearDataIndicator, check.Equals, config.PriorYearDataIndicator)
+	c.Assert(transmitter.TestFileIndicator, check.Equals, config.TestFileIndicator)
+	c.Assert(transmitter.VendorName, check.Equals, "GSG CORP")
+	c.Assert(transmitter.TotalNumberPayees, check.Equals, 2)
+
+	c.Assert(len(imported.Payers()), check.Equals, 1)
+	person := imported.Payers()[0]
+	payer := person.PayerRecord()
+	c.Assert(payer.TypeOfReturn, check.Equals, "A")
+	c.Assert(payer.AmountCodes, check.Equals, "3")
+	c.Assert(payer.CombinedFSFilingProgram, check.Equals, config.FSFilingProgramApproved)
+	c.Assert(person.EndPayerRecord().ControlTotal3, check.Equals, 1400)
+	c.Assert(len(person.StateRecords()), check.Equals, 1)
+	c.Assert(person.StateRecords()[0].CombinedFederalStateCode, check.Equals, "AL")
+
+	payee := person.PayeeRecords()[0]
+	c.Assert(payee.TypeOfTIN, check.Equals, config.TinType1)
+	c.Assert(payee.PaymentAmount3, check.Equals, 700)
+	c.Assert(payee.FederalState(), check.Equals, 1)
+	second, err := payee.SecondTIN()
+	c.Assert(err, check.IsNil)
+	c.Assert(*second, check.Equals, config.SecondTINNotice)
+	state, local, err := payee.IncomeTax()
+	c.Assert(err, check.IsNil)
+	c.Assert(state, check.Equals, 4)
+	c.Assert(local, check.Equals, 2)
+
+	// the imported file writes the same forms
+	again, err := imported.Iris()
+	c.Assert(err, check.IsNil)
+	original, transmission := irisTransmission{}, irisTransmission{}
+	c.Assert(xml.Unmarshal(buf, &original), check.IsNil)
+	c.Assert(xml.Unmarshal(again, &transmission), check.IsNil)
+	original.Manifest.UniqueTransmissionId, transmission.Manifest.UniqueTransmissionId = "", ""
+	c.Assert(transmission, check.DeepEquals, original)
+
+	// xml to fire ascii and back
+	ascii, err := CreateFile(imported.Ascii())
+	c.Assert(err, check.IsNil)
+	roundTrip, err := ascii.Iris()
+	c.Assert(err, check.IsNil)
+	c.Assert(string(roundTrip), check.Equals, string(again))
+
+	// CreateFile reads IRIS transmissions
+	created, err := CreateFile(buf)
+	c.Assert(err, check.IsNil)
+	c.Assert(string(created.Ascii()), check.Equals, string(imported.Ascii()))
+}
+
+func (t *FileTest) TestImportIrisUnsupported(c *check.C) {
+	f, err := CreateFile(t.oneTransactionIrisJson)
+	c.Assert(err, check.IsNil)
+	buf, err := f.Iris()
+	c.Assert(err, check.IsNil)
+
+	data := string(buf)
+	data = strings.Replace(data, "<CityNm>MOON</CityNm>", "<CityNm>MOON</CityNm><ProvinceCd>ON</ProvinceCd>", 1)
+	data = strings.Replace(data, "<OtherIncomeAmt>7.00</OtherIncomeAmt>", "<OtherIncomeAmt>7.001</OtherIncomeAmt><ForeignTaxPaidAmt>1.00</ForeignTaxPaidAmt>", 1)
+	data = strings.Replace(data, "<TestCd>T</TestCd>", "<TestCd>T</TestCd><OriginalReceiptId>1</OriginalReceiptId>", 1)
+
+	_, err = ImportIris(strings.NewReader(data))
+	errs, ok := err.(IrisErrors)
+	c.Assert(ok, check.Equals, true)
+	c.Assert(len(errs), check.Equals, 4)
+	c.Assert(errs[0].Error(), check.Equals, "element IRTransmissionManifest/OriginalReceiptId: "+utils.ErrUnsupportedIris.Error())
+	c.Assert(errs[1].Error(), check.Equals, "submission 1 form 1 element RecipientDetail/MailingAddressGrp/USAddress/ProvinceCd: "+utils.ErrUnsupportedIris.Error())
+	c.Assert(errs[2].Element, check.Equals, "OtherIncomeAmt")
+	c.Assert(errors.Is(errs[3], utils.ErrUnsupportedIris), check.Equals, true)
+	c.Assert(errs[3].Element, check.Equals, "ForeignTaxPaidAmt")
+
+	data = strings.Replace(string(buf), "<FormTypeCd>1099MISC</FormTypeCd>", "<FormTypeCd>1099K</FormTypeCd>", 1)
+	_, err = ImportIris(strings.NewReader(data))
+	c.Assert(err.Error(), check.Equals, "submission 1 element IRSubmission1Header/FormTypeCd: "+utils.ErrUnsupportedIris.Error())
+
+	_, err = ImportIris(strings.NewReader("<Return></Return>"))
+	c.Assert(err, check.NotNil)
+}
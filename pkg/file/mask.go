@@ -0,0 +1,178 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package file
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/moov-io/irs/pkg/records"
+	"github.com/moov-io/irs/pkg/subrecords"
+	"github.com/moov-io/irs/pkg/utils"
+)
+
+// Kinds of personal data of ParseMaskOptions
+const (
+	MaskTINs           = "tins"
+	MaskNames          = "names"
+	MaskAccountNumbers = "accounts"
+	MaskAddresses      = "addresses"
+	MaskAllFields      = "all"
+)
+
+// maskCharacter replaces redacted characters
+const maskCharacter = "*"
+
+// tinVisibleDigits is the number of last digits that masked TINs keep
+const tinVisibleDigits = 4
+
+// MaskOptions chooses the personal data that Mask redacts in addition to TINs
+type MaskOptions struct {
+	// Names redacts names and name controls of transmitters, contacts, payers and payees
+	Names bool `json:"names,omitempty"`
+	// AccountNumbers redacts payer's account numbers for payees
+	AccountNumbers bool `json:"account_numbers,omitempty"`
+	// Addresses redacts mailing addresses, cities, ZIP Codes, telephone numbers
+	// and email addresses, states are kept
+	Addresses bool `json:"addresses,omitempty"`
+}
+
+// ParseMaskOptions returns the mask options of a comma separated list of kinds of personal data
+//
+// TINs are always masked, so "tins" (or "true") alone masks only TINs. "all"
+// masks names, account numbers and addresses as well.
+func ParseMaskOptions(value string) (MaskOptions, error) {
+	var options MaskOptions
+	for _, kind := range strings.Split(value, ",") {
+		switch strings.ToLower(strings.TrimSpace(kind)) {
+		case "", MaskTINs, "true":
+		case MaskNames:
+			options.Names = true
+		case MaskAccountNumbers:
+			options.AccountNumbers = true
+		case MaskAddresses:
+			options.Addresses = true
+		case MaskAllFields:
+			options = MaskOptions{Names: true, AccountNumbers: true, Addresses: true}
+		default:
+			return options, fmt.Errorf("%s %w", kind, utils.ErrInvalidMask)
+		}
+	}
+	return options, nil
+}
+
+// Mask returns a copy of the file with TINs of “T”, “A” and “B” records redacted to their last four digits
+//
+// SSNs of insured persons of 1099-LTC extension blocks are TINs as well. The
+// options redact names, account numbers and addresses entirely. Masked files
+// are meant for logs and support tickets, they don't pass validation.
+func Mask(f File, options MaskOptions) (File, error) {
+	if f == nil {
+		return nil, utils.ErrInvalidFile
+	}
+	buf, err := json.Marshal(f)
+	if err != nil {
+		return nil, err
+	}
+	masked, err := CreateFile(buf)
+	if err != nil {
+		return nil, err
+	}
+
+	if tRecord := masked.TransmitterRecord(); tRecord != nil {
+		maskTransmitter(tRecord, options)
+	}
+	for _, person := range masked.Payers() {
+		if payer := person.PayerRecord(); payer != nil {
+			maskPayer(payer, options)
+		}
+		for _, payee := range person.PayeeRecords() {
+			maskPayee(payee, options)
+		}
+	}
+	return masked, nil
+}
+
+// MarshalMaskedJSON returns the json encoding of the file masked with the options
+func MarshalMaskedJSON(f File, options MaskOptions) ([]byte, error) {
+	masked, err := Mask(f, options)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(masked)
+}
+
+// MaskTIN returns the TIN with all but its last four characters replaced
+func MaskTIN(tin string) string {
+	if len(tin) <= tinVisibleDigits {
+		return maskText(tin)
+	}
+	return maskText(tin[:len(tin)-tinVisibleDigits]) + tin[len(tin)-tinVisibleDigits:]
+}
+
+func maskText(text string) string {
+	return strings.Repeat(maskCharacter, len(text))
+}
+
+func maskFields(fields ...*string) {
+	for _, field := range fields {
+		*field = maskText(*field)
+	}
+}
+
+func maskTransmitter(r *records.TRecord, options MaskOptions) {
+	r.TIN = MaskTIN(r.TIN)
+	if options.Names {
+		maskFields(&r.TransmitterName, &r.TransmitterNameContinuation, &r.CompanyName, &r.CompanyNameContinuation,
+			&r.ContactName, &r.VendorName, &r.VendorContactName)
+	}
+	if options.Addresses {
+		maskFields(&r.CompanyMailingAddress, &r.CompanyCity, &r.CompanyZipCode, &r.ContactTelephoneNumber,
+			&r.ContactEmailAddress, &r.VendorMailingAddress, &r.VendorCity, &r.VendorZipCode, &r.VendorContactTelephoneNumber)
+	}
+}
+
+func maskPayer(r *records.ARecord, options MaskOptions) {
+	r.TIN = MaskTIN(r.TIN)
+	if options.Names {
+		maskFields(&r.PayerNameControl, &r.FirstPayerNameLine, &r.SecondPayerNameLine)
+	}
+	if options.Addresses {
+		maskFields(&r.PayerShippingAddress, &r.PayerCity, &r.PayerZipCode, &r.PayerTelephoneNumber)
+	}
+}
+
+func maskPayee(r *records.BRecord, options MaskOptions) {
+	r.TIN = MaskTIN(r.TIN)
+	if options.Names {
+		maskFields(&r.NameControl, &r.FirstPayeeNameLine, &r.SecondPayeeNameLine)
+	}
+	if options.AccountNumbers {
+		maskFields(&r.PayerAccountNumber)
+	}
+	if options.Addresses {
+		maskFields(&r.PayeeMailingAddress, &r.PayeeCity, &r.PayeeZipCode)
+	}
+
+	switch ext := r.Extension().(type) {
+	case *subrecords.Sub1099LTC:
+		ext.SocialSecurityNumberInsured = MaskTIN(ext.SocialSecurityNumberInsured)
+		if options.Names {
+			maskFields(&ext.NameInsured)
+		}
+		if options.Addresses {
+			maskFields(&ext.AddressInsured, &ext.CityInsured, &ext.ZipCodeInsured)
+		}
+	case *subrecords.Sub1098:
+		if options.Addresses {
+			maskFields(&ext.PropertyADSecuringMortgage)
+		}
+	case *subrecords.Sub1099S:
+		if options.Addresses {
+			maskFields(&ext.AddressLegalDescription)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package file
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"gopkg.in/check.v1"
+
+	"github.com/moov-io/irs/pkg/config"
+	"github.com/moov-io/irs/pkg/subrecords"
+	"github.com/moov-io/irs/pkg/utils"
+)
+
+func (t *FileTest) TestMaskTIN(c *check.C) {
+	c.Assert(MaskTIN("987654321"), check.Equals, "*****4321")
+	c.Assert(MaskTIN("4321"), check.Equals, "****")
+	c.Assert(MaskTIN(""), check.Equals, "")
+}
+
+func (t *FileTest) TestParseMaskOptions(c *check.C) {
+	options, err := ParseMaskOptions("tins")
+	c.Assert(err, check.IsNil)
+	c.Assert(options, check.Equals, MaskOptions{})
+	options, err = ParseMaskOptions("names, Addresses")
+	c.Assert(err, check.IsNil)
+	c.Assert(options, check.Equals, MaskOptions{Names: true, Addresses: true})
+	options, err = ParseMaskOptions("all")
+	c.Assert(err, check.IsNil)
+	c.Assert(options, check.Equals, MaskOptions{Names: true, AccountNumbers: true, Addresses: true})
+	_, err = ParseMaskOptions("names,phones")
+	c.Assert(errors.Is(err, utils.ErrInvalidMask), check.Equals, true)
+}
+
+func (t *FileTest) TestMask(c *check.C) {
+	f, err := CreateFile(t.oneTransactionJson)
+	c.Assert(err, check.IsNil)
+	payee := f.Payers()[0].PayeeRecords()[0]
+	payee.PayerAccountNumber = "ACCT123"
+
+	masked, err := Mask(f, MaskOptions{})
+	c.Assert(err, check.IsNil)
+	c.Assert(masked.TransmitterRecord().TIN, check.Equals, "*****6789")
+	c.Assert(masked.Payers()[0].PayerRecord().TIN, check.Equals, "*****6789")
+	maskedPayee := masked.Payers()[0].PayeeRecords()[0]
+	c.Assert(maskedPayee.TIN, check.Equals, "*****4321")
+	c.Assert(maskedPayee.FirstPayeeNameLine, check.Equals, "SPACELEY SPROCKETS")
+	c.Assert(maskedPayee.PayerAccountNumber, check.Equals, "ACCT123")
+	c.Assert(strings.Contains(string(masked.Ascii()), "987654321"), check.Equals, false)
+
+	// the original file is unchanged
+	c.Assert(payee.TIN, check.Equals, "987654321")
+
+	masked, err = Mask(f, MaskOptions{Names: true, AccountNumbers: true, Addresses: true})
+	c.Assert(err, check.IsNil)
+	maskedPayee = masked.Payers()[0].PayeeRecords()[0]
+	c.Assert(maskedPayee.FirstPayeeNameLine, check.Equals, "******************")
+	c.Assert(maskedPayee.NameControl, check.Equals, "****")
+	c.Assert(maskedPayee.PayerAccountNumber, check.Equals, "*******")
+	c.Assert(maskedPayee.PayeeMailingAddress, check.Equals, "*******************")
+	c.Assert(maskedPayee.PayeeState, check.Equals, "CA")
+	c.Assert(masked.TransmitterRecord().ContactEmailAddress, check.Equals, "******************")
+	c.Assert(masked.Payers()[0].PayerRecord().FirstPayerNameLine, check.Equals, "***************")
+
+	_, err = Mask(nil, MaskOptions{})
+	c.Assert(err, check.Equals, utils.ErrInvalidFile)
+}
+
+func (t *FileTest) TestMaskExtension(c *check.C) {
+	f, err := CreateFile(t.oneTransactionJson)
+	c.Assert(err, check.IsNil)
+	payee := f.Payers()[0].PayeeRecords()[0]
+	ext := &subrecords.Sub1099LTC{SocialSecurityNumberInsured: "123456789", NameInsured: "JOHN SMITH", AddressInsured: "1 MAIN ST"}
+	c.Assert(payee.SetExtension(ext), check.IsNil)
+	f.Payers()[0].PayerRecord().TypeOfReturn = "T"
+
+	masked, err := Mask(f, MaskOptions{Names: true})
+	c.Assert(err, check.IsNil)
+	maskedExt, ok := masked.Payers()[0].PayeeRecords()[0].Extension().(*subrecords.Sub1099LTC)
+	c.Assert(ok, check.Equals, true)
+	c.Assert(maskedExt.SocialSecurityNumberInsured, check.Equals, "*****6789")
+	c.Assert(maskedExt.NameInsured, check.Equals, "**********")
+	c.Assert(maskedExt.AddressInsured, check.Equals, "1 MAIN ST")
+}
+
+func (t *FileTest) TestMarshalMaskedJSON(c *check.C) {
+	f, err := CreateFile(t.oneTransactionJson)
+	c.Assert(err, check.IsNil)
+	buf, err := MarshalMaskedJSON(f, MaskOptions{Names: true})
+	c.Assert(err, check.IsNil)
+	c.Assert(strings.Contains(string(buf), "987654321"), check.Equals, false)
+	c.Assert(strings.Contains(string(buf), "SPACELEY"), check.Equals, false)
+
+	masked := NewFile()
+	c.Assert(json.Unmarshal(buf, masked), check.IsNil)
+	c.Assert(masked.Payers()[0].PayeeRecords()[0].TIN, check.Equals, "*****4321")
+	c.Assert(masked.TransmitterRecord().Type(), check.Equals, config.TRecordType)
+}
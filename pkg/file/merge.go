@@ -0,0 +1,135 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package file
+
+import (
+	"fmt"
+
+	"github.com/moov-io/irs/pkg/config"
+	"github.com/moov-io/irs/pkg/records"
+	"github.com/moov-io/irs/pkg/utils"
+)
+
+// Keys of Split
+const (
+	SplitByPayer        = "payer"
+	SplitByTypeOfReturn = "type"
+)
+
+// Merge combines payers of the files into one transmission
+//
+// The transmitter “T” record of the first file is used. All files must have
+// the same transmitter control code and payment year, and every payer must
+// have the payment year of its transmitter. Payers are copied, so the files
+// are not changed. The merged file is finalized.
+func Merge(files ...File) (File, error) {
+	if len(files) == 0 || files[0] == nil || files[0].TransmitterRecord() == nil {
+		return nil, utils.ErrInvalidFile
+	}
+
+	transmitter := files[0].TransmitterRecord()
+	merged := newTransmission(transmitter)
+	for index, f := range files {
+		if f == nil || f.TransmitterRecord() == nil {
+			return nil, fmt.Errorf("%w (file %d)", utils.ErrInvalidFile, index+1)
+		}
+		tRecord := f.TransmitterRecord()
+		if tRecord.TCC != transmitter.TCC {
+			return nil, fmt.Errorf("%w (file %d)", utils.ErrMixedTCC, index+1)
+		}
+		if tRecord.PaymentYear != transmitter.PaymentYear {
+			return nil, fmt.Errorf("%w (file %d)", utils.ErrMixedTaxYears, index+1)
+		}
+		for _, person := range f.Payers() {
+			copied, err := copyPerson(person, transmitter.PaymentYear)
+			if err != nil {
+				return nil, fmt.Errorf("%w (file %d)", err, index+1)
+			}
+			merged.PaymentPersons = append(merged.PaymentPersons, copied)
+		}
+	}
+
+	if err := merged.Finalize(); err != nil {
+		return nil, err
+	}
+	return merged, nil
+}
+
+// Split partitions payers of the file into transmissions by payer TIN (SplitByPayer)
+// or by type of return (SplitByTypeOfReturn)
+//
+// Every transmission has a copy of the transmitter “T” record and is finalized.
+// Transmissions are returned in order of their first payer in the file.
+func Split(f File, by string) ([]File, error) {
+	if f == nil || f.TransmitterRecord() == nil {
+		return nil, utils.ErrInvalidFile
+	}
+
+	var key func(*records.ARecord) string
+	switch by {
+	case SplitByPayer:
+		key = func(payer *records.ARecord) string { return payer.TIN }
+	case SplitByTypeOfReturn:
+		key = func(payer *records.ARecord) string { return payer.TypeOfReturn }
+	default:
+		return nil, utils.ErrInvalidSplit
+	}
+
+	transmitter := f.TransmitterRecord()
+	var list []*fileInstance
+	indexes := make(map[string]int)
+	for _, person := range f.Payers() {
+		copied, err := copyPerson(person, transmitter.PaymentYear)
+		if err != nil {
+			return nil, err
+		}
+		name := key(copied.PayerRecord())
+		index, ok := indexes[name]
+		if !ok {
+			index = len(list)
+			indexes[name] = index
+			list = append(list, newTransmission(transmitter))
+		}
+		list[index].PaymentPersons = append(list[index].PaymentPersons, copied)
+	}
+
+	files := make([]File, 0, len(list))
+	for _, split := range list {
+		if err := split.Finalize(); err != nil {
+			return nil, err
+		}
+		files = append(files, split)
+	}
+	return files, nil
+}
+
+// newTransmission returns a file without payers with a copy of the transmitter record
+func newTransmission(transmitter *records.TRecord) *fileInstance {
+	tRecord := &records.TRecord{}
+	utils.CopyStruct(transmitter, tRecord)
+	fRecord := &records.FRecord{RecordType: config.FRecordType}
+	fRecord.SetTaxYear(tRecord.PaymentYear)
+	return &fileInstance{
+		Transmitter:    tRecord,
+		EndTransmitter: fRecord,
+	}
+}
+
+// copyPerson returns a copy of the payment person whose payer has the payment year
+func copyPerson(person *PaymentPerson, paymentYear int) (*PaymentPerson, error) {
+	payer := person.PayerRecord()
+	if payer == nil {
+		return nil, utils.ErrNonExistPayer
+	}
+	if payer.PaymentYear != paymentYear {
+		return nil, fmt.Errorf("%w (payer %s)", utils.ErrMixedTaxYears, payer.TIN)
+	}
+
+	copied := &PaymentPerson{}
+	if err := readJsonWithPerson(copied, person); err != nil {
+		return nil, err
+	}
+	return copied, nil
+}
@@ -0,0 +1,103 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package file
+
+import (
+	"errors"
+
+	"gopkg.in/check.v1"
+
+	"github.com/moov-io/irs/pkg/utils"
+)
+
+func (t *FileTest) TestMerge(c *check.C) {
+	a, err := CreateFile(t.oneTransactionJson)
+	c.Assert(err, check.IsNil)
+	b, err := CreateFile(t.oneTransactionJson)
+	c.Assert(err, check.IsNil)
+	b.Payers()[0].PayerRecord().TIN = "111223333"
+	b.Payers()[0].PayeeRecords()[0].PaymentAmount7 = 900
+
+	f, err := Merge(a, b)
+	c.Assert(err, check.IsNil)
+	c.Assert(f.Validate(), check.IsNil)
+	c.Assert(len(f.Payers()), check.Equals, 2)
+	c.Assert(f.Payers()[1].PayerRecord().TIN, check.Equals, "111223333")
+	c.Assert(f.EndTransmitterRecord().NumberPayerRecords, check.Equals, 2)
+	c.Assert(f.EndTransmitterRecord().TotalNumberPayees, check.Equals, 4)
+	c.Assert(f.TransmitterRecord().TotalNumberPayees, check.Equals, 4)
+	c.Assert(f.EndTransmitterRecord().SequenceNumber(), check.Equals, 12)
+
+	total := 0
+	for _, payee := range b.Payers()[0].PayeeRecords() {
+		total += payee.PaymentAmount7
+	}
+	c.Assert(f.Payers()[1].EndPayerRecord().ControlTotal7, check.Equals, total)
+
+	// merged files are not changed
+	c.Assert(a.Payers()[0].PayerRecord().SequenceNumber(), check.Equals, 2)
+	c.Assert(f.Payers()[0], check.Not(check.Equals), a.Payers()[0])
+}
+
+func (t *FileTest) TestMergeWithInvalidFiles(c *check.C) {
+	a, err := CreateFile(t.oneTransactionJson)
+	c.Assert(err, check.IsNil)
+
+	_, err = Merge()
+	c.Assert(err, check.Equals, utils.ErrInvalidFile)
+	_, err = Merge(a, nil)
+	c.Assert(errors.Is(err, utils.ErrInvalidFile), check.Equals, true)
+
+	b, err := CreateFile(t.oneTransactionJson)
+	c.Assert(err, check.IsNil)
+	c.Assert(b.SetTCC("99999"), check.IsNil)
+	_, err = Merge(a, b)
+	c.Assert(errors.Is(err, utils.ErrMixedTCC), check.Equals, true)
+	c.Assert(utils.ErrorCode(err), check.Equals, utils.CodeMixedTCC)
+
+	b, err = CreateFile(t.oneTransactionJson)
+	c.Assert(err, check.IsNil)
+	b.TransmitterRecord().PaymentYear = 2018
+	_, err = Merge(a, b)
+	c.Assert(errors.Is(err, utils.ErrMixedTaxYears), check.Equals, true)
+
+	b, err = CreateFile(t.oneTransactionJson)
+	c.Assert(err, check.IsNil)
+	b.Payers()[0].PayerRecord().PaymentYear = 2018
+	_, err = Merge(a, b)
+	c.Assert(errors.Is(err, utils.ErrMixedTaxYears), check.Equals, true)
+}
+
+func (t *FileTest) TestSplit(c *check.C) {
+	a, err := CreateFile(t.oneTransactionJson)
+	c.Assert(err, check.IsNil)
+	b, err := CreateFile(t.oneTransactionJson)
+	c.Assert(err, check.IsNil)
+	b.Payers()[0].PayerRecord().TIN = "111223333"
+	f, err := Merge(a, b, a)
+	c.Assert(err, check.IsNil)
+
+	files, err := Split(f, SplitByPayer)
+	c.Assert(err, check.IsNil)
+	c.Assert(len(files), check.Equals, 2)
+	c.Assert(len(files[0].Payers()), check.Equals, 2)
+	c.Assert(len(files[1].Payers()), check.Equals, 1)
+	c.Assert(files[1].Payers()[0].PayerRecord().TIN, check.Equals, "111223333")
+	for _, split := range files {
+		c.Assert(split.Validate(), check.IsNil)
+		c.Assert(split.EndTransmitterRecord().NumberPayerRecords, check.Equals, len(split.Payers()))
+	}
+	c.Assert(len(f.Payers()), check.Equals, 3)
+
+	files, err = Split(f, SplitByTypeOfReturn)
+	c.Assert(err, check.IsNil)
+	c.Assert(len(files), check.Equals, 1)
+	c.Assert(files[0].Validate(), check.IsNil)
+
+	_, err = Split(f, "unknown")
+	c.Assert(err, check.Equals, utils.ErrInvalidSplit)
+	_, err = Split(nil, SplitByPayer)
+	c.Assert(err, check.Equals, utils.ErrInvalidFile)
+}
@@ -0,0 +1,57 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package file
+
+import (
+	"strings"
+
+	"github.com/moov-io/irs/pkg/utils"
+)
+
+// FillNameControls sets blank name controls of payer “A” and payee “B” records
+// to the name controls derived by the IRS name control rules
+func FillNameControls(f File) {
+	if f == nil {
+		return
+	}
+	for _, person := range f.Payers() {
+		person.fillNameControls()
+	}
+}
+
+func (p *PaymentPerson) fillNameControls() {
+	if payer := p.PayerRecord(); payer != nil && len(strings.TrimSpace(payer.PayerNameControl)) == 0 {
+		payer.PayerNameControl = payer.DerivedNameControl()
+	}
+	for _, payee := range p.PayeeRecords() {
+		if len(strings.TrimSpace(payee.NameControl)) == 0 {
+			payee.NameControl = payee.DerivedNameControl()
+		}
+	}
+}
+
+// reportNameControls warns of supplied name controls that differ from the derived name controls
+func (f *fileInstance) reportNameControls(report *ValidationReport) {
+	for index, person := range f.PaymentPersons {
+		if payer := person.PayerRecord(); payer != nil {
+			if supplied, derived, ok := nameControlMismatch(payer.PayerNameControl, payer.DerivedNameControl()); ok {
+				report.warn(payer, index+1, "PayerNameControl", utils.NewErrNameControl(supplied, derived))
+			}
+		}
+		for _, payee := range person.PayeeRecords() {
+			if supplied, derived, ok := nameControlMismatch(payee.NameControl, payee.DerivedNameControl()); ok {
+				report.warn(payee, index+1, "NameControl", utils.NewErrNameControl(supplied, derived))
+			}
+		}
+	}
+}
+
+func nameControlMismatch(supplied, derived string) (string, string, bool) {
+	supplied = strings.ToUpper(strings.TrimSpace(supplied))
+	if len(supplied) == 0 || len(derived) == 0 {
+		return supplied, derived, false
+	}
+	return supplied, derived, supplied != derived
+}
@@ -0,0 +1,58 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package file
+
+import (
+	"gopkg.in/check.v1"
+
+	"github.com/moov-io/irs/pkg/utils"
+)
+
+func (t *FileTest) TestFillNameControls(c *check.C) {
+	f, err := CreateFile(t.oneTransactionJson)
+	c.Assert(err, check.IsNil)
+	payer := f.Payers()[0].PayerRecord()
+	payee := f.Payers()[0].PayeeRecords()[0]
+	derivedPayer, derivedPayee := payer.DerivedNameControl(), payee.DerivedNameControl()
+	c.Assert(len(derivedPayer) > 0, check.Equals, true)
+	c.Assert(len(derivedPayee) > 0, check.Equals, true)
+
+	payer.PayerNameControl = ""
+	payee.NameControl = ""
+	FillNameControls(f)
+	c.Assert(payer.PayerNameControl, check.Equals, derivedPayer)
+	c.Assert(payee.NameControl, check.Equals, derivedPayee)
+
+	// supplied name controls are kept
+	payee.NameControl = "ABCD"
+	FillNameControls(f)
+	c.Assert(payee.NameControl, check.Equals, "ABCD")
+
+	payer.PayerNameControl = ""
+	payee.NameControl = ""
+	c.Assert(f.Finalize(), check.IsNil)
+	c.Assert(payer.PayerNameControl, check.Equals, derivedPayer)
+	c.Assert(payee.NameControl, check.Equals, derivedPayee)
+
+	FillNameControls(nil)
+}
+
+func (t *FileTest) TestNameControlWarnings(c *check.C) {
+	f, err := CreateFile(t.oneTransactionJson)
+	c.Assert(err, check.IsNil)
+	matchZipCodes(f)
+	report := f.ValidateAll()
+	c.Assert(report.Count(SeverityWarning), check.Equals, 0)
+
+	payee := f.Payers()[0].PayeeRecords()[0]
+	payee.NameControl = "ZZZZ"
+	report = f.ValidateAll()
+	c.Assert(report.Valid(), check.Equals, true)
+	c.Assert(report.Count(SeverityWarning), check.Equals, 1)
+	entry := report.Entries[0]
+	c.Assert(entry.FieldName, check.Equals, "NameControl")
+	c.Assert(entry.Code, check.Equals, utils.CodeNameControlMismatch)
+	c.Assert(entry.Message, check.Equals, "has name control ZZZZ, but derived "+payee.DerivedNameControl())
+}
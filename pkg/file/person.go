@@ -14,24 +14,98 @@ import (
 	"github.com/moov-io/irs/pkg/config"
 	PDF "github.com/moov-io/irs/pkg/pdf_generator"
 	"github.com/moov-io/irs/pkg/records"
+	"github.com/moov-io/irs/pkg/subrecords"
 	"github.com/moov-io/irs/pkg/utils"
 )
 
-// paymentPerson identifies the person making payments
-type paymentPerson struct {
+// PaymentPerson identifies the person making payments
+type PaymentPerson struct {
 	Payer    records.Record   `json:"payer"`
 	Payees   []records.Record `json:"payees"`
 	EndPayer records.Record   `json:"end_payer"`
 	States   []records.Record `json:"states,omitempty"`
 }
 
+// NewPaymentPerson returns a payment person with the payer record and an empty end of payer record
+func NewPaymentPerson(payer *records.ARecord) *PaymentPerson {
+	return &PaymentPerson{
+		Payer:    payer,
+		Payees:   []records.Record{},
+		EndPayer: &records.CRecord{RecordType: config.CRecordType},
+	}
+}
+
+// PayerRecord returns payer “A” record
+func (p *PaymentPerson) PayerRecord() *records.ARecord {
+	aRecord, _ := p.Payer.(*records.ARecord)
+	return aRecord
+}
+
+// PayeeRecords returns payee “B” records
+func (p *PaymentPerson) PayeeRecords() []*records.BRecord {
+	payees := make([]*records.BRecord, 0, len(p.Payees))
+	for _, payee := range p.Payees {
+		if bRecord, ok := payee.(*records.BRecord); ok {
+			payees = append(payees, bRecord)
+		}
+	}
+	return payees
+}
+
+// EndPayerRecord returns end of payer “C” record
+func (p *PaymentPerson) EndPayerRecord() *records.CRecord {
+	cRecord, _ := p.EndPayer.(*records.CRecord)
+	return cRecord
+}
+
+// StateRecords returns state totals “K” records
+func (p *PaymentPerson) StateRecords() []*records.KRecord {
+	states := make([]*records.KRecord, 0, len(p.States))
+	for _, state := range p.States {
+		if kRecord, ok := state.(*records.KRecord); ok {
+			states = append(states, kRecord)
+		}
+	}
+	return states
+}
+
+// AddPayee appends payee “B” record
+//
+// Extension block of the payee is created with type of return of the payer
+// if the payee has no extension block.
+func (p *PaymentPerson) AddPayee(payee *records.BRecord) error {
+	if payee == nil {
+		return utils.ErrNonExistPayee
+	}
+	if payee.Extension() == nil {
+		typeOfReturn, err := p.getTypeOfReturn()
+		if err != nil {
+			return err
+		}
+		if err = payee.SetTypeOfReturn(typeOfReturn); err != nil {
+			return err
+		}
+	}
+	p.Payees = append(p.Payees, payee)
+	return nil
+}
+
+// AddState appends state totals “K” record
+func (p *PaymentPerson) AddState(state *records.KRecord) error {
+	if state == nil {
+		return utils.ErrValidField
+	}
+	p.States = append(p.States, state)
+	return nil
+}
+
 // Type returns type of “Person” record
-func (p *paymentPerson) Type() string {
+func (p *PaymentPerson) Type() string {
 	return "Person"
 }
 
 // Ascii returns fire ascii of “Person” record
-func (p *paymentPerson) Ascii() []byte {
+func (p *PaymentPerson) Ascii() []byte {
 	var buf bytes.Buffer
 
 	if p.Payer != nil {
@@ -64,7 +138,7 @@ func (p *paymentPerson) Ascii() []byte {
 }
 
 // Ascii returns pdf buffer of “Person” record
-func (p *paymentPerson) Pdf() ([]byte, error) {
+func (p *PaymentPerson) Pdf() ([]byte, error) {
 	if p.Payer == nil {
 		return nil, utils.ErrNonExistPayer
 	}
@@ -86,6 +160,13 @@ func (p *paymentPerson) Pdf() ([]byte, error) {
 		if payer.CombinedFSFilingProgram != config.FSFilingProgramApproved {
 			pdfType = PDF.PdfNecCopyC
 		}
+	case config.Sub1099DaType:
+		pdf := PDF.Pdf1099Da{Type: PDF.PdfDaCopyB}
+		err := p.fillingPdfInfoDa(&pdf)
+		if err != nil {
+			return nil, err
+		}
+		return PDF.GeneratePdf1099Da(&pdf)
 	default:
 		return nil, utils.ErrUnsupportedPdf
 	}
@@ -100,7 +181,7 @@ func (p *paymentPerson) Pdf() ([]byte, error) {
 }
 
 // Validate performs some checks on the record and returns an error if not Validated
-func (p *paymentPerson) Validate() error {
+func (p *PaymentPerson) Validate() error {
 	var err error
 	if err = p.validateRecords(); err != nil {
 		return err
@@ -130,7 +211,7 @@ func (p *paymentPerson) Validate() error {
 }
 
 // SequenceNumber returns sequence number of the record
-func (p *paymentPerson) SequenceNumber() int {
+func (p *PaymentPerson) SequenceNumber() int {
 	if p.Payer == nil {
 		return 0
 	}
@@ -138,10 +219,15 @@ func (p *paymentPerson) SequenceNumber() int {
 }
 
 // SequenceNumber set sequence number of the record
-func (p *paymentPerson) SetSequenceNumber(int) {}
+func (p *PaymentPerson) SetSequenceNumber(int) {}
 
 // Parse attempts to parse with raw data.
-func (p *paymentPerson) Parse(buf []byte) (int, error) {
+func (p *PaymentPerson) Parse(buf []byte) (int, error) {
+	return p.parse(buf, false)
+}
+
+// parse attempts to parse with raw data, payer and payees of prior year data use the current layout revision
+func (p *PaymentPerson) parse(buf []byte, priorYearData bool) (int, error) {
 	bufSize := len(buf)
 	readPtr := 0
 
@@ -152,6 +238,7 @@ func (p *paymentPerson) Parse(buf []byte) (int, error) {
 	if p.Payer == nil {
 		p.Payer = records.NewARecord()
 	}
+	setPriorYearData(p.Payer, priorYearData)
 	err := p.Payer.Parse(buf[readPtr : readPtr+config.RecordLength])
 	if err != nil {
 		return readPtr, err
@@ -173,6 +260,7 @@ func (p *paymentPerson) Parse(buf []byte) (int, error) {
 		if err != nil {
 			return readPtr, err
 		}
+		setPriorYearData(newPayee, priorYearData)
 		if err = newPayee.Parse(buf[readPtr : readPtr+config.RecordLength]); err != nil {
 			return readPtr, err
 		}
@@ -192,6 +280,7 @@ func (p *paymentPerson) Parse(buf []byte) (int, error) {
 		if p.EndPayer == nil {
 			p.EndPayer = records.NewCRecord()
 		}
+		setTaxYear(p.EndPayer, records.TaxYear(p.Payer))
 		err := p.EndPayer.Parse(buf[readPtr : readPtr+config.RecordLength])
 		if err != nil {
 			return readPtr, err
@@ -206,6 +295,7 @@ func (p *paymentPerson) Parse(buf []byte) (int, error) {
 		}
 
 		newState := records.NewKRecord()
+		setTaxYear(newState, records.TaxYear(p.Payer))
 		if err = newState.Parse(buf[readPtr : readPtr+config.RecordLength]); err != nil {
 			return readPtr, err
 		}
@@ -218,7 +308,7 @@ func (p *paymentPerson) Parse(buf []byte) (int, error) {
 }
 
 // UnmarshalJSON parses a JSON blob
-func (p *paymentPerson) UnmarshalJSON(data []byte) error {
+func (p *PaymentPerson) UnmarshalJSON(data []byte) error {
 	dummy := make(map[string]interface{})
 	err := json.Unmarshal(data, &dummy)
 	if err != nil {
@@ -303,10 +393,32 @@ func (p *paymentPerson) UnmarshalJSON(data []byte) error {
 		})
 	}
 
+	p.syncTaxYear()
 	return nil
 }
 
-func (p *paymentPerson) integrationCheck() error {
+// setPriorYearData sets prior year data of the transmitter to the payer and payees
+func (p *PaymentPerson) setPriorYearData(priorYearData bool) {
+	setPriorYearData(p.Payer, priorYearData)
+	for _, payee := range p.Payees {
+		setPriorYearData(payee, priorYearData)
+	}
+}
+
+// syncTaxYear sets tax year of the payer to “C” and “K” records
+func (p *PaymentPerson) syncTaxYear() {
+	if p.Payer == nil {
+		return
+	}
+	year := records.TaxYear(p.Payer)
+	setTaxYear(p.EndPayer, year)
+	for _, state := range p.States {
+		setTaxYear(state, year)
+	}
+}
+
+// integrationCheck returns the first error of SeverityError of the checks between records of the payer
+func (p *PaymentPerson) integrationCheck() error {
 	if err := p.validateRecords(); err != nil {
 		return err
 	}
@@ -342,19 +454,19 @@ func (p *paymentPerson) integrationCheck() error {
 	}
 
 	// 3. verify payment codes
-	err = p.validatePaymentCodes()
+	err = utils.FirstError(p.validatePaymentCodes())
 	if err != nil {
 		return err
 	}
 
 	// 4. verify payment amounts
-	err = p.validateAmounts()
+	err = utils.FirstError(p.validateAmounts())
 	if err != nil {
 		return err
 	}
 
 	// 5. verify  CF/SF code
-	err = p.validateFSCodes()
+	err = utils.FirstError(p.validateFSCodes())
 	if err != nil {
 		return err
 	}
@@ -395,7 +507,7 @@ func equal(a, b map[string]bool) bool {
 	return subset(a, b) && subset(b, a)
 }
 
-func (p *paymentPerson) validatePaymentCodes() error {
+func (p *PaymentPerson) validatePaymentCodes() error {
 	aRecord, cRecord, err := p.getRecords()
 	if err != nil {
 		return err
@@ -436,43 +548,47 @@ func (p *paymentPerson) validatePaymentCodes() error {
 	return nil
 }
 
-func (p *paymentPerson) validateAmounts() error {
+func (p *PaymentPerson) validateAmounts() error {
 	_, cRecord, err := p.getRecords()
 	if err != nil {
 		return err
 	}
 
 	amountCodes := strings.Split(cRecord.TotalCodes(), "")
+	typeOfReturn, err := p.getTypeOfReturn()
+	if err != nil {
+		return err
+	}
 
 	// -----------  ΣB == C for every amount code ------------------------
-	for _, code := range amountCodes {
-		control, err := cRecord.ControlTotal(code)
-		if err != nil {
-			return err
+	totals := newPayeeTotals(typeOfReturn)
+	for _, payee := range p.Payees {
+		bRecord, ok := payee.(*records.BRecord)
+		if !ok {
+			return utils.NewErrUnexpectedRecord("payee", payee)
 		}
-		for _, payee := range p.Payees {
-			bRecord, ok := payee.(*records.BRecord)
-			if !ok {
-				return utils.NewErrUnexpectedRecord("payee", payee)
-			}
+		for _, code := range amountCodes {
 			amount, err := bRecord.PaymentAmount(code)
 			if err != nil {
 				return err
 			}
-			control -= amount
+			totals.add(code, amount)
 		}
-		if control != 0 {
-			return utils.ErrInvalidTotalAmounts
+	}
+	for _, code := range amountCodes {
+		control, err := cRecord.ControlTotal(code)
+		if err != nil {
+			return err
+		}
+		if err := totals.endPayerTotalError(code, control); err != nil {
+			return err
 		}
 	}
 
-	// --- ΣK ≤ C (state totals may be subset of national totals) --------
+	// --- ΣK within losses and gains of B (state totals may be subset of national totals) ---
 	if len(p.States) > 0 {
 		for _, code := range amountCodes {
-			control, err := cRecord.ControlTotal(code)
-			if err != nil {
-				return err
-			}
+			total := 0
 			for _, state := range p.States {
 				kRecord, ok := state.(*records.KRecord)
 				if !ok {
@@ -482,10 +598,13 @@ func (p *paymentPerson) validateAmounts() error {
 				if err != nil {
 					return err
 				}
-				control -= amount
+				if err := totals.negativeTotalError(code, amount); err != nil {
+					return err
+				}
+				total += amount
 			}
-			if control < 0 { // K exceeded C — must never happen
-				return utils.ErrInvalidTotalAmounts
+			if err := totals.stateTotalError(code, total); err != nil {
+				return err
 			}
 		}
 	}
@@ -493,7 +612,7 @@ func (p *paymentPerson) validateAmounts() error {
 	return nil
 }
 
-func (p *paymentPerson) validateRecords() error {
+func (p *PaymentPerson) validateRecords() error {
 	if p.Payer == nil {
 		return utils.ErrNonExistPayer
 	}
@@ -506,7 +625,7 @@ func (p *paymentPerson) validateRecords() error {
 	return nil
 }
 
-func (p *paymentPerson) getRecords() (*records.ARecord, *records.CRecord, error) {
+func (p *PaymentPerson) getRecords() (*records.ARecord, *records.CRecord, error) {
 	aRecord, ok := p.Payer.(*records.ARecord)
 	if !ok {
 		return nil, nil, utils.NewErrUnexpectedRecord("payer", p.Payer)
@@ -520,7 +639,7 @@ func (p *paymentPerson) getRecords() (*records.ARecord, *records.CRecord, error)
 	return aRecord, cRecord, nil
 }
 
-func (p *paymentPerson) getTypeOfReturn() (string, error) {
+func (p *PaymentPerson) getTypeOfReturn() (string, error) {
 	typeOfReturn := ""
 	if p.Payer == nil {
 		return typeOfReturn, utils.ErrNonExistPayer
@@ -536,7 +655,7 @@ func (p *paymentPerson) getTypeOfReturn() (string, error) {
 	return typeOfReturn, nil
 }
 
-func (p *paymentPerson) validateFSCodes() error {
+func (p *PaymentPerson) validateFSCodes() error {
 	existed := make(map[string]interface{})
 	for _, state := range p.States {
 		kRecord, ok := state.(*records.KRecord)
@@ -595,7 +714,7 @@ func (p *paymentPerson) validateFSCodes() error {
 	return nil
 }
 
-func (p *paymentPerson) fillingPdfInfoMisc(pdf *PDF.Pdf1099Misc) error {
+func (p *PaymentPerson) fillingPdfInfoMisc(pdf *PDF.Pdf1099Misc) error {
 	payer, cRecord, err := p.getRecords()
 	if err != nil {
 		return err
@@ -614,6 +733,64 @@ func (p *paymentPerson) fillingPdfInfoMisc(pdf *PDF.Pdf1099Misc) error {
 	return fillRecipient(pdf, payee)
 }
 
+// fillingPdfInfoDa fills the 1099-DA copy B of the first payee, whose amounts are those of the transaction
+func (p *PaymentPerson) fillingPdfInfoDa(pdf *PDF.Pdf1099Da) error {
+	payer, _, err := p.getRecords()
+	if err != nil {
+		return err
+	}
+	payee, ok := p.Payees[0].(*records.BRecord)
+	if !ok {
+		return utils.ErrNonExistPayee
+	}
+	ext, ok := payee.Extension().(*subrecords.Sub1099DA)
+	if !ok {
+		return utils.ErrUnsupportedBlock
+	}
+
+	pdf.Corrected = len(payee.CorrectedReturnIndicator) > 0
+	pdf.PayerTin = payer.TIN
+	pdf.PayerInfo = payerInfo(payer)
+	pdf.AccountNumber = payee.PayerAccountNumber
+	pdf.Street = payee.PayeeMailingAddress
+	pdf.RecipientTin = payee.TIN
+	pdf.City = recipientCity(payee)
+	pdf.RecipientName = recipientName(payee)
+
+	pdf.Proceeds = payee.PaymentAmount1
+	pdf.Cost = payee.PaymentAmount2
+	pdf.MarketDiscount = payee.PaymentAmount3
+	pdf.Federal = payee.PaymentAmount4
+	pdf.WashSale = payee.PaymentAmount5
+
+	pdf.Fatca = ext.FATCA == config.FatcaFilingRequirementIndicator
+	pdf.DigitalAssetCode = ext.DigitalAssetCode
+	pdf.DigitalAssetName = ext.DigitalAssetName
+	pdf.NumberUnits = ext.NumberUnits
+	if !ext.DateAcquired.IsZero() {
+		pdf.DateAcquired = ext.DateAcquired.Format("01/02/2006")
+	}
+	if !ext.DateSoldDisposed.IsZero() {
+		pdf.DateSold = ext.DateSoldDisposed.Format("01/02/2006")
+	}
+	pdf.ShortTerm = ext.TypeGainLossIndicator == "1"
+	pdf.LongTerm = ext.TypeGainLossIndicator == "2"
+	pdf.Ordinary = ext.TypeGainLossIndicator == "3"
+	pdf.Collectibles = ext.ApplicableCheckboxCollectables == config.GeneralOneIndicator
+	pdf.Qof = ext.ApplicableCheckboxQOF == config.GeneralOneIndicator
+	pdf.GrossProceeds = ext.GrossProceedsIndicator == config.GeneralOneIndicator
+	pdf.GrossProceedsLessCosts = ext.GrossProceedsIndicator == config.GeneralTwoIndicator
+	pdf.LossNotAllowed = ext.LossNotAllowedIndicator == config.GeneralOneIndicator
+	pdf.Noncovered = len(ext.NoncoveredSecurityIndicator) > 0
+	pdf.Form8949 = ext.ApplicableCheckboxForm8949
+
+	pdf.StateTax1 = ext.StateIncomeTaxWithheld
+	if payee.FederalState() > 0 {
+		pdf.StateNo1 = fmt.Sprintf("%02d", payee.FederalState())
+	}
+	return nil
+}
+
 func eq(a, b map[string]bool) bool {
 	if len(a) != len(b) {
 		return false
@@ -667,6 +844,18 @@ func fillAmounts(amountCodes []string, pdf *PDF.Pdf1099Misc, cRecord *records.CR
 
 func fillPayer(pdf *PDF.Pdf1099Misc, payer *records.ARecord, cRecord *records.CRecord) error {
 	pdf.PayerTin = payer.TIN
+	pdf.PayerInfo = payerInfo(payer)
+
+	amountCodes := strings.Split(payer.AmountCodes, "")
+	err := fillAmounts(amountCodes, pdf, cRecord)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// payerInfo returns the name, address and telephone number of the payer, one per line
+func payerInfo(payer *records.ARecord) string {
 	info := make([]string, 0)
 	name := make([]string, 0)
 	if len(payer.FirstPayerNameLine) > 0 {
@@ -693,20 +882,11 @@ func fillPayer(pdf *PDF.Pdf1099Misc, payer *records.ARecord, cRecord *records.CR
 	if len(payer.PayerTelephoneNumber) > 0 {
 		info = append(info, payer.PayerTelephoneNumber)
 	}
-	pdf.PayerInfo = strings.Join(info, "\r")
-
-	amountCodes := strings.Split(payer.AmountCodes, "")
-	err := fillAmounts(amountCodes, pdf, cRecord)
-	if err != nil {
-		return err
-	}
-	return nil
+	return strings.Join(info, "\r")
 }
 
-func fillRecipient(pdf *PDF.Pdf1099Misc, payee *records.BRecord) error {
-	pdf.AccountNumber = payee.PayerAccountNumber
-	pdf.Street = payee.PayeeMailingAddress
-	pdf.RecipientTin = payee.TIN
+// recipientCity returns the city, state and ZIP Code of the payee
+func recipientCity(payee *records.BRecord) string {
 	info := make([]string, 0)
 	if len(payee.PayeeCity) > 0 {
 		info = append(info, payee.PayeeCity)
@@ -717,7 +897,11 @@ func fillRecipient(pdf *PDF.Pdf1099Misc, payee *records.BRecord) error {
 	if len(payee.PayeeZipCode) > 0 {
 		info = append(info, payee.PayeeZipCode)
 	}
-	pdf.City = strings.Join(info, ",")
+	return strings.Join(info, ",")
+}
+
+// recipientName returns the name lines of the payee
+func recipientName(payee *records.BRecord) string {
 	name := make([]string, 0)
 	if len(payee.FirstPayeeNameLine) > 0 {
 		name = append(name, payee.FirstPayeeNameLine)
@@ -725,7 +909,15 @@ func fillRecipient(pdf *PDF.Pdf1099Misc, payee *records.BRecord) error {
 	if len(payee.SecondPayeeNameLine) > 0 {
 		name = append(name, payee.SecondPayeeNameLine)
 	}
-	pdf.RecipientName = strings.Join(name, " ")
+	return strings.Join(name, " ")
+}
+
+func fillRecipient(pdf *PDF.Pdf1099Misc, payee *records.BRecord) error {
+	pdf.AccountNumber = payee.PayerAccountNumber
+	pdf.Street = payee.PayeeMailingAddress
+	pdf.RecipientTin = payee.TIN
+	pdf.City = recipientCity(payee)
+	pdf.RecipientName = recipientName(payee)
 
 	fatca, err := payee.Fatca()
 	if err != nil {
@@ -5,9 +5,13 @@
 package file
 
 import (
+	"encoding/json"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
+	"github.com/moov-io/irs/pkg/config"
 	PDF "github.com/moov-io/irs/pkg/pdf_generator"
 	"github.com/moov-io/irs/pkg/records"
 )
@@ -41,3 +45,50 @@ func TestFillAmounts(t *testing.T) {
 		t.Error(err)
 	}
 }
+
+func TestPdf1099Da(t *testing.T) {
+	buf, err := os.ReadFile(filepath.Join("..", "..", "test", "testdata", "payeeRecordWith1099Da.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	payee, err := records.NewBRecord(config.Sub1099DaType)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(buf, payee); err != nil {
+		t.Fatal(err)
+	}
+	payer := &records.ARecord{TypeOfReturn: "DA", TIN: "123456789", FirstPayerNameLine: "ACME BROKERS", PayerCity: "NEW YORK"}
+	person := &PaymentPerson{Payer: payer, Payees: []records.Record{payee}, EndPayer: &records.CRecord{}}
+
+	pdf := PDF.Pdf1099Da{Type: PDF.PdfDaCopyB}
+	if err := person.fillingPdfInfoDa(&pdf); err != nil {
+		t.Fatal(err)
+	}
+	if pdf.PayerInfo != "ACME BROKERS\rNEW YORK" || pdf.RecipientName != "SPACELEY SPROCKETS" || pdf.City != "MOON,CA,22222" {
+		t.Errorf("unexpected payer or recipient %q %q %q", pdf.PayerInfo, pdf.RecipientName, pdf.City)
+	}
+	if pdf.Proceeds != 1250000 || pdf.Cost != 900000 || pdf.Federal != 30000 || pdf.WashSale != 1500 || pdf.StateTax1 != 1200 {
+		t.Errorf("unexpected amounts %+v", pdf)
+	}
+	if pdf.DigitalAssetName != "BITCOIN" || pdf.DateAcquired != "06/01/2021" || pdf.DateSold != "03/14/2025" || pdf.NumberUnits != "0.25" {
+		t.Errorf("unexpected digital asset %+v", pdf)
+	}
+	if !pdf.LongTerm || pdf.ShortTerm || !pdf.GrossProceeds || !pdf.Fatca || pdf.Corrected || pdf.Form8949 != "J" {
+		t.Errorf("unexpected check boxes %+v", pdf)
+	}
+
+	// pdftk fills the template
+	if _, err := person.Pdf(); err != nil {
+		t.Error(err)
+	}
+
+	payee, err = records.NewBRecord(config.Sub1099MiscType)
+	if err != nil {
+		t.Fatal(err)
+	}
+	person.Payees = []records.Record{payee}
+	if err := person.fillingPdfInfoDa(&pdf); err == nil {
+		t.Error("expected an error of a payee without 1099-DA block")
+	}
+}
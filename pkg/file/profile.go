@@ -0,0 +1,147 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package file
+
+import (
+	"fmt"
+
+	"github.com/moov-io/irs/pkg/config"
+	"github.com/moov-io/irs/pkg/records"
+	"github.com/moov-io/irs/pkg/utils"
+)
+
+// Names of validation profiles
+const (
+	ProfileDefault        = "default"
+	ProfileFireProduction = "fire-production"
+	ProfileFireTest       = "fire-test"
+	ProfileLenientImport  = "lenient-import"
+)
+
+// Profile chooses the checks that run and the severities of their findings
+//
+// Severities maps error codes to SeverityError, SeverityWarning, SeverityInfo
+// or SeverityOff. Layout checks of codes without a severity keep their own
+// severity, filing rules (utils.CodeBelowThreshold, utils.CodeZeroPayment,
+// utils.CodeWithholdingExceeds) and test file checks (utils.CodeProductionTestFile,
+// utils.CodeTestFileIndicator) without a severity don't run.
+type Profile struct {
+	Name       string            `json:"name"`
+	Severities map[string]string `json:"severities"`
+}
+
+// DefaultProfile warns of returns below the filing thresholds and of payees without
+// payment amounts, federal income tax withheld greater than the gross amount is an error
+var DefaultProfile = &Profile{
+	Name: ProfileDefault,
+	Severities: map[string]string{
+		utils.CodeBelowThreshold:     SeverityWarning,
+		utils.CodeZeroPayment:        SeverityWarning,
+		utils.CodeWithholdingExceeds: SeverityError,
+	},
+}
+
+// Profiles are the named validation profiles
+//
+//   - fire-production checks a file for FIRE production, the test file indicator and
+//     ITINs or ATINs with the EIN type of TIN are errors
+//   - fire-test checks a file for the FIRE test system, which requires the test file
+//     indicator and doesn't process returns, so filing rules are only informational
+//   - lenient-import accepts files that Finalize repairs, wrong sequence numbers,
+//     counts and totals are warnings and filing rules don't run
+var Profiles = map[string]*Profile{
+	ProfileDefault: DefaultProfile,
+	ProfileFireProduction: {
+		Name: ProfileFireProduction,
+		Severities: map[string]string{
+			utils.CodeProductionTestFile: SeverityError,
+			utils.CodeTINTypeMismatch:    SeverityError,
+			utils.CodeBelowThreshold:     SeverityWarning,
+			utils.CodeZeroPayment:        SeverityWarning,
+			utils.CodeWithholdingExceeds: SeverityError,
+		},
+	},
+	ProfileFireTest: {
+		Name: ProfileFireTest,
+		Severities: map[string]string{
+			utils.CodeTestFileIndicator:  SeverityError,
+			utils.CodeBelowThreshold:     SeverityInfo,
+			utils.CodeZeroPayment:        SeverityInfo,
+			utils.CodeWithholdingExceeds: SeverityWarning,
+		},
+	},
+	ProfileLenientImport: {
+		Name: ProfileLenientImport,
+		Severities: map[string]string{
+			utils.CodeInvalidSequenceNumber: SeverityWarning,
+			utils.CodeInvalidNumberPayees:   SeverityWarning,
+			utils.CodeInvalidNumberPayers:   SeverityWarning,
+			utils.CodeInvalidTotalAmounts:   SeverityWarning,
+			utils.CodeUnexpectedTotal:       SeverityWarning,
+			utils.CodeNameControlMismatch:   SeverityInfo,
+			utils.CodeTypeOfTINInferred:     SeverityInfo,
+			utils.CodeZipCodeState:          SeverityInfo,
+		},
+	},
+}
+
+// LookupProfile returns the named validation profile, a blank name is the DefaultProfile
+func LookupProfile(name string) (*Profile, error) {
+	if len(name) == 0 {
+		return DefaultProfile, nil
+	}
+	profile, ok := Profiles[name]
+	if !ok {
+		return nil, fmt.Errorf("%s %w", name, utils.ErrUnknownProfile)
+	}
+	return profile, nil
+}
+
+// severity returns the severity of the code in the profile and whether the profile has one
+func (p *Profile) severity(code string) (string, bool) {
+	severity, ok := p.Severities[code]
+	return severity, ok && severity != SeverityOff
+}
+
+// ValidateProfile performs the checks of ValidateAll, the filing rules of ValidateRules
+// and the test file checks with the severities of the profile
+//
+// A nil profile is the DefaultProfile. Entries of SeverityOff are dropped.
+func (f *fileInstance) ValidateProfile(profile *Profile) *ValidationReport {
+	if profile == nil {
+		profile = DefaultProfile
+	}
+
+	report := f.ValidateAll()
+	entries := report.Entries[:0]
+	for _, entry := range report.Entries {
+		if severity, ok := profile.Severities[entry.Code]; ok {
+			entry.Severity = severity
+		}
+		if entry.Severity != SeverityOff {
+			entries = append(entries, entry)
+		}
+	}
+	report.Entries = entries
+
+	report.Entries = append(report.Entries, f.ValidateRules(profile).Entries...)
+	f.reportTestFile(report, profile)
+	return report
+}
+
+// reportTestFile checks the test file indicator of the transmitter against the profile
+func (f *fileInstance) reportTestFile(report *ValidationReport, profile *Profile) {
+	tRecord, ok := f.Transmitter.(*records.TRecord)
+	if !ok {
+		return
+	}
+	isTest := tRecord.TestFileIndicator == config.TestFileIndicator
+	if severity, ok := profile.severity(utils.CodeProductionTestFile); ok && isTest {
+		report.addWithSeverity(tRecord, 0, "TestFileIndicator", severity, utils.ErrProductionTestFile)
+	}
+	if severity, ok := profile.severity(utils.CodeTestFileIndicator); ok && !isTest {
+		report.addWithSeverity(tRecord, 0, "TestFileIndicator", severity, utils.ErrMissingTestFileIndicator)
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package file
+
+import (
+	"errors"
+
+	"gopkg.in/check.v1"
+
+	"github.com/moov-io/irs/pkg/config"
+	"github.com/moov-io/irs/pkg/utils"
+)
+
+func (t *FileTest) TestLookupProfile(c *check.C) {
+	profile, err := LookupProfile("")
+	c.Assert(err, check.IsNil)
+	c.Assert(profile, check.Equals, DefaultProfile)
+	for _, name := range []string{ProfileFireProduction, ProfileFireTest, ProfileLenientImport} {
+		profile, err = LookupProfile(name)
+		c.Assert(err, check.IsNil)
+		c.Assert(profile.Name, check.Equals, name)
+	}
+	_, err = LookupProfile("unknown")
+	c.Assert(errors.Is(err, utils.ErrUnknownProfile), check.Equals, true)
+	c.Assert(err.Error(), check.Equals, "unknown is an unknown validation profile")
+}
+
+func (t *FileTest) TestValidateProfile(c *check.C) {
+	f, err := CreateFile(t.oneTransactionJson)
+	c.Assert(err, check.IsNil)
+	matchZipCodes(f)
+
+	// the file is a test file with a payee below the filing threshold
+	report := f.ValidateProfile(Profiles[ProfileFireProduction])
+	c.Assert(report.Valid(), check.Equals, false)
+	c.Assert(report.Count(SeverityError), check.Equals, 1)
+	c.Assert(report.Count(SeverityWarning), check.Equals, 1)
+	entry := report.Entries[len(report.Entries)-1]
+	c.Assert(entry.RecordType, check.Equals, config.TRecordType)
+	c.Assert(entry.FieldName, check.Equals, "TestFileIndicator")
+	c.Assert(entry.Code, check.Equals, utils.CodeProductionTestFile)
+
+	report = f.ValidateProfile(Profiles[ProfileFireTest])
+	c.Assert(report.Valid(), check.Equals, true)
+	c.Assert(report.Count(SeverityInfo), check.Equals, 1)
+	c.Assert(report.Entries[0].Code, check.Equals, utils.CodeBelowThreshold)
+
+	tRecord := f.TransmitterRecord()
+	tRecord.TestFileIndicator = ""
+	report = f.ValidateProfile(Profiles[ProfileFireTest])
+	c.Assert(report.Count(SeverityError), check.Equals, 1)
+	c.Assert(report.Entries[len(report.Entries)-1].Code, check.Equals, utils.CodeTestFileIndicator)
+	report = f.ValidateProfile(Profiles[ProfileFireProduction])
+	c.Assert(report.Valid(), check.Equals, true)
+
+	c.Assert(f.ValidateProfile(nil).Count(SeverityWarning), check.Equals, 1)
+	c.Assert(f.ValidateProfile(Profiles[ProfileLenientImport]).Entries, check.HasLen, 0)
+}
+
+func (t *FileTest) TestValidateProfileSeverities(c *check.C) {
+	f, err := CreateFile(t.oneTransactionJson)
+	c.Assert(err, check.IsNil)
+	matchZipCodes(f)
+	payee := f.Payers()[0].PayeeRecords()[1]
+	payee.RecordSequenceNumber = 10
+	payee.TypeOfTIN = ""
+	c.Assert(f.Validate(), check.NotNil)
+
+	report := f.ValidateProfile(Profiles[ProfileLenientImport])
+	c.Assert(report.Valid(), check.Equals, true)
+	c.Assert(report.Count(SeverityWarning), check.Equals, 1)
+	c.Assert(report.Count(SeverityInfo), check.Equals, 1)
+
+	profile := &Profile{Name: "quiet", Severities: map[string]string{
+		utils.CodeInvalidSequenceNumber: SeverityOff,
+		utils.CodeTypeOfTINInferred:     SeverityError,
+		utils.CodeBelowThreshold:        SeverityOff,
+	}}
+	report = f.ValidateProfile(profile)
+	c.Assert(report.Entries, check.HasLen, 1)
+	c.Assert(report.Entries[0].Code, check.Equals, utils.CodeTypeOfTINInferred)
+	c.Assert(report.Entries[0].Severity, check.Equals, SeverityError)
+
+	// findings of other severities don't fail validation
+	payee.RecordSequenceNumber = 4
+	payee.TypeOfTIN = config.TinType1
+	payee.PayeeZipCode = "22222"
+	c.Assert(f.Validate(), check.IsNil)
+	report = f.ValidateAll()
+	c.Assert(report.Entries, check.HasLen, 1)
+	c.Assert(report.Entries[0].Severity, check.Equals, SeverityWarning)
+	c.Assert(report.Entries[0].StartPosition, check.Equals, 490)
+}
@@ -0,0 +1,116 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package file
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/moov-io/irs/pkg/config"
+	"github.com/moov-io/irs/pkg/records"
+	"github.com/moov-io/irs/pkg/utils"
+)
+
+// Reader reads records of a fire ascii file one at a time.
+//
+// Records are validated as they are read: sequence numbers, payee counts,
+// control totals of “C” and “K” records and the counts of the “F” record are
+// checked against running totals, so memory use does not grow with the number
+// of payees.
+type Reader struct {
+	reader *bufio.Reader
+	state  *streamState
+	// number of records read
+	count int
+}
+
+// NewReader returns a new Reader that reads from r.
+func NewReader(r io.Reader) *Reader {
+	return &Reader{
+		reader: bufio.NewReaderSize(r, config.RecordLength*64),
+		state:  newStreamState(),
+	}
+}
+
+// SetValidation enables or disables the checks performed while reading.
+// Record order is always enforced.
+func (r *Reader) SetValidation(enabled bool) {
+	r.state.validate = enabled
+}
+
+// Read returns the next record of the file.
+// Read returns io.EOF once the end of transmission “F” record has been read.
+func (r *Reader) Read() (records.Record, error) {
+	if r.state.finished() {
+		return nil, io.EOF
+	}
+
+	buf := make([]byte, config.RecordLength)
+	n, err := io.ReadFull(r.reader, buf)
+	if err != nil {
+		if errors.Is(err, io.EOF) && n == 0 {
+			return nil, r.wrap(utils.ErrIncompleteFile)
+		}
+		if errors.Is(err, io.ErrUnexpectedEOF) {
+			return nil, r.wrap(utils.ErrInvalidAscii)
+		}
+		return nil, err
+	}
+	r.count++
+
+	record, err := r.newRecord(string(buf[0]))
+	if err != nil {
+		return nil, r.wrap(err)
+	}
+	r.state.setTaxYear(record)
+	if err = record.Parse(buf); err != nil {
+		return nil, r.wrap(err)
+	}
+	if err = r.state.check(record); err != nil {
+		return nil, r.wrap(err)
+	}
+
+	return record, nil
+}
+
+// Report returns the warnings of the records read so far, such as ITINs with the EIN type of TIN.
+// Errors are returned by Read.
+func (r *Reader) Report() *ValidationReport {
+	return r.state.report
+}
+
+// Count returns the number of records read
+func (r *Reader) Count() int {
+	return r.count
+}
+
+func (r *Reader) newRecord(recordType string) (records.Record, error) {
+	if err := r.state.next(recordType); err != nil {
+		return nil, err
+	}
+
+	switch recordType {
+	case config.TRecordType:
+		return records.NewTRecord(), nil
+	case config.ARecordType:
+		return records.NewARecord(), nil
+	case config.BRecordType:
+		return records.NewBRecord(r.state.typeOfReturn)
+	case config.CRecordType:
+		return &records.CRecord{}, nil
+	case config.KRecordType:
+		return &records.KRecord{}, nil
+	case config.FRecordType:
+		return &records.FRecord{}, nil
+	}
+
+	return nil, utils.ErrInvalidAscii
+}
+
+func (r *Reader) wrap(err error) error {
+	return fmt.Errorf("record %d: %w", r.count, err)
+}
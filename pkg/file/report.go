@@ -0,0 +1,361 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package file
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/moov-io/irs/pkg/config"
+	"github.com/moov-io/irs/pkg/records"
+	"github.com/moov-io/irs/pkg/utils"
+)
+
+// Severities of validation report entries
+const (
+	SeverityError   = utils.SeverityError
+	SeverityWarning = utils.SeverityWarning
+	SeverityInfo    = utils.SeverityInfo
+	// SeverityOff turns off checks in a Profile, reports have no entries of it
+	SeverityOff = "off"
+)
+
+// ValidationEntry describes a single problem found in a file
+type ValidationEntry struct {
+	// RecordType is the type of the record (T, A, B, C, K, F)
+	RecordType string `json:"record_type"`
+	// SequenceNumber is the record sequence number as it appears in the file
+	SequenceNumber int `json:"record_sequence_number"`
+	// PayerIndex is the one-based index of the payer, zero for transmitter records
+	PayerIndex int `json:"payer_index,omitempty"`
+	// FieldName is the name of the field
+	FieldName string `json:"field_name,omitempty"`
+	// StartPosition is the Pub 1220 start position of the field (one-based)
+	StartPosition int `json:"start_position,omitempty"`
+	// EndPosition is the Pub 1220 end position of the field (one-based)
+	EndPosition int `json:"end_position,omitempty"`
+	// Severity of the problem
+	Severity string `json:"severity"`
+	// Code is a stable error code
+	Code string `json:"code"`
+	// Message describes the problem
+	Message string `json:"message"`
+}
+
+// String returns a human-readable form of the entry
+func (e ValidationEntry) String() string {
+	var buf strings.Builder
+	buf.WriteString(fmt.Sprintf("%s record %d", e.RecordType, e.SequenceNumber))
+	if e.PayerIndex > 0 {
+		buf.WriteString(fmt.Sprintf(" (payer %d)", e.PayerIndex))
+	}
+	if len(e.FieldName) > 0 {
+		buf.WriteString(fmt.Sprintf(" %s", e.FieldName))
+	}
+	if e.StartPosition > 0 {
+		buf.WriteString(fmt.Sprintf(" [%d-%d]", e.StartPosition, e.EndPosition))
+	}
+	buf.WriteString(fmt.Sprintf(": %s %s (%s)", e.Severity, e.Message, e.Code))
+	return buf.String()
+}
+
+// ValidationReport collects every problem found in a file
+type ValidationReport struct {
+	Entries []ValidationEntry `json:"entries"`
+}
+
+// Valid returns true if the report has no entries of error severity
+func (r *ValidationReport) Valid() bool {
+	return r.Count(SeverityError) == 0
+}
+
+// Count returns number of entries with the severity
+func (r *ValidationReport) Count(severity string) int {
+	count := 0
+	for _, entry := range r.Entries {
+		if entry.Severity == severity {
+			count++
+		}
+	}
+	return count
+}
+
+// Err returns the report as an error if it has entries of error severity
+func (r *ValidationReport) Err() error {
+	if r.Valid() {
+		return nil
+	}
+	return r
+}
+
+// Error returns all entries, one entry per line
+func (r *ValidationReport) Error() string {
+	lines := make([]string, 0, len(r.Entries))
+	for _, entry := range r.Entries {
+		lines = append(lines, entry.String())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// add appends an entry for the record with the severity of the error
+func (r *ValidationReport) add(record records.Record, payerIndex int, fieldName string, err error) {
+	entry := ValidationEntry{
+		PayerIndex: payerIndex,
+		FieldName:  fieldName,
+		Severity:   utils.Severity(err),
+		Code:       utils.ErrorCode(err),
+		Message:    err.Error(),
+	}
+	if record != nil {
+		entry.RecordType = record.Type()
+		entry.SequenceNumber = record.SequenceNumber()
+		if spec, ok := config.RecordLayout(records.TaxYear(record), entry.RecordType)[fieldName]; ok {
+			entry.StartPosition = spec.Start + 1
+			entry.EndPosition = spec.Start + spec.Length
+		}
+	}
+	r.Entries = append(r.Entries, entry)
+}
+
+// warn appends an entry of warning severity for the record
+func (r *ValidationReport) warn(record records.Record, payerIndex int, fieldName string, err error) {
+	r.addWithSeverity(record, payerIndex, fieldName, SeverityWarning, err)
+}
+
+// addWithSeverity appends an entry with the severity
+func (r *ValidationReport) addWithSeverity(record records.Record, payerIndex int, fieldName, severity string, err error) {
+	r.add(record, payerIndex, fieldName, err)
+	r.Entries[len(r.Entries)-1].Severity = severity
+}
+
+// addFields appends entries for all field errors of the record
+func (r *ValidationReport) addFields(record records.Record, payerIndex int) {
+	for _, err := range records.ValidateFields(record) {
+		entry := ValidationEntry{
+			RecordType:     record.Type(),
+			SequenceNumber: record.SequenceNumber(),
+			PayerIndex:     payerIndex,
+			FieldName:      err.FieldName,
+			Severity:       err.Severity,
+			Code:           utils.ErrorCode(err.Err),
+			Message:        err.Err.Error(),
+		}
+		if err.Length > 0 {
+			entry.StartPosition = err.Start + 1
+			entry.EndPosition = err.Start + err.Length
+		}
+		r.Entries = append(r.Entries, entry)
+	}
+}
+
+// ValidateAll performs all checks of Validate and collects every problem into a report
+func (f *fileInstance) ValidateAll() *ValidationReport {
+	report := &ValidationReport{}
+
+	if f.Transmitter == nil || f.EndTransmitter == nil {
+		report.add(nil, 0, "", utils.ErrInvalidFile)
+		return report
+	}
+
+	report.addFields(f.Transmitter, 0)
+	for index, person := range f.PaymentPersons {
+		person.report(report, index+1)
+	}
+	report.addFields(f.EndTransmitter, 0)
+
+	f.reportSequenceNumbers(report)
+	f.reportCounts(report)
+	f.reportTINs(report)
+	f.reportNameControls(report)
+
+	return report
+}
+
+func (f *fileInstance) reportSequenceNumbers(report *ValidationReport) {
+	sequenceNumber := 0
+	check := func(record records.Record, payerIndex int) {
+		sequenceNumber++
+		if record != nil && record.SequenceNumber() != sequenceNumber {
+			report.add(record, payerIndex, "RecordSequenceNumber", utils.NewErrRecordSequenceNumber(record.Type()))
+		}
+	}
+
+	check(f.Transmitter, 0)
+	for index, person := range f.PaymentPersons {
+		check(person.Payer, index+1)
+		for _, payee := range person.Payees {
+			check(payee, index+1)
+		}
+		check(person.EndPayer, index+1)
+		for _, state := range person.States {
+			check(state, index+1)
+		}
+	}
+	check(f.EndTransmitter, 0)
+}
+
+func (f *fileInstance) reportCounts(report *ValidationReport) {
+	tRecord, fRecord, err := f.getRecords()
+	if err != nil {
+		report.add(nil, 0, "", err)
+		return
+	}
+
+	if fRecord.NumberPayerRecords != len(f.PaymentPersons) {
+		report.add(fRecord, 0, "NumberPayerRecords", utils.ErrInvalidNumberPayers)
+	}
+
+	if tRecord.TotalNumberPayees > 0 {
+		if tRecord.TotalNumberPayees != f.getNumberPayees() {
+			report.add(tRecord, 0, "TotalNumberPayees", utils.ErrInvalidNumberPayees)
+		}
+	} else if fRecord.NumberPayerRecords > 0 && fRecord.TotalNumberPayees != f.getNumberPayees() {
+		report.add(fRecord, 0, "TotalNumberPayees", utils.ErrInvalidNumberPayees)
+	}
+}
+
+// report collects every problem of the payer into the report
+func (p *PaymentPerson) report(report *ValidationReport, index int) {
+	if err := p.validateRecords(); err != nil {
+		report.add(p.Payer, index, "", err)
+		if p.Payer == nil {
+			return
+		}
+	}
+
+	for _, record := range p.records() {
+		report.addFields(record, index)
+	}
+
+	aRecord, ok := p.Payer.(*records.ARecord)
+	if !ok {
+		report.add(p.Payer, index, "", utils.NewErrUnexpectedRecord("payer", p.Payer))
+		return
+	}
+	cRecord, ok := p.EndPayer.(*records.CRecord)
+	if !ok {
+		if p.EndPayer != nil {
+			report.add(p.EndPayer, index, "", utils.NewErrUnexpectedRecord("end of payer", p.EndPayer))
+		}
+		return
+	}
+
+	// corrected return indicator, payment codes and amounts of payees
+	aCodes := toSet(aRecord.AmountCodes)
+	bUnion := make(map[string]bool)
+	totals := newPayeeTotals(config.TypeOfReturns[aRecord.TypeOfReturn])
+	existedIndicator := ""
+	for _, payee := range p.Payees {
+		bRecord, ok := payee.(*records.BRecord)
+		if !ok {
+			report.add(payee, index, "", utils.NewErrUnexpectedRecord("payee", payee))
+			continue
+		}
+
+		indicator := bRecord.CorrectedReturnIndicator
+		if len(indicator) == 0 {
+			indicator = "N"
+		}
+		if existedIndicator == "" {
+			existedIndicator = indicator
+		}
+		if existedIndicator != indicator {
+			report.add(bRecord, index, "CorrectedReturnIndicator", utils.ErrIncorrectReturnIndicator)
+		}
+
+		for _, code := range strings.Split(bRecord.PaymentCodes(), "") {
+			if !aCodes[code] {
+				report.add(bRecord, index, "PaymentAmount"+code, utils.ErrUnexpectedPaymentAmount)
+			}
+			bUnion[code] = true
+			amount, _ := bRecord.PaymentAmount(code)
+			totals.add(code, amount)
+		}
+	}
+
+	if len(p.Payees) != cRecord.NumberPayees {
+		report.add(cRecord, index, "NumberPayees", utils.ErrInvalidNumberPayees)
+	}
+
+	cCodes := toSet(cRecord.TotalCodes())
+	for code := range bUnion {
+		if !cCodes[code] {
+			report.add(cRecord, index, "ControlTotal"+code, utils.ErrUnexpectedTotalAmount)
+		}
+	}
+	for _, code := range strings.Split(cRecord.TotalCodes(), "") {
+		if !bUnion[code] {
+			report.add(cRecord, index, "ControlTotal"+code, utils.ErrUnexpectedTotalAmount)
+			continue
+		}
+		control, _ := cRecord.ControlTotal(code)
+		if err := totals.endPayerTotalError(code, control); err != nil {
+			report.add(cRecord, index, "ControlTotal"+code, err)
+		}
+	}
+
+	// state totals
+	stateTotals := make(map[string]int)
+	fsCodes := make(map[string]bool)
+	for _, state := range p.States {
+		kRecord, ok := state.(*records.KRecord)
+		if !ok {
+			report.add(state, index, "", utils.NewErrUnexpectedRecord("state", state))
+			continue
+		}
+		for _, code := range strings.Split(kRecord.PaymentCodes(), "") {
+			if !aCodes[code] {
+				report.add(kRecord, index, "ControlTotal"+code, utils.ErrUnexpectedTotalAmount)
+			}
+		}
+		for _, code := range strings.Split(cRecord.TotalCodes(), "") {
+			amount, _ := kRecord.ControlTotal(code)
+			if err := totals.negativeTotalError(code, amount); err != nil {
+				report.add(kRecord, index, "ControlTotal"+code, err)
+			}
+			stateTotals[code] += amount
+		}
+		if fsCodes[kRecord.CombinedFederalStateCode] {
+			report.add(kRecord, index, "CombinedFederalStateCode", utils.ErrDuplicatedFSCode)
+		}
+		fsCodes[kRecord.CombinedFederalStateCode] = true
+	}
+	if len(p.States) > 0 {
+		for _, code := range strings.Split(cRecord.TotalCodes(), "") {
+			if err := totals.stateTotalError(code, stateTotals[code]); err != nil {
+				report.add(cRecord, index, "ControlTotal"+code, err)
+			}
+		}
+	}
+
+	// combined federal/state filing program
+	if aRecord.CombinedFSFilingProgram == config.FSFilingProgramApproved {
+		if err := p.validateFSCodes(); err != nil && err != utils.ErrDuplicatedFSCode {
+			report.add(aRecord, index, "CombinedFSFilingProgram", err)
+		}
+	}
+}
+
+// records returns all existing records of the payer in file order
+func (p *PaymentPerson) records() []records.Record {
+	list := make([]records.Record, 0, len(p.Payees)+len(p.States)+2)
+	if p.Payer != nil {
+		list = append(list, p.Payer)
+	}
+	for _, payee := range p.Payees {
+		if payee != nil {
+			list = append(list, payee)
+		}
+	}
+	if p.EndPayer != nil {
+		list = append(list, p.EndPayer)
+	}
+	for _, state := range p.States {
+		if state != nil {
+			list = append(list, state)
+		}
+	}
+	return list
+}
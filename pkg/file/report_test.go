@@ -0,0 +1,87 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package file
+
+import (
+	"encoding/json"
+
+	"gopkg.in/check.v1"
+
+	"github.com/moov-io/irs/pkg/records"
+	"github.com/moov-io/irs/pkg/utils"
+)
+
+func (t *FileTest) TestValidateAllWithValidFile(c *check.C) {
+	f, err := CreateFile(t.oneTransactionJson)
+	c.Assert(err, check.IsNil)
+	report := f.ValidateAll()
+	c.Assert(report.Valid(), check.Equals, true)
+	c.Assert(report.Err(), check.IsNil)
+	c.Assert(report.Count(SeverityError), check.Equals, 0)
+}
+
+func (t *FileTest) TestValidateAllCollectsErrors(c *check.C) {
+	f, err := CreateFile(t.oneTransactionJson)
+	c.Assert(err, check.IsNil)
+	instance := f.(*fileInstance)
+
+	payer := instance.PaymentPersons[0].Payer.(*records.ARecord)
+	payer.TypeOfReturn = "?"
+	payee := instance.PaymentPersons[0].Payees[1].(*records.BRecord)
+	payee.SetSequenceNumber(9)
+	payee.PayeeZipCode = "!"
+	endPayer := instance.PaymentPersons[0].EndPayer.(*records.CRecord)
+	endPayer.NumberPayees = 5
+
+	report := f.ValidateAll()
+	c.Assert(report.Valid(), check.Equals, false)
+	c.Assert(report.Err(), check.NotNil)
+
+	find := func(recordType, fieldName string) *ValidationEntry {
+		for i, entry := range report.Entries {
+			if entry.RecordType == recordType && entry.FieldName == fieldName {
+				return &report.Entries[i]
+			}
+		}
+		return nil
+	}
+
+	entry := find("A", "TypeOfReturn")
+	c.Assert(entry, check.NotNil)
+	c.Assert(entry.PayerIndex, check.Equals, 1)
+	c.Assert(entry.StartPosition, check.Equals, 26)
+	c.Assert(entry.EndPosition, check.Equals, 27)
+	c.Assert(entry.Code, check.Equals, utils.CodeInvalidValue)
+	c.Assert(entry.Severity, check.Equals, SeverityError)
+
+	entry = find("B", "RecordSequenceNumber")
+	c.Assert(entry, check.NotNil)
+	c.Assert(entry.SequenceNumber, check.Equals, 9)
+	c.Assert(entry.StartPosition, check.Equals, 500)
+	c.Assert(entry.EndPosition, check.Equals, 507)
+	c.Assert(entry.Code, check.Equals, utils.CodeInvalidSequenceNumber)
+
+	c.Assert(find("B", "PayeeZipCode"), check.NotNil)
+
+	entry = find("C", "NumberPayees")
+	c.Assert(entry, check.NotNil)
+	c.Assert(entry.Code, check.Equals, utils.CodeInvalidNumberPayees)
+
+	buf, err := json.Marshal(report)
+	c.Assert(err, check.IsNil)
+	c.Assert(json.Valid(buf), check.Equals, true)
+}
+
+func (t *FileTest) TestValidateAllWithInvalidPayment(c *check.C) {
+	f, err := CreateFile(t.jsonWithInvalidPayment)
+	c.Assert(err, check.IsNil)
+	report := f.ValidateAll()
+	c.Assert(report.Valid(), check.Equals, false)
+	c.Assert(report.Count(SeverityError) > 0, check.Equals, true)
+
+	report = (&fileInstance{}).ValidateAll()
+	c.Assert(report.Valid(), check.Equals, false)
+	c.Assert(report.Entries[0].Code, check.Equals, utils.CodeInvalidRecord)
+}
@@ -0,0 +1,207 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package file
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/moov-io/irs/pkg/config"
+	"github.com/moov-io/irs/pkg/records"
+	"github.com/moov-io/irs/pkg/subrecords"
+	"github.com/moov-io/irs/pkg/utils"
+)
+
+// filingRule checks a payee “B” record of the type of return
+type filingRule struct {
+	code  string
+	check func(form string, payee *records.BRecord) (string, error)
+}
+
+var filingRules = []filingRule{
+	{utils.CodeBelowThreshold, checkFilingThreshold},
+	{utils.CodeZeroPayment, checkZeroPayment},
+	{utils.CodeWithholdingExceeds, checkWithholding},
+}
+
+// ValidateRules checks payees against the filing rules of their type of return
+//
+// Unlike Validate and ValidateAll, which check the file layout, the filing
+// rules ask whether a return is required and whether its amounts make sense:
+//   - payment amounts below the filing thresholds of config.FilingThresholds,
+//     unless federal income tax was withheld or direct sales are indicated
+//   - payees without payment amounts and without a direct sales indicator
+//   - federal income tax withheld greater than the gross amount
+//
+// The profile chooses the rules and the severities of their findings, a nil
+// profile is the DefaultProfile.
+func (f *fileInstance) ValidateRules(profile *Profile) *ValidationReport {
+	if profile == nil {
+		profile = DefaultProfile
+	}
+
+	report := &ValidationReport{}
+	for index, person := range f.PaymentPersons {
+		payer := person.PayerRecord()
+		if payer == nil {
+			continue
+		}
+		form := config.TypeOfReturns[payer.TypeOfReturn]
+		for _, payee := range person.PayeeRecords() {
+			for _, rule := range filingRules {
+				severity, ok := profile.severity(rule.code)
+				if !ok {
+					continue
+				}
+				if fieldName, err := rule.check(form, payee); err != nil {
+					report.addWithSeverity(payee, index+1, fieldName, severity, err)
+				}
+			}
+		}
+	}
+	return report
+}
+
+// checkFilingThreshold returns an error if every payment amount is below its filing threshold
+//
+// Only gross amounts are checked, so 1099-K monthly amounts don't count. Boxes
+// without a threshold require a return for any amount, payees without payment
+// amounts are left to checkZeroPayment.
+func checkFilingThreshold(form string, payee *records.BRecord) (string, error) {
+	if len(config.FilingThresholds[form]) == 0 || withheldAmount(form, payee) > 0 || directSales(payee) {
+		return "", nil
+	}
+
+	var amounts []string
+	for _, code := range grossAmountCodes(form) {
+		amount, err := payee.PaymentAmount(code)
+		if err != nil || amount == 0 || isWithholdingCode(form, code) {
+			continue
+		}
+		threshold, ok := filingThreshold(form, code, payee)
+		if !ok || (amount >= threshold.Amount && paymentTransactions(payee) >= threshold.Transactions) {
+			return "", nil
+		}
+		amounts = append(amounts, fmt.Sprintf("%s %s of %s", config.AmountCodes[form][code], utils.FormatDollars(amount), utils.FormatDollars(threshold.Amount)))
+	}
+	if len(amounts) == 0 {
+		return "", nil
+	}
+	return "", utils.NewErrBelowThreshold(strings.Join(amounts, ", "))
+}
+
+// checkZeroPayment returns an error if the payee has no payment amounts and no direct sales indicator
+func checkZeroPayment(form string, payee *records.BRecord) (string, error) {
+	if len(payee.PaymentCodes()) > 0 || directSales(payee) {
+		return "", nil
+	}
+	return "", utils.ErrZeroPaymentAmounts
+}
+
+// checkWithholding returns an error if federal income tax withheld is greater than the gross amount
+func checkWithholding(form string, payee *records.BRecord) (string, error) {
+	withheld := withheldAmount(form, payee)
+	if withheld == 0 {
+		return "", nil
+	}
+
+	gross := 0
+	for _, code := range grossAmountCodes(form) {
+		if amount, err := payee.PaymentAmount(code); err == nil && !isWithholdingCode(form, code) {
+			gross += amount
+		}
+	}
+	if withheld <= gross {
+		return "", nil
+	}
+
+	fieldName := ""
+	for _, code := range amountCodes {
+		if isWithholdingCode(form, code) {
+			fieldName = "PaymentAmount" + code
+			break
+		}
+	}
+	return fieldName, utils.NewErrWithholdingExceeds(utils.FormatDollars(withheld), utils.FormatDollars(gross))
+}
+
+// filingThreshold returns the threshold of the box for the payment year of the payee
+//
+// The latest threshold of the payment year or before applies, payment years
+// before all thresholds use the earliest one.
+func filingThreshold(form, code string, payee *records.BRecord) (config.FilingThreshold, bool) {
+	var found *config.FilingThreshold
+	thresholds := config.FilingThresholds[form]
+	for i := range thresholds {
+		threshold := &thresholds[i]
+		if threshold.AmountCode != code || !matchTypeWager(threshold, payee) {
+			continue
+		}
+		switch {
+		case found == nil:
+			found = threshold
+		case found.TaxYear > payee.PaymentYear:
+			if threshold.TaxYear < found.TaxYear {
+				found = threshold
+			}
+		case threshold.TaxYear <= payee.PaymentYear && threshold.TaxYear > found.TaxYear:
+			found = threshold
+		}
+	}
+	if found == nil {
+		return config.FilingThreshold{}, false
+	}
+	return *found, true
+}
+
+func matchTypeWager(threshold *config.FilingThreshold, payee *records.BRecord) bool {
+	if len(threshold.TypeWagerCodes) == 0 {
+		return true
+	}
+	ext, ok := payee.Extension().(*subrecords.SubW2G)
+	if !ok {
+		return false
+	}
+	for _, code := range threshold.TypeWagerCodes {
+		if code == ext.TypeWagerCode {
+			return true
+		}
+	}
+	return false
+}
+
+func grossAmountCodes(form string) []string {
+	if codes, ok := config.GrossAmountCodes[form]; ok {
+		return codes
+	}
+	return amountCodes
+}
+
+// isWithholdingCode returns true if the amount code of the type of return is federal income tax withheld
+func isWithholdingCode(form, code string) bool {
+	return strings.Contains(strings.ToLower(config.AmountCodes[form][code]), "federal income tax withheld")
+}
+
+func withheldAmount(form string, payee *records.BRecord) int {
+	withheld := 0
+	for code := range config.AmountCodes[form] {
+		if amount, err := payee.PaymentAmount(code); err == nil && isWithholdingCode(form, code) {
+			withheld += amount
+		}
+	}
+	return withheld
+}
+
+func directSales(payee *records.BRecord) bool {
+	indicator, err := payee.DirectSales()
+	return err == nil && *indicator == config.DirectSalesIndicator
+}
+
+func paymentTransactions(payee *records.BRecord) int {
+	if ext, ok := payee.Extension().(*subrecords.Sub1099K); ok {
+		return ext.NumberPaymentTransactions
+	}
+	return 0
+}
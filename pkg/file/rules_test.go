@@ -0,0 +1,112 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package file
+
+import (
+	"gopkg.in/check.v1"
+
+	"github.com/moov-io/irs/pkg/config"
+	"github.com/moov-io/irs/pkg/records"
+	"github.com/moov-io/irs/pkg/subrecords"
+	"github.com/moov-io/irs/pkg/utils"
+)
+
+func (t *FileTest) TestValidateRules(c *check.C) {
+	f, err := CreateFile(t.oneTransactionJson)
+	c.Assert(err, check.IsNil)
+
+	// the first payee indicates direct sales
+	report := f.ValidateRules(nil)
+	c.Assert(report.Valid(), check.Equals, true)
+	c.Assert(report.Count(SeverityWarning), check.Equals, 1)
+	c.Assert(report.Entries[0].RecordType, check.Equals, config.BRecordType)
+	c.Assert(report.Entries[0].PayerIndex, check.Equals, 1)
+	c.Assert(report.Entries[0].Code, check.Equals, utils.CodeBelowThreshold)
+	c.Assert(report.Entries[0].Message, check.Equals, "has payment amounts below filing threshold (Nonemployee compensation (NEC) 7.00 of 600.00)")
+
+	payees := f.Payers()[0].PayeeRecords()
+	payees[1].PaymentAmount7 = 60000
+	payees[1].PaymentAmount4 = 70000
+	report = f.ValidateRules(nil)
+	c.Assert(report.Valid(), check.Equals, false)
+	c.Assert(report.Count(SeverityError), check.Equals, 1)
+	c.Assert(report.Entries[0].FieldName, check.Equals, "PaymentAmount4")
+	c.Assert(report.Entries[0].Code, check.Equals, utils.CodeWithholdingExceeds)
+	c.Assert(report.Entries[0].Message, check.Equals, "has federal income tax withheld 700.00 greater than gross amount 600.00")
+
+	payees[1].PaymentAmount4 = 0
+	payees[1].PaymentAmount7 = 0
+	report = f.ValidateRules(nil)
+	c.Assert(report.Count(SeverityWarning), check.Equals, 1)
+	c.Assert(report.Entries[0].Code, check.Equals, utils.CodeZeroPayment)
+
+	payees[0].PaymentAmount7 = 0
+	report = f.ValidateRules(nil)
+	c.Assert(report.Count(SeverityWarning), check.Equals, 1)
+
+	profile := &Profile{Name: "strict", Severities: map[string]string{utils.CodeZeroPayment: SeverityError}}
+	report = f.ValidateRules(profile)
+	c.Assert(report.Valid(), check.Equals, false)
+	c.Assert(report.Count(SeverityError), check.Equals, 1)
+	c.Assert(f.ValidateRules(&Profile{}).Entries, check.HasLen, 0)
+}
+
+func (t *FileTest) TestFilingThresholds(c *check.C) {
+	newPayee := func(typeOfReturn string, year int) *records.BRecord {
+		record, err := records.NewBRecord(typeOfReturn)
+		c.Assert(err, check.IsNil)
+		payee := record.(*records.BRecord)
+		payee.PaymentYear = year
+		return payee
+	}
+
+	payee := newPayee(config.Sub1099NecType, 2025)
+	payee.PaymentAmount1 = 150000
+	_, err := checkFilingThreshold(config.Sub1099NecType, payee)
+	c.Assert(err, check.IsNil)
+	payee.PaymentYear = 2026
+	_, err = checkFilingThreshold(config.Sub1099NecType, payee)
+	c.Assert(utils.ErrorCode(err), check.Equals, utils.CodeBelowThreshold)
+	payee.PaymentAmount4 = 100
+	_, err = checkFilingThreshold(config.Sub1099NecType, payee)
+	c.Assert(err, check.IsNil)
+
+	payee = newPayee(config.Sub1099KType, 2024)
+	payee.PaymentAmount1 = 2500000
+	payee.PaymentAmount5 = 100
+	ext := payee.Extension().(*subrecords.Sub1099K)
+	ext.NumberPaymentTransactions = 150
+	_, err = checkFilingThreshold(config.Sub1099KType, payee)
+	c.Assert(utils.ErrorCode(err), check.Equals, utils.CodeBelowThreshold)
+	ext.NumberPaymentTransactions = 201
+	_, err = checkFilingThreshold(config.Sub1099KType, payee)
+	c.Assert(err, check.IsNil)
+
+	payee = newPayee(config.SubW2GType, 2024)
+	payee.PaymentAmount1 = 130000
+	wager := payee.Extension().(*subrecords.SubW2G)
+	wager.TypeWagerCode = "1"
+	_, err = checkFilingThreshold("W-2G", payee)
+	c.Assert(err, check.IsNil)
+	wager.TypeWagerCode = "5"
+	_, err = checkFilingThreshold("W-2G", payee)
+	c.Assert(utils.ErrorCode(err), check.Equals, utils.CodeBelowThreshold)
+	wager.TypeWagerCode = "7"
+	_, err = checkFilingThreshold("W-2G", payee)
+	c.Assert(err, check.IsNil)
+	payee.PaymentYear = 2026
+	_, err = checkFilingThreshold("W-2G", payee)
+	c.Assert(utils.ErrorCode(err), check.Equals, utils.CodeBelowThreshold)
+
+	payee = newPayee(config.Sub1099MiscType, 2017)
+	payee.PaymentAmount2 = 500
+	payee.PaymentAmount3 = 20000
+	_, err = checkFilingThreshold(config.Sub1099MiscType, payee)
+	c.Assert(utils.ErrorCode(err), check.Equals, utils.CodeBelowThreshold)
+	c.Assert(err.Error(), check.Equals, "has payment amounts below filing threshold (Royalties 5.00 of 10.00, Other income 200.00 of 600.00)")
+	payee.PaymentAmount2 = 1000
+	_, err = checkFilingThreshold(config.Sub1099MiscType, payee)
+	c.Assert(err, check.IsNil)
+}
@@ -0,0 +1,343 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package file
+
+import (
+	"strings"
+
+	"github.com/moov-io/irs/pkg/config"
+	"github.com/moov-io/irs/pkg/records"
+	"github.com/moov-io/irs/pkg/utils"
+)
+
+// streamState tracks the position inside a file that is read or written one
+// record at a time. Only running totals of the current payer are kept, so
+// memory use does not depend on the number of payees.
+type streamState struct {
+	validate bool
+	// findings of the records below SeverityError
+	report *ValidationReport
+
+	last         string
+	sequence     int
+	typeOfReturn string
+
+	transmitter  *records.TRecord
+	payer        *records.ARecord
+	endPayer     *records.CRecord
+	numberPayers int
+	numberPayees int
+
+	// running state of the current payer
+	payees      int
+	indicator   string
+	aCodes      map[string]bool
+	bCodes      map[string]bool
+	totals      *payeeTotals
+	stateTotals map[string]int
+	fsCodes     map[string]bool
+	payeeStates map[string]bool
+	stateCodes  map[string]bool
+}
+
+func newStreamState() *streamState {
+	return &streamState{validate: true, report: &ValidationReport{}}
+}
+
+// finished returns true after the end of transmission record
+func (s *streamState) finished() bool {
+	return s.last == config.FRecordType
+}
+
+// next verifies that a record of the given type may follow the previous record
+func (s *streamState) next(recordType string) error {
+	allowed := false
+	switch recordType {
+	case config.TRecordType:
+		allowed = s.last == ""
+	case config.ARecordType:
+		allowed = s.last == config.TRecordType || s.last == config.CRecordType || s.last == config.KRecordType
+	case config.BRecordType:
+		allowed = s.last == config.ARecordType || s.last == config.BRecordType
+	case config.CRecordType:
+		allowed = s.last == config.ARecordType || s.last == config.BRecordType
+	case config.KRecordType:
+		allowed = s.last == config.CRecordType || s.last == config.KRecordType
+	case config.FRecordType:
+		allowed = s.last == config.CRecordType || s.last == config.KRecordType
+	default:
+		return utils.NewErrRecordType(recordType)
+	}
+
+	if !allowed {
+		if (recordType == config.ARecordType || recordType == config.KRecordType || recordType == config.FRecordType) &&
+			(s.last == config.ARecordType || s.last == config.BRecordType) {
+			return utils.ErrNonExistEndPayer
+		}
+		return utils.ErrUnexpectedRecordOrder
+	}
+	return nil
+}
+
+// record checks the record against the records seen before it and updates the running totals
+func (s *streamState) record(r records.Record) error {
+	switch rec := r.(type) {
+	case *records.TRecord:
+		s.transmitter = rec
+	case *records.ARecord:
+		if err := s.endPayerCheck(); err != nil {
+			return err
+		}
+		typeOfReturn, ok := config.TypeOfReturns[rec.TypeOfReturn]
+		if !ok {
+			return utils.ErrInvalidTypeOfReturn
+		}
+		s.startPayer(rec, typeOfReturn)
+	case *records.BRecord:
+		if rec.TypeOfReturn() != s.typeOfReturn {
+			return utils.ErrInvalidTypeOfReturn
+		}
+		if err := s.payeeCheck(rec); err != nil {
+			return err
+		}
+	case *records.CRecord:
+		s.endPayer = rec
+		if err := s.endPayerTotalsCheck(rec); err != nil {
+			return err
+		}
+	case *records.KRecord:
+		if err := s.stateCheck(rec); err != nil {
+			return err
+		}
+	case *records.FRecord:
+		if err := s.endPayerCheck(); err != nil {
+			return err
+		}
+		if err := s.endTransmissionCheck(rec); err != nil {
+			return err
+		}
+	default:
+		return utils.NewErrUnexpectedRecord("irs", r)
+	}
+
+	s.last = r.Type()
+	return nil
+}
+
+// check runs the record level checks and the streaming integration checks
+func (s *streamState) check(r records.Record) error {
+	if err := s.next(r.Type()); err != nil {
+		return err
+	}
+
+	if s.validate {
+		if err := r.Validate(); err != nil {
+			return err
+		}
+		s.sequence++
+		if r.SequenceNumber() != s.sequence {
+			return utils.NewErrRecordSequenceNumber(r.Type())
+		}
+	}
+
+	if err := s.record(r); err != nil {
+		return err
+	}
+
+	if s.validate {
+		payerIndex := s.numberPayers
+		if r.Type() == config.TRecordType || r.Type() == config.FRecordType {
+			payerIndex = 0
+		}
+		s.report.addFields(r, payerIndex)
+	}
+	return nil
+}
+
+// setTaxYear sets prior year data of the transmitter to payers and payees and
+// tax year of records without payment year from the current payer or transmitter
+func (s *streamState) setTaxYear(record records.Record) {
+	switch record.(type) {
+	case *records.ARecord, *records.BRecord:
+		setPriorYearData(record, priorYearData(s.transmitter))
+	case *records.CRecord, *records.KRecord:
+		if s.payer != nil {
+			setTaxYear(record, records.TaxYear(s.payer))
+		}
+	case *records.FRecord:
+		if s.transmitter != nil {
+			setTaxYear(record, records.TaxYear(s.transmitter))
+		}
+	}
+}
+
+func (s *streamState) startPayer(payer *records.ARecord, typeOfReturn string) {
+	s.payer = payer
+	s.endPayer = nil
+	s.typeOfReturn = typeOfReturn
+	s.numberPayers++
+	s.payees = 0
+	s.indicator = ""
+	s.aCodes = toSet(payer.AmountCodes)
+	s.bCodes = make(map[string]bool)
+	s.totals = newPayeeTotals(typeOfReturn)
+	s.stateTotals = make(map[string]int)
+	s.fsCodes = make(map[string]bool)
+	s.payeeStates = make(map[string]bool)
+	s.stateCodes = make(map[string]bool)
+}
+
+func (s *streamState) payeeCheck(payee *records.BRecord) error {
+	s.payees++
+	s.numberPayees++
+	if !s.validate {
+		return nil
+	}
+
+	// corrected return indicator
+	indicator := payee.CorrectedReturnIndicator
+	if len(indicator) == 0 {
+		indicator = "N"
+	}
+	if s.indicator == "" {
+		s.indicator = indicator
+	}
+	if s.indicator != indicator {
+		return utils.ErrIncorrectReturnIndicator
+	}
+
+	// B ⊆ A
+	codes := payee.PaymentCodes()
+	if !subset(toSet(codes), s.aCodes) {
+		return utils.ErrUnexpectedPaymentAmount
+	}
+	merge(s.bCodes, codes)
+
+	for _, code := range strings.Split(codes, "") {
+		amount, err := payee.PaymentAmount(code)
+		if err != nil {
+			return err
+		}
+		s.totals.add(code, amount)
+	}
+
+	if s.payer.CombinedFSFilingProgram == config.FSFilingProgramApproved {
+		code, exist := config.ParticipateStateCodes[payee.FederalState()]
+		if !exist {
+			return utils.NewErrValidValue("combined federal state code")
+		}
+		s.payeeStates[code] = true
+	}
+
+	return nil
+}
+
+func (s *streamState) endPayerTotalsCheck(endPayer *records.CRecord) error {
+	if !s.validate {
+		return nil
+	}
+
+	if s.payees != endPayer.NumberPayees {
+		return utils.ErrInvalidNumberPayees
+	}
+
+	// codes(C) must equal ∪B
+	if !equal(toSet(endPayer.TotalCodes()), s.bCodes) {
+		return utils.ErrUnexpectedTotalAmount
+	}
+
+	// ΣB == C for every amount code
+	for _, code := range strings.Split(endPayer.TotalCodes(), "") {
+		control, err := endPayer.ControlTotal(code)
+		if err != nil {
+			return err
+		}
+		if err := s.totals.endPayerTotalError(code, control); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *streamState) stateCheck(state *records.KRecord) error {
+	if !s.validate {
+		return nil
+	}
+
+	// K ⊆ A
+	if !subset(toSet(state.PaymentCodes()), s.aCodes) {
+		return utils.ErrUnexpectedTotalAmount
+	}
+
+	if s.fsCodes[state.CombinedFederalStateCode] {
+		return utils.ErrDuplicatedFSCode
+	}
+	s.fsCodes[state.CombinedFederalStateCode] = true
+
+	// ΣK within losses and gains of B (state totals may be subset of national totals)
+	for _, code := range strings.Split(s.endPayer.TotalCodes(), "") {
+		amount, err := state.ControlTotal(code)
+		if err != nil {
+			return err
+		}
+		if err := s.totals.negativeTotalError(code, amount); err != nil {
+			return err
+		}
+		s.stateTotals[code] += amount
+		if err := s.totals.stateTotalError(code, s.stateTotals[code]); err != nil {
+			return err
+		}
+	}
+
+	if s.payer.CombinedFSFilingProgram == config.FSFilingProgramApproved {
+		code, exist := config.StateAbbreviationCodes[state.CombinedFederalStateCode]
+		if !exist {
+			return utils.NewErrValidValue("combined federal state code")
+		}
+		s.stateCodes[code] = true
+	}
+
+	return nil
+}
+
+// endPayerCheck verifies the CF/SF coding of the finished payer
+func (s *streamState) endPayerCheck() error {
+	if !s.validate || s.payer == nil {
+		return nil
+	}
+	if s.payer.CombinedFSFilingProgram != config.FSFilingProgramApproved {
+		return nil
+	}
+	if s.payees == 0 || len(s.fsCodes) == 0 {
+		return utils.ErrCFSFProgram
+	}
+	if !eq(s.payeeStates, s.stateCodes) {
+		return utils.ErrCFSFState
+	}
+	return nil
+}
+
+func (s *streamState) endTransmissionCheck(endTransmitter *records.FRecord) error {
+	if !s.validate {
+		return nil
+	}
+
+	if endTransmitter.NumberPayerRecords != s.numberPayers {
+		return utils.ErrInvalidNumberPayers
+	}
+
+	numberPayees := 0
+	if s.transmitter.TotalNumberPayees > 0 {
+		numberPayees = s.transmitter.TotalNumberPayees
+	} else if endTransmitter.NumberPayerRecords > 0 {
+		numberPayees = endTransmitter.TotalNumberPayees
+	}
+	if numberPayees != s.numberPayees {
+		return utils.ErrInvalidNumberPayees
+	}
+
+	return nil
+}
@@ -0,0 +1,115 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package file
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+
+	"gopkg.in/check.v1"
+
+	"github.com/moov-io/irs/pkg/config"
+	"github.com/moov-io/irs/pkg/records"
+	"github.com/moov-io/irs/pkg/utils"
+)
+
+func (t *FileTest) TestStreamReadWrite(c *check.C) {
+	reader := NewReader(bytes.NewReader(t.oneTransactionAscii))
+	var out bytes.Buffer
+	writer := NewWriter(&out)
+
+	types := ""
+	for {
+		r, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		c.Assert(err, check.IsNil)
+		types += r.Type()
+		c.Assert(writer.Write(r), check.IsNil)
+	}
+	c.Assert(types, check.Equals, "TABBCKF")
+	c.Assert(reader.Count(), check.Equals, 7)
+	c.Assert(writer.Count(), check.Equals, 7)
+	c.Assert(writer.Close(), check.IsNil)
+	c.Assert(out.String(), check.Equals, string(t.oneTransactionAscii))
+
+	_, err := reader.Read()
+	c.Assert(err, check.Equals, io.EOF)
+	c.Assert(writer.Write(records.NewFRecord()), check.NotNil)
+}
+
+func (t *FileTest) TestStreamWriteFile(c *check.C) {
+	f, err := CreateFile(t.oneTransactionJson)
+	c.Assert(err, check.IsNil)
+
+	var out bytes.Buffer
+	writer := NewWriter(&out)
+	c.Assert(writer.WriteFile(f), check.IsNil)
+	c.Assert(writer.Close(), check.IsNil)
+	c.Assert(out.String(), check.Equals, string(f.Ascii()))
+
+	writer = NewWriter(&out)
+	c.Assert(writer.WriteFile(nil), check.NotNil)
+	c.Assert(writer.Close(), check.Equals, utils.ErrIncompleteFile)
+}
+
+func (t *FileTest) TestStreamReadErrors(c *check.C) {
+	readAll := func(buf []byte, validate bool) error {
+		reader := NewReader(bytes.NewReader(buf))
+		reader.SetValidation(validate)
+		for {
+			_, err := reader.Read()
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	length := config.RecordLength
+	ascii := t.oneTransactionAscii
+
+	// missing end of transmission
+	err := readAll(ascii[:len(ascii)-length], true)
+	c.Assert(errors.Is(err, utils.ErrIncompleteFile), check.Equals, true)
+
+	// truncated record
+	err = readAll(ascii[:len(ascii)-1], true)
+	c.Assert(errors.Is(err, utils.ErrInvalidAscii), check.Equals, true)
+
+	// missing end of payer
+	noEndPayer := append([]byte{}, ascii[:4*length]...)
+	noEndPayer = append(noEndPayer, ascii[5*length:]...)
+	err = readAll(noEndPayer, false)
+	c.Assert(errors.Is(err, utils.ErrNonExistEndPayer), check.Equals, true)
+
+	// duplicated transmitter
+	err = readAll(append(append([]byte{}, ascii[:length]...), ascii...), false)
+	c.Assert(errors.Is(err, utils.ErrUnexpectedRecordOrder), check.Equals, true)
+
+	// wrong control total
+	wrongTotal := strings.Replace(string(ascii), "C00000002      000000000000000000", "C00000002      000000000000000001", 1)
+	c.Assert(readAll([]byte(wrongTotal), false), check.IsNil)
+	c.Assert(readAll([]byte(wrongTotal), true), check.NotNil)
+
+	// wrong number of payees
+	wrongPayees := strings.Replace(string(ascii), "C00000002", "C00000003", 1)
+	err = readAll([]byte(wrongPayees), true)
+	c.Assert(errors.Is(err, utils.ErrInvalidNumberPayees), check.Equals, true)
+
+	// wrong sequence number
+	f, err := CreateFile(t.oneTransactionJson)
+	c.Assert(err, check.IsNil)
+	instance := f.(*fileInstance)
+	instance.PaymentPersons[0].Payees[1].SetSequenceNumber(9)
+	err = readAll(f.Ascii(), true)
+	c.Assert(err, check.NotNil)
+	c.Assert(readAll(f.Ascii(), false), check.IsNil)
+}
@@ -19,13 +19,18 @@ type FileTest struct {
 	oneTransactionAscii                []byte
 	jsonWithInvalidPayment             []byte
 	jsonWithoutCRecord                 []byte
+	jsonWith1099BLosses                []byte
 	fileWithTestOptionJson             []byte
 	oneTransactionWithoutKJson         []byte
 	oneTransactionFileInvalidStateJson []byte
+	oneTransactionIrisJson             []byte
+	oneTransactionZipCodeStateJson     []byte
 	sample1099IntJson                  []byte
 	sample1099MiscJson                 []byte
 	sample1099OidJson                  []byte
 	sample1099PatrJson                 []byte
+	sample1099QaJson                   []byte
+	sample5498QaJson                   []byte
 }
 
 var _ = check.Suite(&FileTest{})
@@ -42,6 +47,12 @@ func (t *FileTest) SetUpSuite(c *check.C) {
 	t.oneTransactionFileInvalidStateJson, err = os.ReadFile(filepath.Join("..", "..", "test", "testdata", "oneTransactionFileInvalidState.json"))
 	c.Assert(err, check.IsNil)
 
+	t.oneTransactionIrisJson, err = os.ReadFile(filepath.Join("..", "..", "test", "testdata", "oneTransactionFileIris.json"))
+	c.Assert(err, check.IsNil)
+
+	t.oneTransactionZipCodeStateJson, err = os.ReadFile(filepath.Join("..", "..", "test", "testdata", "oneTransactionFileZipCodeState.json"))
+	c.Assert(err, check.IsNil)
+
 	t.oneTransactionAscii, err = os.ReadFile(filepath.Join("..", "..", "test", "testdata", "oneTransactionFile.ascii"))
 	c.Assert(err, check.IsNil)
 
@@ -51,6 +62,9 @@ func (t *FileTest) SetUpSuite(c *check.C) {
 	t.jsonWithoutCRecord, err = os.ReadFile(filepath.Join("..", "..", "test", "testdata", "fileWithoutCRecord.json"))
 	c.Assert(err, check.IsNil)
 
+	t.jsonWith1099BLosses, err = os.ReadFile(filepath.Join("..", "..", "test", "testdata", "fileWith1099BLosses.json"))
+	c.Assert(err, check.IsNil)
+
 	t.fileWithTestOptionJson, err = os.ReadFile(filepath.Join("..", "..", "test", "testdata", "fileWithTestOption.json"))
 	c.Assert(err, check.IsNil)
 
@@ -65,4 +79,10 @@ func (t *FileTest) SetUpSuite(c *check.C) {
 
 	t.sample1099PatrJson, err = os.ReadFile(filepath.Join("..", "..", "docs", "examples", "1099patr.json"))
 	c.Assert(err, check.IsNil)
+
+	t.sample1099QaJson, err = os.ReadFile(filepath.Join("..", "..", "docs", "examples", "1099qa.json"))
+	c.Assert(err, check.IsNil)
+
+	t.sample5498QaJson, err = os.ReadFile(filepath.Join("..", "..", "docs", "examples", "5498qa.json"))
+	c.Assert(err, check.IsNil)
 }
@@ -0,0 +1,40 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package file
+
+import (
+	"github.com/moov-io/irs/pkg/utils"
+)
+
+// FillTypesOfTIN sets blank types of TIN of payee “B” records to the types inferred from their TIN and name
+func FillTypesOfTIN(f File) {
+	if f == nil {
+		return
+	}
+	for _, person := range f.Payers() {
+		person.fillTypesOfTIN()
+	}
+}
+
+func (p *PaymentPerson) fillTypesOfTIN() {
+	for _, payee := range p.PayeeRecords() {
+		if len(payee.TypeOfTIN) == 0 {
+			payee.TypeOfTIN = payee.DerivedTypeOfTIN()
+		}
+	}
+}
+
+// reportTINs reports blank types of TIN of payees as warnings
+//
+// Structurally invalid TINs are reported by the ValidateTIN methods of the records.
+func (f *fileInstance) reportTINs(report *ValidationReport) {
+	for index, person := range f.PaymentPersons {
+		for _, payee := range person.PayeeRecords() {
+			if len(payee.TypeOfTIN) == 0 && len(payee.TIN) > 0 {
+				report.warn(payee, index+1, "TypeOfTIN", utils.NewErrTypeOfTINInferred(payee.DerivedTypeOfTIN()))
+			}
+		}
+	}
+}
@@ -0,0 +1,113 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package file
+
+import (
+	"bytes"
+	"errors"
+	"io"
+
+	"gopkg.in/check.v1"
+
+	"github.com/moov-io/irs/pkg/config"
+	"github.com/moov-io/irs/pkg/utils"
+)
+
+func (t *FileTest) TestFillTypesOfTIN(c *check.C) {
+	f, err := CreateFile(t.oneTransactionJson)
+	c.Assert(err, check.IsNil)
+	payee := f.Payers()[0].PayeeRecords()[0]
+	payee.TIN = "962701234"
+	payee.TypeOfTIN = ""
+	FillTypesOfTIN(f)
+	c.Assert(payee.TypeOfTIN, check.Equals, config.TinType2)
+
+	payee.TIN = "123001234"
+	payee.TypeOfTIN = ""
+	c.Assert(f.Finalize(), check.IsNil)
+	c.Assert(payee.TypeOfTIN, check.Equals, config.TinType1)
+
+	FillTypesOfTIN(nil)
+}
+
+func (t *FileTest) TestReportTINs(c *check.C) {
+	f, err := CreateFile(t.oneTransactionJson)
+	c.Assert(err, check.IsNil)
+	matchZipCodes(f)
+	report := f.ValidateAll()
+	c.Assert(report.Count(SeverityWarning), check.Equals, 0)
+
+	// impossible TINs are errors
+	payees := f.Payers()[0].PayeeRecords()
+	payees[0].TIN = "666123456"
+	payees[0].TypeOfTIN = config.TinType2
+	c.Assert(utils.ErrorCode(f.Validate()), check.Equals, utils.CodeInvalidTIN)
+	report = f.ValidateAll()
+	c.Assert(report.Valid(), check.Equals, false)
+	c.Assert(report.Count(SeverityError), check.Equals, 1)
+	c.Assert(report.Entries[0].RecordType, check.Equals, config.BRecordType)
+	c.Assert(report.Entries[0].FieldName, check.Equals, "TIN")
+	c.Assert(report.Entries[0].Code, check.Equals, utils.CodeInvalidTIN)
+	c.Assert(report.Entries[0].StartPosition, check.Equals, 12)
+
+	// an ITIN with the EIN type of TIN is a warning, which fire-production rejects
+	payees[0].TIN = "972701234"
+	payees[0].TypeOfTIN = config.TinType1
+	c.Assert(f.Validate(), check.IsNil)
+	report = f.ValidateAll()
+	c.Assert(report.Valid(), check.Equals, true)
+	c.Assert(report.Count(SeverityWarning), check.Equals, 1)
+	c.Assert(report.Entries[0].Code, check.Equals, utils.CodeTINTypeMismatch)
+	report = f.ValidateProfile(Profiles[ProfileFireProduction])
+	c.Assert(report.Valid(), check.Equals, false)
+	c.Assert(report.Entries[0].Code, check.Equals, utils.CodeTINTypeMismatch)
+	c.Assert(report.Entries[0].Severity, check.Equals, SeverityError)
+
+	payees[0].TypeOfTIN = ""
+	report = f.ValidateAll()
+	c.Assert(report.Valid(), check.Equals, true)
+	c.Assert(report.Count(SeverityWarning), check.Equals, 1)
+	c.Assert(report.Entries[0].FieldName, check.Equals, "TypeOfTIN")
+	c.Assert(report.Entries[0].Code, check.Equals, utils.CodeTypeOfTINInferred)
+	c.Assert(report.Entries[0].Message, check.Equals, "has blank type of tin, inferred 2")
+
+	f.Payers()[0].PayerRecord().TIN = "000000001"
+	report = f.ValidateAll()
+	c.Assert(report.Count(SeverityError), check.Equals, 1)
+	c.Assert(report.Entries[0].RecordType, check.Equals, config.ARecordType)
+	c.Assert(report.Entries[0].Code, check.Equals, utils.CodeInvalidTIN)
+}
+
+func (t *FileTest) TestStreamReportTINs(c *check.C) {
+	f, err := CreateFile(t.oneTransactionJson)
+	c.Assert(err, check.IsNil)
+	matchZipCodes(f)
+	payee := f.Payers()[0].PayeeRecords()[0]
+	payee.TIN, payee.TypeOfTIN = "972701234", config.TinType1
+
+	var out bytes.Buffer
+	writer := NewWriter(&out)
+	c.Assert(writer.WriteFile(f), check.IsNil)
+	c.Assert(writer.Close(), check.IsNil)
+	report := writer.Report()
+	c.Assert(report.Valid(), check.Equals, true)
+	c.Assert(report.Count(SeverityWarning), check.Equals, 1)
+	c.Assert(report.Entries[0].RecordType, check.Equals, config.BRecordType)
+	c.Assert(report.Entries[0].Code, check.Equals, utils.CodeTINTypeMismatch)
+
+	reader := NewReader(bytes.NewReader(out.Bytes()))
+	for {
+		_, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		c.Assert(err, check.IsNil)
+	}
+	c.Assert(reader.Report().Entries, check.DeepEquals, report.Entries)
+
+	// impossible TINs fail the stream
+	f.TransmitterRecord().TIN = "000123456"
+	c.Assert(utils.ErrorCode(NewWriter(&bytes.Buffer{}).WriteFile(f)), check.Equals, utils.CodeInvalidTIN)
+}
@@ -0,0 +1,61 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package file
+
+import (
+	"github.com/moov-io/irs/pkg/config"
+	"github.com/moov-io/irs/pkg/utils"
+)
+
+// payeeTotals sums payment amounts of payees by amount code, losses and gains
+// are kept apart since “K” records may total any subset of the payees
+type payeeTotals struct {
+	typeOfReturn string
+	losses       map[string]int
+	gains        map[string]int
+}
+
+func newPayeeTotals(typeOfReturn string) *payeeTotals {
+	return &payeeTotals{
+		typeOfReturn: typeOfReturn,
+		losses:       make(map[string]int),
+		gains:        make(map[string]int),
+	}
+}
+
+func (t *payeeTotals) add(code string, amount int) {
+	if amount < 0 {
+		t.losses[code] += amount
+	} else {
+		t.gains[code] += amount
+	}
+}
+
+// endPayerTotalError checks the control total of the “C” record, which must equal ΣB
+func (t *payeeTotals) endPayerTotalError(code string, control int) error {
+	if err := t.negativeTotalError(code, control); err != nil {
+		return err
+	}
+	if control != t.losses[code]+t.gains[code] {
+		return utils.ErrInvalidTotalAmounts
+	}
+	return nil
+}
+
+// stateTotalError checks ΣK, which must be within the losses and gains of the payees
+func (t *payeeTotals) stateTotalError(code string, total int) error {
+	if total < t.losses[code] || total > t.gains[code] {
+		return utils.ErrInvalidTotalAmounts
+	}
+	return nil
+}
+
+// negativeTotalError checks the control total of a “C” or “K” record, only losses may be negative
+func (t *payeeTotals) negativeTotalError(code string, total int) error {
+	if total < 0 && !config.LossAmountCodes[t.typeOfReturn][code] {
+		return utils.NewErrNegativeAmount(t.typeOfReturn, code)
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package file
+
+import (
+	"bytes"
+	"errors"
+	"io"
+
+	"gopkg.in/check.v1"
+
+	"github.com/moov-io/irs/pkg/records"
+	"github.com/moov-io/irs/pkg/utils"
+)
+
+// totalsErrorCodes returns the error codes of Validate, ValidateAll and the streaming Writer and Reader
+func totalsErrorCodes(c *check.C, f File) (string, []string, string, string) {
+	validate := utils.ErrorCode(f.Validate())
+
+	var report []string
+	for _, entry := range f.ValidateAll().Entries {
+		if entry.Severity == SeverityError {
+			report = append(report, entry.Code)
+		}
+	}
+
+	var out bytes.Buffer
+	write := utils.ErrorCode(NewWriter(&out).WriteFile(f))
+
+	writer := NewWriter(&out)
+	writer.SetValidation(false)
+	out.Reset()
+	c.Assert(writer.WriteFile(f), check.IsNil)
+	c.Assert(writer.Close(), check.IsNil)
+	reader := NewReader(&out)
+	read := ""
+	for {
+		_, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			read = utils.ErrorCode(err)
+			break
+		}
+	}
+	return validate, report, write, read
+}
+
+func (t *FileTest) TestTotalsWithLosses(c *check.C) {
+	f, err := CreateFile(t.jsonWith1099BLosses)
+	c.Assert(err, check.IsNil)
+	validate, report, write, read := totalsErrorCodes(c, f)
+	c.Assert(validate, check.Equals, "")
+	c.Assert(report, check.HasLen, 0)
+	c.Assert(write, check.Equals, "")
+	c.Assert(read, check.Equals, "")
+
+	// state totals below the losses of the payees
+	person := f.Payers()[0]
+	kRecord := person.States[0].(*records.KRecord)
+	kRecord.ControlTotal9 = -60000
+	validate, report, write, read = totalsErrorCodes(c, f)
+	c.Assert(validate, check.Equals, utils.CodeInvalidTotalAmounts)
+	c.Assert(report, check.DeepEquals, []string{utils.CodeInvalidTotalAmounts})
+	c.Assert(write, check.Equals, utils.CodeInvalidTotalAmounts)
+	c.Assert(read, check.Equals, utils.CodeInvalidTotalAmounts)
+
+	// state totals above the gains of the payees
+	kRecord.ControlTotal9 = 30000
+	validate, report, write, read = totalsErrorCodes(c, f)
+	c.Assert(validate, check.Equals, utils.CodeInvalidTotalAmounts)
+	c.Assert(report, check.DeepEquals, []string{utils.CodeInvalidTotalAmounts})
+	c.Assert(write, check.Equals, utils.CodeInvalidTotalAmounts)
+	c.Assert(read, check.Equals, utils.CodeInvalidTotalAmounts)
+
+	// control totals must equal the sum of losses and gains
+	kRecord.ControlTotal9 = -50000
+	cRecord := person.EndPayer.(*records.CRecord)
+	cRecord.ControlTotal9 = -50000
+	validate, report, write, read = totalsErrorCodes(c, f)
+	c.Assert(validate, check.Equals, utils.CodeInvalidTotalAmounts)
+	c.Assert(report, check.DeepEquals, []string{utils.CodeInvalidTotalAmounts})
+	c.Assert(write, check.Equals, utils.CodeInvalidTotalAmounts)
+	c.Assert(read, check.Equals, utils.CodeInvalidTotalAmounts)
+}
+
+func (t *FileTest) TestTotalsWithNegativeAmounts(c *check.C) {
+	// 1099-MISC doesn't allow negative control totals
+	f, err := CreateFile(t.oneTransactionJson)
+	c.Assert(err, check.IsNil)
+	kRecord := f.Payers()[0].States[0].(*records.KRecord)
+	kRecord.ControlTotal7 = -1
+	validate, report, write, read := totalsErrorCodes(c, f)
+	c.Assert(validate, check.Equals, utils.CodeNegativeAmount)
+	c.Assert(report, check.DeepEquals, []string{utils.CodeNegativeAmount, utils.CodeInvalidTotalAmounts})
+	c.Assert(write, check.Equals, utils.CodeNegativeAmount)
+	c.Assert(read, check.Equals, utils.CodeNegativeAmount)
+
+	kRecord.ControlTotal7 = 1400
+	cRecord := f.Payers()[0].EndPayer.(*records.CRecord)
+	cRecord.ControlTotal7 = -1400
+	validate, report, write, read = totalsErrorCodes(c, f)
+	c.Assert(validate, check.Equals, utils.CodeNegativeAmount)
+	c.Assert(report, check.DeepEquals, []string{utils.CodeNegativeAmount})
+	c.Assert(write, check.Equals, utils.CodeNegativeAmount)
+	c.Assert(read, check.Equals, utils.CodeNegativeAmount)
+}
@@ -0,0 +1,124 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package file
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+
+	"github.com/moov-io/irs/pkg/config"
+	"github.com/moov-io/irs/pkg/records"
+	"github.com/moov-io/irs/pkg/utils"
+)
+
+// Writer writes records of a fire ascii file one at a time.
+//
+// Records are checked with the same streaming rules as Reader before they
+// are written.
+type Writer struct {
+	writer *bufio.Writer
+	state  *streamState
+	// number of records written
+	count int
+}
+
+// NewWriter returns a new Writer that writes to w.
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{
+		writer: bufio.NewWriterSize(w, config.RecordLength*64),
+		state:  newStreamState(),
+	}
+}
+
+// SetValidation enables or disables the checks performed while writing.
+// Record order is always enforced.
+func (w *Writer) SetValidation(enabled bool) {
+	w.state.validate = enabled
+}
+
+// Write writes a single record
+func (w *Writer) Write(r records.Record) error {
+	if r == nil {
+		return utils.ErrInvalidFile
+	}
+	if w.state.finished() {
+		return w.wrap(utils.ErrUnexpectedRecordOrder)
+	}
+
+	w.count++
+	w.state.setTaxYear(r)
+	if err := w.state.check(r); err != nil {
+		return w.wrap(err)
+	}
+
+	_, err := w.writer.Write(r.Ascii())
+	return err
+}
+
+// WriteFile writes all records of the file
+func (w *Writer) WriteFile(f File) error {
+	instance, ok := f.(*fileInstance)
+	if !ok || instance == nil {
+		return utils.ErrInvalidFile
+	}
+
+	if err := w.Write(instance.Transmitter); err != nil {
+		return err
+	}
+	for _, person := range instance.PaymentPersons {
+		if err := person.validateRecords(); err != nil {
+			return err
+		}
+		if err := w.Write(person.Payer); err != nil {
+			return err
+		}
+		for _, payee := range person.Payees {
+			if err := w.Write(payee); err != nil {
+				return err
+			}
+		}
+		if err := w.Write(person.EndPayer); err != nil {
+			return err
+		}
+		for _, state := range person.States {
+			if err := w.Write(state); err != nil {
+				return err
+			}
+		}
+	}
+	return w.Write(instance.EndTransmitter)
+}
+
+// Report returns the warnings of the records written so far, such as ITINs with the EIN type of TIN.
+// Errors are returned by Write.
+func (w *Writer) Report() *ValidationReport {
+	return w.state.report
+}
+
+// Count returns the number of records written
+func (w *Writer) Count() int {
+	return w.count
+}
+
+// Flush writes any buffered data to the underlying io.Writer
+func (w *Writer) Flush() error {
+	return w.writer.Flush()
+}
+
+// Close flushes buffered data and verifies that the end of transmission “F” record was written
+func (w *Writer) Close() error {
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	if !w.state.finished() {
+		return utils.ErrIncompleteFile
+	}
+	return nil
+}
+
+func (w *Writer) wrap(err error) error {
+	return fmt.Errorf("record %d: %w", w.count, err)
+}
@@ -0,0 +1,164 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package pdf_generator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/moov-io/irs/pkg/utils"
+)
+
+const (
+	// 1099-DA Copy B
+	PdfDaCopyB = "1099da_copy_b"
+)
+
+// Pdf struct for 1099-DA
+type Pdf1099Da struct {
+	Type                   string
+	Corrected              bool
+	Fatca                  bool
+	PayerInfo              string
+	PayerTin               string
+	RecipientTin           string
+	RecipientName          string
+	Street                 string
+	City                   string
+	AccountNumber          string
+	DigitalAssetCode       string
+	DigitalAssetName       string
+	NumberUnits            string
+	DateAcquired           string
+	DateSold               string
+	Proceeds               int
+	Cost                   int
+	MarketDiscount         int
+	WashSale               int
+	Federal                int
+	ShortTerm              bool
+	LongTerm               bool
+	Ordinary               bool
+	Collectibles           bool
+	Qof                    bool
+	GrossProceeds          bool
+	GrossProceedsLessCosts bool
+	LossNotAllowed         bool
+	Noncovered             bool
+	Form8949               string
+	StateNo1               string
+	StateTax1              int
+	tempDir                string
+}
+
+var fdf1099DaPatternsCopyB = map[string]string{
+	"Corrected":              "/Off#?#/T (c1_1[0])",
+	"Fatca":                  "/Off#?#/T (c1_2[0])",
+	"ShortTerm":              "/Off#?#/T (c1_3[0])",
+	"LongTerm":               "/Off#?#/T (c1_4[0])",
+	"Ordinary":               "/Off#?#/T (c1_5[0])",
+	"Collectibles":           "/Off#?#/T (c1_6[0])",
+	"Qof":                    "/Off#?#/T (c1_7[0])",
+	"GrossProceeds":          "/Off#?#/T (c1_8[0])",
+	"GrossProceedsLessCosts": "/Off#?#/T (c1_9[0])",
+	"LossNotAllowed":         "/Off#?#/T (c1_10[0])",
+	"Noncovered":             "/Off#?#/T (c1_11[0])",
+	"PayerInfo":              "PAYER Information",
+	"PayerTin":               "PAYER TIN",
+	"RecipientTin":           "RECIP TIN",
+	"RecipientName":          "RECIPIENT Name",
+	"Street":                 "Street Address",
+	"City":                   "ZIP, Postal Code",
+	"AccountNumber":          "Account Number",
+	"DigitalAssetCode":       "Asset Code",
+	"DigitalAssetName":       "Asset Name",
+	"NumberUnits":            "Number Units",
+	"DateAcquired":           "Date Acquired",
+	"DateSold":               "Date Sold",
+	"Proceeds":               "Proceeds",
+	"Cost":                   "Cost Basis",
+	"MarketDiscount":         "Market Discount",
+	"WashSale":               "Wash Sale",
+	"Federal":                "Federal Income",
+	"Form8949":               "Form 8949",
+	"StateNo1":               "State no1",
+	"StateTax1":              "State tax1",
+}
+
+func (p *Pdf1099Da) getSpecFdf() ([]byte, error) {
+	if p.Type != PdfDaCopyB {
+		return nil, utils.ErrUnknownPdfTemplate
+	}
+	return os.ReadFile(filepath.Join(basePath, p.Type, specFDF))
+}
+
+func (p *Pdf1099Da) getTemplateFdf() ([]byte, error) {
+	if p.Type != PdfDaCopyB {
+		return nil, utils.ErrUnknownPdfTemplate
+	}
+	return os.ReadFile(filepath.Join(basePath, p.Type, templateFDF))
+}
+
+func (p *Pdf1099Da) getTemplateFile() (*string, error) {
+	if p.Type != PdfDaCopyB {
+		return nil, utils.ErrUnknownPdfTemplate
+	}
+	filePath := filepath.Join(basePath, p.Type, templatePDF)
+	return &filePath, nil
+}
+
+func (p *Pdf1099Da) generateFDF(fileName string) ([]byte, error) {
+	buf, err := p.getSpecFdf()
+	if err != nil {
+		return nil, err
+	}
+	newFdf := string(buf)
+
+	fields := reflect.ValueOf(p).Elem()
+	for i := 0; i < fields.NumField(); i++ {
+		fieldName := fields.Type().Field(i).Name
+		pattern, ok := fdf1099DaPatternsCopyB[fieldName]
+		if !ok {
+			continue
+		}
+		field := fields.Field(i)
+		switch field.Kind() {
+		case reflect.String:
+			newFdf = strings.ReplaceAll(newFdf, pattern, field.String())
+		case reflect.Bool:
+			// the check boxes of the template are on in state 1
+			if field.Bool() {
+				newFdf = strings.ReplaceAll(newFdf, pattern, strings.ReplaceAll(pattern, "Off", "1"))
+			}
+		case reflect.Int:
+			value := ""
+			if field.Int() > 0 {
+				value = fmt.Sprintf("%.2f", float64(field.Int())/100)
+			}
+			newFdf = strings.ReplaceAll(newFdf, pattern, value)
+		}
+	}
+	newFdf = strings.ReplaceAll(newFdf, "#?#", "\n")
+
+	if fileName != "" {
+		err = os.WriteFile(fileName, []byte(newFdf), 0o600)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return []byte(newFdf), nil
+}
+
+// Generate pdf file form Pdf1099Da struct using pdftk
+func GeneratePdf1099Da(p *Pdf1099Da) ([]byte, error) {
+	if p == nil {
+		return nil, utils.ErrInvalidFile
+	}
+	return generate(p, &p.tempDir)
+}
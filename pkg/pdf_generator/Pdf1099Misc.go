@@ -254,17 +254,28 @@ func (p *Pdf1099Misc) generateFDF(fileName string) ([]byte, error) {
 }
 
 func (p *Pdf1099Misc) generatePDF(fdfFile string) ([]byte, error) {
+	return fillForm(p, fdfFile, p.tempDir)
+}
+
+// pdfForm is a recipient copy whose fdf fills a pdf template
+type pdfForm interface {
+	getTemplateFile() (*string, error)
+	generateFDF(fileName string) ([]byte, error)
+}
+
+// fillForm fills the pdf template of the form with the fdf file using pdftk
+func fillForm(form pdfForm, fdfFile, tempDir string) ([]byte, error) {
 	execFile, err := exec.LookPath(pdfConverter)
 	if err != nil {
 		return nil, err
 	}
 
-	template, err := p.getTemplateFile()
+	template, err := form.getTemplateFile()
 	if err != nil {
 		return nil, err
 	}
 
-	result := filepath.Join(p.tempDir, resultPDF)
+	result := filepath.Join(tempDir, resultPDF)
 	cmd := exec.Command(execFile, *template, convertParam1, fdfFile, convertParam2, result)
 	err = cmd.Run()
 	if err != nil {
@@ -274,38 +285,42 @@ func (p *Pdf1099Misc) generatePDF(fdfFile string) ([]byte, error) {
 	return os.ReadFile(result)
 }
 
-// Generate pdf file form Pdf1099Misc struct using pdftk
-func GeneratePdf(p *Pdf1099Misc) ([]byte, error) {
-	if p == nil {
-		return nil, utils.ErrInvalidFile
-	}
-
+// generate writes the fdf of the form in a temporary directory and fills the pdf template with it
+func generate(form pdfForm, tempDir *string) ([]byte, error) {
 	randStr, err := utils.RandAlphanumericString(40)
 	if err != nil {
 		return nil, err
 	}
 
-	p.tempDir = filepath.Join(basePath, "."+randStr)
-	err = os.Mkdir(p.tempDir, 0o700)
+	*tempDir = filepath.Join(basePath, "."+randStr)
+	err = os.Mkdir(*tempDir, 0o700)
 	if err != nil {
 		return nil, err
 	}
 
-	fdfFile := filepath.Join(p.tempDir, templateFDF)
-	_, err = p.generateFDF(fdfFile)
+	fdfFile := filepath.Join(*tempDir, templateFDF)
+	_, err = form.generateFDF(fdfFile)
 	if err != nil {
-		return returnWithRemoveTmp(p.tempDir, err)
+		return returnWithRemoveTmp(*tempDir, err)
 	}
 
-	buf, err := p.generatePDF(fdfFile)
+	buf, err := fillForm(form, fdfFile, *tempDir)
 	if err != nil {
-		return returnWithRemoveTmp(p.tempDir, err)
+		return returnWithRemoveTmp(*tempDir, err)
 	}
 
-	err = os.RemoveAll(p.tempDir)
+	err = os.RemoveAll(*tempDir)
 	return buf, err
 }
 
+// Generate pdf file form Pdf1099Misc struct using pdftk
+func GeneratePdf(p *Pdf1099Misc) ([]byte, error) {
+	if p == nil {
+		return nil, utils.ErrInvalidFile
+	}
+	return generate(p, &p.tempDir)
+}
+
 // Generate pdf file form Pdf1099Misc struct using pdftk
 func MergePdfs(files [][]byte) ([]byte, error) {
 	randStr, err := utils.RandAlphanumericString(40)
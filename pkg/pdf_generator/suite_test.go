@@ -86,6 +86,37 @@ func (t *PdfTest) TestPdfWithNecCopyC(c *check.C) {
 	c.Assert(err, check.IsNil)
 }
 
+func (t *PdfTest) TestPdfWithDaCopyB(c *check.C) {
+	pdf := Pdf1099Da{Type: PdfDaCopyB}
+	templateFdf, err := pdf.getTemplateFdf()
+	c.Assert(err, check.IsNil)
+	newFdf, err := pdf.generateFDF("")
+	c.Assert(err, check.IsNil)
+	c.Assert(strings.ReplaceAll(string(templateFdf), "\r", ""),
+		check.Equals, strings.ReplaceAll(string(newFdf), "\r", ""))
+
+	pdf = Pdf1099Da{Type: PdfDaCopyB, DigitalAssetName: "BITCOIN", Proceeds: 123456, LongTerm: true}
+	newFdf, err = pdf.generateFDF("")
+	c.Assert(err, check.IsNil)
+	c.Assert(strings.Contains(string(newFdf), "/V (BITCOIN)\n/T (f1_9[0])"), check.Equals, true)
+	c.Assert(strings.Contains(string(newFdf), "/V (1234.56)\n/T (f1_13[0])"), check.Equals, true)
+	c.Assert(strings.Contains(string(newFdf), "/V /1\n/T (c1_4[0])"), check.Equals, true)
+	c.Assert(strings.Contains(string(newFdf), "/V /Off\n/T (c1_3[0])"), check.Equals, true)
+	_, err = GeneratePdf1099Da(&pdf)
+	c.Assert(err, check.IsNil)
+
+	_, err = GeneratePdf1099Da(nil)
+	c.Assert(err, check.NotNil)
+	pdf = Pdf1099Da{Type: PdfMscCopyB}
+	_, err = pdf.generateFDF("")
+	c.Assert(err, check.NotNil)
+	_, err = pdf.getTemplateFdf()
+	c.Assert(err, check.NotNil)
+	_, err = GeneratePdf1099Da(&pdf)
+	c.Assert(err, check.NotNil)
+	os.RemoveAll(pdf.tempDir)
+}
+
 func (t *PdfTest) TestPdfWithUnknownTemplate(c *check.C) {
 	_, err := GeneratePdf(nil)
 	c.Assert(err, check.NotNil)
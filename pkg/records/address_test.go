@@ -0,0 +1,111 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package records
+
+import (
+	"gopkg.in/check.v1"
+
+	"github.com/moov-io/irs/pkg/config"
+	"github.com/moov-io/irs/pkg/utils"
+)
+
+func (t *RecordTest) TestNormalizeText(c *check.C) {
+	c.Assert(utils.NormalizeText("Müller Straße 5"), check.Equals, "MULLER STRASSE 5")
+	c.Assert(utils.NormalizeText("  Ærø  Søndergade "), check.Equals, "AERO SONDERGADE")
+	c.Assert(utils.NormalizeText("São Paulo – Brasil"), check.Equals, "SAO PAULO - BRASIL")
+	c.Assert(utils.NormalizeText("O’Brien, Apt #3"), check.Equals, "O'BRIEN, APT #3")
+	c.Assert(utils.NormalizeText("東京 Tokyo"), check.Equals, "TOKYO")
+}
+
+func (t *RecordTest) TestStateAbbreviation(c *check.C) {
+	c.Assert(utils.StateAbbreviation("ca"), check.Equals, "CA")
+	c.Assert(utils.StateAbbreviation("New York"), check.Equals, "NY")
+	c.Assert(utils.StateAbbreviation("kansas"), check.Equals, "KS")
+	c.Assert(utils.StateAbbreviation("U.S. Virgin Islands"), check.Equals, "VI")
+	c.Assert(utils.StateAbbreviation("Northern Mariana Islands"), check.Equals, "MP")
+	c.Assert(utils.StateAbbreviation("Armed Forces Europe"), check.Equals, "AE")
+	c.Assert(utils.StateAbbreviation("Atlantis"), check.Equals, "")
+}
+
+func (t *RecordTest) TestNormalizeAddress(c *check.C) {
+	address, err := utils.NormalizeAddress(utils.Address{Street: "12 Rue Cléry", City: "San José", State: "California", ZipCode: "95112-1234"})
+	c.Assert(err, check.IsNil)
+	c.Assert(address, check.Equals, utils.Address{Street: "12 RUE CLERY", City: "SAN JOSE", State: "CA", ZipCode: "951121234"})
+
+	// military addresses
+	address, err = utils.NormalizeAddress(utils.Address{Street: "Unit 2050 Box 4190", City: "a.p.o.", State: "Armed Forces Europe", ZipCode: "09096"})
+	c.Assert(err, check.IsNil)
+	c.Assert(address.City, check.Equals, "APO")
+	c.Assert(address.State, check.Equals, "AE")
+	_, err = utils.NormalizeAddress(utils.Address{City: "FPO", State: "CA", ZipCode: "96349"})
+	c.Assert(err, check.Equals, utils.ErrMilitaryAddress)
+	_, err = utils.NormalizeAddress(utils.Address{City: "San Diego", State: "AP", ZipCode: "96349"})
+	c.Assert(err, check.Equals, utils.ErrMilitaryAddress)
+
+	// invalid state and zip code
+	_, err = utils.NormalizeAddress(utils.Address{City: "Moon", State: "Atlantis"})
+	c.Assert(err, check.ErrorMatches, "is an invalid value of state")
+	_, err = utils.NormalizeAddress(utils.Address{City: "Moon", State: "CA", ZipCode: "9511"})
+	c.Assert(err, check.ErrorMatches, "is an invalid value of zip code")
+
+	// foreign addresses
+	address, err = utils.NormalizeAddress(utils.Address{Street: "1 Yonge St", City: "Toronto", State: "Ontario", ZipCode: "M5E 1E5", Country: "Canada", Foreign: true})
+	c.Assert(err, check.IsNil)
+	c.Assert(address, check.Equals, utils.Address{Street: "1 YONGE ST", City: "TORONTO ONTARIO M5E 1E5 CANADA", Foreign: true})
+	address, err = utils.NormalizeAddress(utils.Address{City: "Arles", State: "Provence-Alpes-Côte d'Azur", ZipCode: "13200", Country: "France", Foreign: true})
+	c.Assert(err, check.IsNil)
+	c.Assert(address.City, check.Equals, "ARLES PROVENCE-ALPES-COTE D'AZUR 13200 F")
+	c.Assert(address.State, check.Equals, "RA")
+	c.Assert(address.ZipCode, check.Equals, "NCE")
+	_, err = utils.NormalizeAddress(utils.Address{City: "Llanfairpwllgwyngyllgogerychwyrndrobwllllantysiliogogogoch", State: "Anglesey", ZipCode: "LL61 5UJ", Foreign: true})
+	c.Assert(err, check.ErrorMatches, "is an invalid value of foreign address")
+
+	// laid out foreign addresses keep their layout
+	again, err := utils.NormalizeAddress(utils.Address{City: address.City, State: address.State, ZipCode: address.ZipCode, Foreign: true})
+	c.Assert(err, check.IsNil)
+	c.Assert(again, check.Equals, utils.Address{City: address.City, State: address.State, ZipCode: address.ZipCode, Foreign: true})
+}
+
+func (t *RecordTest) TestValidateZipCodeState(c *check.C) {
+	c.Assert(utils.ValidateZipCodeState("10001", "NY"), check.IsNil)
+	c.Assert(utils.ValidateZipCodeState("063901234", "NY"), check.IsNil)
+	c.Assert(utils.ValidateZipCodeState("09096", "AE"), check.IsNil)
+	c.Assert(utils.ValidateZipCodeState("", "NY"), check.IsNil)
+	c.Assert(utils.ValidateZipCodeState("10001", "XX"), check.IsNil)
+	err := utils.ValidateZipCodeState("22222", "CA")
+	c.Assert(err, check.ErrorMatches, "has zip code 22222, which isn't in state CA")
+	c.Assert(utils.ErrorCode(err), check.Equals, utils.CodeZipCodeState)
+}
+
+func (t *RecordTest) TestRecordNormalizeAddress(c *check.C) {
+	payee := &BRecord{PayeeMailingAddress: "5678 Industry Place", PayeeCity: "Moon", PayeeState: "california", PayeeZipCode: "92222"}
+	c.Assert(payee.NormalizeAddress(), check.IsNil)
+	c.Assert(payee.PayeeMailingAddress, check.Equals, "5678 INDUSTRY PLACE")
+	c.Assert(payee.PayeeCity, check.Equals, "MOON")
+	c.Assert(payee.PayeeState, check.Equals, "CA")
+	c.Assert(payee.ValidatePayeeState(), check.IsNil)
+
+	payee.PayeeState = "Atlantis"
+	c.Assert(payee.NormalizeAddress(), check.NotNil)
+	c.Assert(payee.PayeeState, check.Equals, "Atlantis")
+
+	payee = &BRecord{ForeignCountryIndicator: config.ForeignCountryIndicator, PayeeCity: "Toronto", PayeeState: "ON", PayeeZipCode: "M5E 1E5"}
+	c.Assert(payee.NormalizeAddress(), check.IsNil)
+	c.Assert(payee.PayeeCity, check.Equals, "TORONTO ON M5E 1E5")
+	c.Assert(payee.ValidatePayeeState(), check.IsNil)
+	c.Assert(payee.ValidatePayeeZipCode(), check.IsNil)
+
+	payee = &BRecord{PayeeCity: "APO", PayeeState: "AE", PayeeZipCode: "09096"}
+	c.Assert(payee.ValidatePayeeState(), check.IsNil)
+
+	payer := &ARecord{PayerShippingAddress: "123 asdf street", PayerCity: "new york", PayerState: "New York", PayerZipCode: "10001"}
+	c.Assert(payer.NormalizeAddress(), check.IsNil)
+	c.Assert(payer.PayerState, check.Equals, "NY")
+	c.Assert(payer.PayerCity, check.Equals, "NEW YORK")
+
+	payer = &ARecord{ForeignEntityIndicator: config.ForeignEntityIndicator, PayerState: "", PayerZipCode: "SW1A 1AA"}
+	c.Assert(payer.ValidatePayerState(), check.IsNil)
+	c.Assert(payer.ValidatePayerZipCode(), check.IsNil)
+}
@@ -0,0 +1,131 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package records
+
+import (
+	"errors"
+
+	"gopkg.in/check.v1"
+
+	"github.com/moov-io/irs/pkg/config"
+	"github.com/moov-io/irs/pkg/utils"
+)
+
+func (t *RecordTest) TestParseDollars(c *check.C) {
+	amounts := map[string]int{
+		"1234.56":  123456,
+		"1234.5":   123450,
+		"1234":     123400,
+		"1234.":    123400,
+		".07":      7,
+		"-0.01":    -1,
+		"+12.00":   1200,
+		"00012.30": 1230,
+	}
+	for value, cents := range amounts {
+		amount, err := utils.ParseDollars(value)
+		c.Assert(err, check.IsNil)
+		c.Assert(amount, check.Equals, cents)
+	}
+
+	for _, value := range []string{"", ".", "-", "1.234", "1.2.3", "1,234.56", "$12", "1e3", "12 ", "- 1", "99999999999999999999"} {
+		_, err := utils.ParseDollars(value)
+		c.Assert(errors.Is(err, utils.ErrInvalidDollarAmount), check.Equals, true, check.Commentf(value))
+		c.Assert(utils.ErrorCode(err), check.Equals, utils.CodeInvalidFormat)
+	}
+}
+
+func (t *RecordTest) TestFormatDollars(c *check.C) {
+	c.Assert(utils.FormatDollars(123456), check.Equals, "1234.56")
+	c.Assert(utils.FormatDollars(7), check.Equals, "0.07")
+	c.Assert(utils.FormatDollars(0), check.Equals, "0.00")
+	c.Assert(utils.FormatDollars(-1250), check.Equals, "-12.50")
+}
+
+func (t *RecordTest) TestParseSignedNumeric(c *check.C) {
+	amounts := map[string]int{
+		"000000012345": 12345,
+		"+00000012345": 12345,
+		"-00000012345": -12345,
+		"00000001234N": -12345,
+		"00000001234}": -12340,
+		"00000001234R": -12349,
+		"            ": 0,
+	}
+	for data, amount := range amounts {
+		value, err := utils.ParseSignedNumeric(data)
+		c.Assert(err, check.IsNil)
+		c.Assert(value, check.Equals, amount, check.Commentf(data))
+	}
+
+	for _, data := range []string{"0000-0012345", "-0000001234N", "+-0000012345", "00000001234A", "-           "} {
+		_, err := utils.ParseSignedNumeric(data)
+		c.Assert(err, check.Equals, utils.ErrNumeric, check.Commentf(data))
+	}
+
+	c.Assert(utils.FormatSignedNumeric(12345, 12), check.Equals, "000000012345")
+	c.Assert(utils.FormatSignedNumeric(-12345, 12), check.Equals, "-00000012345")
+	c.Assert(utils.FormatSignedNumeric(0, 12), check.Equals, "000000000000")
+}
+
+func (t *RecordTest) TestBRecordSignedAmounts(c *check.C) {
+	amount := config.BRecordLayout["PaymentAmount9"]
+	withAmount := func(ascii []byte, value string) string {
+		return string(ascii[:amount.Start]) + value + string(ascii[amount.Start+amount.Length:])
+	}
+
+	// profit or (loss) of 1099-B may be negative
+	r, err := NewBRecord(config.Sub1099BType)
+	c.Assert(err, check.IsNil)
+	c.Assert(r.Parse([]byte(withAmount(t.bRecord1099BAscii, "-00000000100"))), check.IsNil)
+	payee := r.(*BRecord)
+	c.Assert(payee.PaymentAmount9, check.Equals, -100)
+	c.Assert(payee.PaymentCodes(), check.Matches, ".*9.*")
+	c.Assert(r.Validate(), check.IsNil)
+	c.Assert(string(r.Ascii()), check.Equals, withAmount(t.bRecord1099BAscii, "-00000000100"))
+
+	c.Assert(r.Parse([]byte(withAmount(t.bRecord1099BAscii, "00000000010J"))), check.IsNil)
+	c.Assert(payee.PaymentAmount9, check.Equals, -101)
+	c.Assert(r.Parse([]byte(withAmount(t.bRecord1099BAscii, "+00000000900"))), check.IsNil)
+	c.Assert(payee.PaymentAmount9, check.Equals, 900)
+	c.Assert(string(r.Ascii()), check.Equals, string(t.bRecord1099BAscii))
+	c.Assert(r.Parse([]byte(withAmount(t.bRecord1099BAscii, "0000000-0100"))), check.Equals, utils.ErrNumeric)
+
+	// other boxes of 1099-B don't report a loss
+	payee.PaymentAmount2 = -100
+	err = r.Validate()
+	c.Assert(utils.ErrorCode(err), check.Equals, utils.CodeNegativeAmount)
+	c.Assert(err.Error(), check.Equals, "has negative amount of amount code 2, which 1099-B doesn't allow")
+
+	// other types of return don't allow negative amounts
+	r, err = NewBRecord(config.Sub1099MiscType)
+	c.Assert(err, check.IsNil)
+	c.Assert(r.Parse(t.bRecord1099MiscAscii), check.IsNil)
+	payee = r.(*BRecord)
+	payee.PaymentAmount1 = -100
+	// ZIP Code of the state of the payee
+	payee.PayeeZipCode = "92222"
+	err = r.Validate()
+	c.Assert(err, check.NotNil)
+	c.Assert(utils.ErrorCode(err), check.Equals, utils.CodeNegativeAmount)
+	c.Assert(err.Error(), check.Equals, "has negative amount of amount code 1, which 1099-MISC doesn't allow")
+	errs := payee.ValidateFields()
+	c.Assert(errs, check.HasLen, 1)
+	c.Assert(errs[0].FieldName, check.Equals, "PaymentAmount1")
+	c.Assert(errs[0].Start, check.Equals, config.BRecordLayout["PaymentAmount1"].Start)
+}
+
+func (t *RecordTest) TestCRecordSignedAmounts(c *check.C) {
+	total := config.CRecordLayout["ControlTotal1"]
+	r := NewCRecord()
+	c.Assert(r.Parse(t.cRecordAscii), check.IsNil)
+	cRecord := r.(*CRecord)
+	cRecord.ControlTotal1 = -12345
+	ascii := string(r.Ascii())
+	c.Assert(ascii[total.Start:total.Start+total.Length], check.Equals, "-00000000000012345")
+	c.Assert(r.Parse([]byte(ascii)), check.IsNil)
+	c.Assert(cRecord.ControlTotal1, check.Equals, -12345)
+	c.Assert(cRecord.TotalCodes()[:1], check.Equals, "1")
+}
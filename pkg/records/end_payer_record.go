@@ -67,6 +67,8 @@ type CRecord struct {
 	// Record, “00000004” and so on until the final record of the
 	// file, the “F” Record.
 	RecordSequenceNumber int `json:"record_sequence_number" validate:"required"`
+
+	taxYear int
 }
 
 // Type returns type of “C” record
@@ -86,13 +88,13 @@ func (r *CRecord) Parse(buf []byte) error {
 		return utils.ErrValidField
 	}
 
-	return utils.ParseValue(fields, config.CRecordLayout, record)
+	return utils.ParseValue(fields, r.layout(), record)
 }
 
 // Ascii returns fire ascii of “C” record
 func (r *CRecord) Ascii() []byte {
 	var buf bytes.Buffer
-	records := config.ToSpecifications(config.CRecordLayout)
+	records := config.ToSpecifications(r.layout())
 	fields := reflect.ValueOf(r).Elem()
 	if !fields.IsValid() {
 		return nil
@@ -109,7 +111,12 @@ func (r *CRecord) Ascii() []byte {
 
 // Validate performs some checks on the record and returns an error if not Validated
 func (r *CRecord) Validate() error {
-	return utils.Validate(r, config.CRecordLayout, config.CRecordType)
+	return utils.Validate(r, r.layout(), config.CRecordType)
+}
+
+// ValidateFields performs the checks of Validate and returns every field error
+func (r *CRecord) ValidateFields() []*utils.FieldError {
+	return utils.ValidateFields(r, r.layout(), config.CRecordType)
 }
 
 // SequenceNumber returns sequence number of the record
@@ -122,6 +129,20 @@ func (r *CRecord) SetSequenceNumber(number int) {
 	r.RecordSequenceNumber = number
 }
 
+// TaxYear returns tax year of the record that selects its layout revision
+func (r *CRecord) TaxYear() int {
+	return r.taxYear
+}
+
+// SetTaxYear sets tax year of the record that selects its layout revision
+func (r *CRecord) SetTaxYear(year int) {
+	r.taxYear = year
+}
+
+func (r *CRecord) layout() map[string]config.SpecField {
+	return config.RecordLayout(r.TaxYear(), config.CRecordType)
+}
+
 // ControlTotal returns total of any payment amount field
 func (r *CRecord) ControlTotal(index string) (int, error) {
 	value, err := utils.GetField(r, "ControlTotal"+index)
@@ -131,13 +152,23 @@ func (r *CRecord) ControlTotal(index string) (int, error) {
 	return int(value.Int()), nil
 }
 
+// SetControlTotal sets total of any payment amount field
+func (r *CRecord) SetControlTotal(index string, amount int) error {
+	value, err := utils.GetField(r, "ControlTotal"+index)
+	if err != nil {
+		return err
+	}
+	value.SetInt(int64(amount))
+	return nil
+}
+
 // TotalCodes returns total codes
 func (r *CRecord) TotalCodes() string {
 	codes := ""
 	codeIndexes := []string{"1", "2", "3", "4", "5", "6", "7", "8", "9", "A", "B", "C", "D", "E", "F", "G"}
 	for _, index := range codeIndexes {
 		amount, err := r.ControlTotal(index)
-		if err == nil && amount > 0 {
+		if err == nil && amount != 0 {
 			codes += index
 		}
 	}
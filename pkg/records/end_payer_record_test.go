@@ -34,6 +34,10 @@ func (t *RecordTest) TestCRecord(c *check.C) {
 	c.Assert(len(codes), check.Not(check.Equals), 0)
 	_, err = cRecord.ControlTotal("1")
 	c.Assert(err, check.IsNil)
+	c.Assert(cRecord.SetControlTotal("2", 250), check.IsNil)
+	amount, _ := cRecord.ControlTotal("2")
+	c.Assert(amount, check.Equals, 250)
+	c.Assert(cRecord.SetControlTotal("K", 1), check.NotNil)
 }
 
 func (t *RecordTest) TestCRecordWithError(c *check.C) {
@@ -44,6 +44,8 @@ type FRecord struct {
 	// Record, “00000004” and so on until the final record of the
 	// file, the “F” Record.
 	RecordSequenceNumber int `json:"record_sequence_number" validate:"required"`
+
+	taxYear int
 }
 
 // Type returns type of “F” record
@@ -63,13 +65,13 @@ func (r *FRecord) Parse(buf []byte) error {
 		return utils.ErrValidField
 	}
 
-	return utils.ParseValue(fields, config.FRecordLayout, record)
+	return utils.ParseValue(fields, r.layout(), record)
 }
 
 // Ascii returns fire ascii of “F” record
 func (r *FRecord) Ascii() []byte {
 	var buf bytes.Buffer
-	records := config.ToSpecifications(config.FRecordLayout)
+	records := config.ToSpecifications(r.layout())
 	fields := reflect.ValueOf(r).Elem()
 	if !fields.IsValid() {
 		return nil
@@ -86,7 +88,12 @@ func (r *FRecord) Ascii() []byte {
 
 // Validate performs some checks on the record and returns an error if not Validated
 func (r *FRecord) Validate() error {
-	return utils.Validate(r, config.FRecordLayout, config.FRecordType)
+	return utils.Validate(r, r.layout(), config.FRecordType)
+}
+
+// ValidateFields performs the checks of Validate and returns every field error
+func (r *FRecord) ValidateFields() []*utils.FieldError {
+	return utils.ValidateFields(r, r.layout(), config.FRecordType)
 }
 
 // SequenceNumber returns sequence number of the record
@@ -99,6 +106,20 @@ func (r *FRecord) SetSequenceNumber(number int) {
 	r.RecordSequenceNumber = number
 }
 
+// TaxYear returns tax year of the record that selects its layout revision
+func (r *FRecord) TaxYear() int {
+	return r.taxYear
+}
+
+// SetTaxYear sets tax year of the record that selects its layout revision
+func (r *FRecord) SetTaxYear(year int) {
+	r.taxYear = year
+}
+
+func (r *FRecord) layout() map[string]config.SpecField {
+	return config.RecordLayout(r.TaxYear(), config.FRecordType)
+}
+
 // customized field validation functions
 // function name should be "Validate" + field name
 
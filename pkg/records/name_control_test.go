@@ -0,0 +1,62 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package records
+
+import (
+	"gopkg.in/check.v1"
+
+	"github.com/moov-io/irs/pkg/config"
+	"github.com/moov-io/irs/pkg/utils"
+)
+
+func (t *RecordTest) TestNameControl(c *check.C) {
+	cases := []struct {
+		name      string
+		typeOfTIN string
+		expected  string
+	}{
+		// individuals, surname first
+		{"SMITH JOHN", config.TinType2, "SMIT"},
+		{"Smith, John A.", "", "SMIT"},
+		{"NG TIMOTHY", config.TinType2, "NG"},
+		{"HO-CHAN MING", config.TinType2, "HO-C"},
+		{"SMITH-JONES MARY", config.TinType2, "SMIT"},
+		{"VAN DYKE JOHN", config.TinType2, "VAND"},
+		{"DE LA ROSA MARIA", config.TinType2, "DELA"},
+		{"O'NEIL PATRICK", config.TinType2, "ONEI"},
+		{"MC DONALD RONALD", config.TinType2, "MCDO"},
+		{"SMITH JOHN DBA SMITH PLUMBING", config.TinType2, "SMIT"},
+		// businesses
+		{"SPACELEY SPROCKETS", config.TinType1, "SPAC"},
+		{"The Flower Shop", config.TinType1, "FLOW"},
+		{"THE HIDEAWAY", config.TinType1, "THEH"},
+		{"P & P COMPANY", config.TinType1, "P&PC"},
+		{"1ST BANK", config.TinType1, "1STB"},
+		{"A.B.C. CORP", config.TinType1, "ABCC"},
+		{"ABC CORP D/B/A XYZ SERVICES", config.TinType1, "ABCC"},
+		// estates
+		{"ESTATE OF FRANK WHITE", config.TinType1, "WHIT"},
+		{"JOHN VAN DYKE ESTATE", config.TinType1, "VAND"},
+		// trusts
+		{"JANE DOE IRREVOCABLE TRUST", config.TinType1, "DOE"},
+		{"JOHN SMITH TRUST DTD 01/01/2000", config.TinType1, "SMIT"},
+		{"ACME HOLDINGS TRUST", config.TinType1, "ACME"},
+		// not determinable
+		{"", config.TinType1, ""},
+		{"...", config.TinType2, ""},
+	}
+	for _, item := range cases {
+		payee := &BRecord{FirstPayeeNameLine: item.name, TypeOfTIN: item.typeOfTIN}
+		c.Assert(payee.DerivedNameControl(), check.Equals, item.expected, check.Commentf("name %q", item.name))
+	}
+
+	c.Assert(utils.NameType("SMITH, JOHN", ""), check.Equals, utils.NameIndividual)
+	c.Assert(utils.NameType("ESTATE OF FRANK WHITE", config.TinType1), check.Equals, utils.NameEstate)
+	c.Assert(utils.NameType("JANE DOE TRUST", config.TinType1), check.Equals, utils.NameTrust)
+	c.Assert(utils.NameType("ASDF GLOBAL INC", ""), check.Equals, utils.NameBusiness)
+
+	payer := &ARecord{FirstPayerNameLine: "ASDF GLOBAL INC"}
+	c.Assert(payer.DerivedNameControl(), check.Equals, "ASDF")
+}
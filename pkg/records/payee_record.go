@@ -8,6 +8,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"reflect"
+	"strings"
 	"unicode/utf8"
 
 	"github.com/moov-io/irs/pkg/config"
@@ -218,8 +219,9 @@ type BRecord struct {
 	//file, the “F” Record.
 	RecordSequenceNumber int `json:"record_sequence_number" validate:"required"`
 
-	typeOfReturn string
-	extRecord    subrecords.SubRecord
+	typeOfReturn  string
+	extRecord     subrecords.SubRecord
+	priorYearData bool
 }
 
 // Type returns type of “B” record
@@ -239,12 +241,13 @@ func (r *BRecord) Parse(buf []byte) error {
 		return utils.ErrValidField
 	}
 
-	err := utils.ParseValue(fields, config.BRecordLayout, record)
+	err := utils.ParseValue(fields, config.RecordLayout(config.LayoutYear(paymentYear(record), r.priorYearData), config.BRecordType), record)
 	if err != nil {
 		return err
 	}
 
 	if r.extRecord != nil {
+		subrecords.SetTaxYear(r.extRecord, r.TaxYear())
 		err = r.extRecord.Parse(buf[config.RecordLength-config.SubRecordLength:])
 	}
 
@@ -254,7 +257,7 @@ func (r *BRecord) Parse(buf []byte) error {
 // Ascii returns fire ascii of “B” record
 func (r *BRecord) Ascii() []byte {
 	var buf bytes.Buffer
-	records := config.ToSpecifications(config.BRecordLayout)
+	records := config.ToSpecifications(r.layout())
 	fields := reflect.ValueOf(r).Elem()
 	if !fields.IsValid() {
 		return nil
@@ -267,6 +270,7 @@ func (r *BRecord) Ascii() []byte {
 	}
 
 	if r.extRecord != nil {
+		subrecords.SetTaxYear(r.extRecord, r.TaxYear())
 		buf.Grow(config.RecordLength)
 		buf.Write(r.extRecord.Ascii())
 	}
@@ -276,17 +280,71 @@ func (r *BRecord) Ascii() []byte {
 
 // Validate performs some checks on the record and returns an error if not Validated
 func (r *BRecord) Validate() error {
-	err := utils.Validate(r, config.BRecordLayout, config.BRecordType)
+	err := utils.Validate(r, r.layout(), config.BRecordType)
 	if err != nil {
 		return err
 	}
 	if r.extRecord == nil {
 		return utils.ErrPayeeExtBlock
 	}
+	if errs := r.negativeAmountErrors(); len(errs) > 0 {
+		return errs[0].Err
+	}
 
+	subrecords.SetTaxYear(r.extRecord, r.TaxYear())
 	return r.extRecord.Validate()
 }
 
+// ValidateFields performs the checks of Validate and returns every field error
+// including the errors of the extension block
+func (r *BRecord) ValidateFields() []*utils.FieldError {
+	errs := utils.ValidateFields(r, r.layout(), config.BRecordType)
+	if r.extRecord == nil {
+		return append(errs, &utils.FieldError{
+			FieldName: "Reserved",
+			Start:     config.RecordLength - config.SubRecordLength,
+			Length:    config.SubRecordLength,
+			Err:       utils.ErrPayeeExtBlock,
+			Severity:  utils.SeverityError,
+		})
+	}
+
+	errs = append(errs, r.negativeAmountErrors()...)
+
+	layout := config.SubRecordLayout(r.TaxYear(), r.extRecord.Type())
+	for _, err := range utils.ValidateFields(r.extRecord, layout, r.extRecord.Type()) {
+		err.Start += config.RecordLength - config.SubRecordLength
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+// negativeAmountErrors returns field errors of negative payment amounts, only
+// losses of config.LossAmountCodes may be negative
+func (r *BRecord) negativeAmountErrors() []*utils.FieldError {
+	if r.extRecord == nil {
+		return nil
+	}
+	var errs []*utils.FieldError
+	layout := r.layout()
+	losses := config.LossAmountCodes[r.extRecord.Type()]
+	for _, name := range config.AmountFields[config.BRecordType] {
+		code := strings.TrimPrefix(name, "PaymentAmount")
+		field, err := utils.GetField(r, name)
+		if err != nil || field.Int() >= 0 || losses[code] {
+			continue
+		}
+		errs = append(errs, &utils.FieldError{
+			FieldName: name,
+			Start:     layout[name].Start,
+			Length:    layout[name].Length,
+			Err:       utils.NewErrNegativeAmount(r.extRecord.Type(), code),
+			Severity:  utils.SeverityError,
+		})
+	}
+	return errs
+}
+
 // SequenceNumber returns sequence number of the record
 func (r *BRecord) SequenceNumber() int {
 	return r.RecordSequenceNumber
@@ -297,6 +355,21 @@ func (r *BRecord) SetSequenceNumber(number int) {
 	r.RecordSequenceNumber = number
 }
 
+// TaxYear returns tax year of the record that selects its layout revision,
+// prior year data uses the current revision
+func (r *BRecord) TaxYear() int {
+	return config.LayoutYear(r.PaymentYear, r.priorYearData)
+}
+
+// SetPriorYearData sets the prior year data indicator of the transmitter that selects the layout revision
+func (r *BRecord) SetPriorYearData(priorYearData bool) {
+	r.priorYearData = priorYearData
+}
+
+func (r *BRecord) layout() map[string]config.SpecField {
+	return config.RecordLayout(r.TaxYear(), config.BRecordType)
+}
+
 // SetTypeOfReturn set type of return of the record
 func (r *BRecord) SetTypeOfReturn(typeOfReturn string) error {
 	r.typeOfReturn = typeOfReturn
@@ -308,6 +381,51 @@ func (r *BRecord) SetTypeOfReturn(typeOfReturn string) error {
 	return nil
 }
 
+// Extension returns extension block of the record
+func (r *BRecord) Extension() subrecords.SubRecord {
+	return r.extRecord
+}
+
+// SetExtension set extension block and type of return of the record
+func (r *BRecord) SetExtension(extension subrecords.SubRecord) error {
+	if extension == nil {
+		return utils.ErrPayeeExtBlock
+	}
+	r.typeOfReturn = extension.Type()
+	r.extRecord = extension
+	return nil
+}
+
+// DerivedNameControl returns name control of the first payee name line by the IRS name control rules
+func (r *BRecord) DerivedNameControl() string {
+	return utils.NameControl(r.FirstPayeeNameLine, utils.NameType(r.FirstPayeeNameLine, r.TypeOfTIN))
+}
+
+// DerivedTypeOfTIN returns type of TIN inferred from the TIN and first payee name line,
+// or blank if it can't be inferred
+func (r *BRecord) DerivedTypeOfTIN() string {
+	return utils.InferTypeOfTIN(r.TIN, r.FirstPayeeNameLine)
+}
+
+// NormalizeAddress normalizes mailing address, city, state and ZIP Code of the payee
+//
+// Foreign addresses (ForeignCountryIndicator “1”) are laid out as one continuous
+// field of city, province or state and postal code. See utils.NormalizeAddress.
+func (r *BRecord) NormalizeAddress() error {
+	address, err := utils.NormalizeAddress(utils.Address{
+		Street:  r.PayeeMailingAddress,
+		City:    r.PayeeCity,
+		State:   r.PayeeState,
+		ZipCode: r.PayeeZipCode,
+		Foreign: r.ForeignCountryIndicator == config.ForeignCountryIndicator,
+	})
+	if err != nil {
+		return err
+	}
+	r.PayeeMailingAddress, r.PayeeCity, r.PayeeState, r.PayeeZipCode = address.Street, address.City, address.State, address.ZipCode
+	return nil
+}
+
 // PaymentAmount returns payment amount
 func (r *BRecord) PaymentAmount(index string) (int, error) {
 	value, err := utils.GetField(r, "PaymentAmount"+index)
@@ -323,7 +441,7 @@ func (r *BRecord) PaymentCodes() string {
 	codeIndexes := []string{"1", "2", "3", "4", "5", "6", "7", "8", "9", "A", "B", "C", "D", "E", "F", "G"}
 	for _, index := range codeIndexes {
 		amount, err := r.PaymentAmount(index)
-		if err == nil && amount > 0 {
+		if err == nil && amount != 0 {
 			codes += index
 		}
 	}
@@ -337,6 +455,9 @@ func (r *BRecord) TypeOfReturn() string {
 
 // Type returns FS code of “B” record
 func (r *BRecord) FederalState() int {
+	if r.extRecord == nil {
+		return 0
+	}
 	return r.extRecord.FederalState()
 }
 
@@ -470,6 +591,19 @@ func (r *BRecord) ValidateCorrectedReturnIndicator() error {
 	return utils.NewErrValidValue("corrected return indicator")
 }
 
+func (r *BRecord) ValidateTIN() error {
+	// TINs that aren't nine digits are left to the field validation
+	if len(r.TIN) != 9 || utils.IsNumeric(r.TIN) != nil {
+		return nil
+	}
+	err := utils.ValidateTIN(r.TIN, r.TypeOfTIN)
+	// an ITIN or ATIN with the EIN type of TIN may be a wrong type of TIN, the fire-production profile rejects it
+	if utils.ErrorCode(err) == utils.CodeTINTypeMismatch {
+		return utils.WithSeverity(err, utils.SeverityWarning)
+	}
+	return err
+}
+
 func (r *BRecord) ValidateTypeOfTIN() error {
 	if r.TypeOfTIN == config.TinType1 ||
 		r.TypeOfTIN == config.TinType2 ||
@@ -487,18 +621,25 @@ func (r *BRecord) ValidateForeignCountryIndicator() error {
 }
 
 func (r *BRecord) ValidatePayeeState() error {
+	if r.ForeignCountryIndicator == config.ForeignCountryIndicator {
+		return nil
+	}
 	if _, ok := config.StateAbbreviationCodes[r.PayeeState]; ok {
 		return nil
 	}
+	if _, ok := config.MilitaryStateCodes[r.PayeeState]; ok {
+		return nil
+	}
 	return utils.NewErrValidValue("payee state")
 }
 
 func (r *BRecord) ValidatePayeeZipCode() error {
-	if len(r.PayeeZipCode) == 0 {
+	if len(r.PayeeZipCode) == 0 || r.ForeignCountryIndicator == config.ForeignCountryIndicator {
 		return nil
 	}
-	if err := utils.IsNumeric(r.PayeeZipCode); err == nil {
-		return nil
+	if err := utils.IsNumeric(r.PayeeZipCode); err != nil {
+		return utils.NewErrValidValue("payee zip code")
 	}
-	return utils.NewErrValidValue("payee zip code")
+	// a ZIP Code of another state is suspicious, not invalid
+	return utils.WithSeverity(utils.ValidateZipCodeState(r.PayeeZipCode, r.PayeeState), utils.SeverityWarning)
 }
@@ -10,6 +10,7 @@ import (
 	"gopkg.in/check.v1"
 
 	"github.com/moov-io/irs/pkg/config"
+	"github.com/moov-io/irs/pkg/subrecords"
 )
 
 func (t *RecordTest) TestBRecordWith1099MISC(c *check.C) {
@@ -366,6 +367,30 @@ func (t *RecordTest) TestBRecordWith1099Cap(c *check.C) {
 	c.Assert(string(r.Ascii()), check.Equals, string(t.bRecord1099CapAscii))
 }
 
+func (t *RecordTest) TestBRecordWith1099DA(c *check.C) {
+	r := &BRecord{}
+	err := r.SetTypeOfReturn(config.Sub1099DaType)
+	c.Assert(err, check.IsNil)
+	c.Assert(r.Validate(), check.Not(check.IsNil))
+	err = json.Unmarshal(t.bRecord1099DaJson, r)
+	c.Assert(err, check.IsNil)
+	c.Assert(r.Validate(), check.IsNil)
+	c.Assert(r.extRecord.Type(), check.Equals, config.Sub1099DaType)
+	c.Assert(string(r.Ascii()), check.Equals, string(t.bRecord1099DaAscii))
+	c.Assert(r.Validate(), check.IsNil)
+	err = r.Parse(t.bRecord1099DaAscii)
+	c.Assert(err, check.IsNil)
+	c.Assert(r.Validate(), check.IsNil)
+	c.Assert(string(r.Ascii()), check.Equals, string(t.bRecord1099DaAscii))
+
+	extension := r.Extension().(*subrecords.Sub1099DA)
+	extension.NumberUnits = "1,000"
+	c.Assert(r.Validate(), check.Not(check.IsNil))
+	extension.NumberUnits = "1000.5"
+	extension.ApplicableCheckboxForm8949 = "A"
+	c.Assert(r.Validate(), check.Not(check.IsNil))
+}
+
 func (t *RecordTest) TestBRecordWith1099Div(c *check.C) {
 	r := &BRecord{}
 	err := r.SetTypeOfReturn(config.Sub1099DivType)
@@ -485,6 +510,27 @@ func (t *RecordTest) TestBRecordWith1099Q(c *check.C) {
 	c.Assert(string(r.Ascii()), check.Equals, string(t.bRecord1099QAscii))
 }
 
+func (t *RecordTest) TestBRecordWith1099QA(c *check.C) {
+	r := &BRecord{}
+	err := r.SetTypeOfReturn(config.Sub1099QaType)
+	c.Assert(err, check.IsNil)
+	c.Assert(r.Validate(), check.Not(check.IsNil))
+	err = json.Unmarshal(t.bRecord1099QaJson, r)
+	c.Assert(err, check.IsNil)
+	c.Assert(r.Validate(), check.IsNil)
+	c.Assert(r.extRecord.Type(), check.Equals, config.Sub1099QaType)
+	c.Assert(string(r.Ascii()), check.Equals, string(t.bRecord1099QaAscii))
+	c.Assert(r.Validate(), check.IsNil)
+	err = r.Parse(t.bRecord1099QaAscii)
+	c.Assert(err, check.IsNil)
+	c.Assert(r.Validate(), check.IsNil)
+	c.Assert(string(r.Ascii()), check.Equals, string(t.bRecord1099QaAscii))
+
+	extension := r.Extension().(*subrecords.Sub1099QA)
+	extension.DesignatedBeneficiary = "2"
+	c.Assert(r.Validate(), check.Not(check.IsNil))
+}
+
 func (t *RecordTest) TestBRecordWith1099R(c *check.C) {
 	r := &BRecord{}
 	err := r.SetTypeOfReturn(config.Sub1099RType)
@@ -621,6 +667,27 @@ func (t *RecordTest) TestBRecordWith5498ESA(c *check.C) {
 	c.Assert(string(r.Ascii()), check.Equals, string(t.bRecord5498EsaAscii))
 }
 
+func (t *RecordTest) TestBRecordWith5498QA(c *check.C) {
+	r := &BRecord{}
+	err := r.SetTypeOfReturn(config.Sub5498QaType)
+	c.Assert(err, check.IsNil)
+	c.Assert(r.Validate(), check.Not(check.IsNil))
+	err = json.Unmarshal(t.bRecord5498QaJson, r)
+	c.Assert(err, check.IsNil)
+	c.Assert(r.Validate(), check.IsNil)
+	c.Assert(r.extRecord.Type(), check.Equals, config.Sub5498QaType)
+	c.Assert(string(r.Ascii()), check.Equals, string(t.bRecord5498QaAscii))
+	c.Assert(r.Validate(), check.IsNil)
+	err = r.Parse(t.bRecord5498QaAscii)
+	c.Assert(err, check.IsNil)
+	c.Assert(r.Validate(), check.IsNil)
+	c.Assert(string(r.Ascii()), check.Equals, string(t.bRecord5498QaAscii))
+
+	extension := r.Extension().(*subrecords.Sub5498QA)
+	extension.DisabilityCode = "7"
+	c.Assert(r.Validate(), check.Not(check.IsNil))
+}
+
 func (t *RecordTest) TestBRecordWith5498SA(c *check.C) {
 	r := &BRecord{}
 	err := r.SetTypeOfReturn(config.Sub5498SaType)
@@ -149,6 +149,8 @@ type ARecord struct {
 	// second “B” Record, “00000004” and so on until the final record
 	// of the file, the “F” Record.
 	RecordSequenceNumber int `json:"record_sequence_number" validate:"required"`
+
+	priorYearData bool
 }
 
 // Type returns type of “A” record
@@ -168,13 +170,13 @@ func (r *ARecord) Parse(buf []byte) error {
 		return utils.ErrValidField
 	}
 
-	return utils.ParseValue(fields, config.ARecordLayout, record)
+	return utils.ParseValue(fields, config.RecordLayout(config.LayoutYear(paymentYear(record), r.priorYearData), config.ARecordType), record)
 }
 
 // Ascii returns fire ascii of “A” record
 func (r *ARecord) Ascii() []byte {
 	var buf bytes.Buffer
-	records := config.ToSpecifications(config.ARecordLayout)
+	records := config.ToSpecifications(r.layout())
 	fields := reflect.ValueOf(r).Elem()
 	if !fields.IsValid() {
 		return nil
@@ -191,7 +193,12 @@ func (r *ARecord) Ascii() []byte {
 
 // Validate performs some checks on the record and returns an error if not Validated
 func (r *ARecord) Validate() error {
-	return utils.Validate(r, config.ARecordLayout, config.ARecordType)
+	return utils.Validate(r, r.layout(), config.ARecordType)
+}
+
+// ValidateFields performs the checks of Validate and returns every field error
+func (r *ARecord) ValidateFields() []*utils.FieldError {
+	return utils.ValidateFields(r, r.layout(), config.ARecordType)
 }
 
 // SequenceNumber returns sequence number of the record
@@ -204,6 +211,45 @@ func (r *ARecord) SetSequenceNumber(number int) {
 	r.RecordSequenceNumber = number
 }
 
+// TaxYear returns tax year of the record that selects its layout revision,
+// prior year data uses the current revision
+func (r *ARecord) TaxYear() int {
+	return config.LayoutYear(r.PaymentYear, r.priorYearData)
+}
+
+// SetPriorYearData sets the prior year data indicator of the transmitter that selects the layout revision
+func (r *ARecord) SetPriorYearData(priorYearData bool) {
+	r.priorYearData = priorYearData
+}
+
+// DerivedNameControl returns name control of the first payer name line by the IRS name control rules
+func (r *ARecord) DerivedNameControl() string {
+	return utils.NameControl(r.FirstPayerNameLine, utils.NameType(r.FirstPayerNameLine, ""))
+}
+
+// NormalizeAddress normalizes shipping address, city, state and ZIP Code of the payer
+//
+// Foreign addresses (ForeignEntityIndicator “1”) are laid out as one continuous
+// field of city, province or state and postal code. See utils.NormalizeAddress.
+func (r *ARecord) NormalizeAddress() error {
+	address, err := utils.NormalizeAddress(utils.Address{
+		Street:  r.PayerShippingAddress,
+		City:    r.PayerCity,
+		State:   r.PayerState,
+		ZipCode: r.PayerZipCode,
+		Foreign: r.ForeignEntityIndicator == config.ForeignEntityIndicator,
+	})
+	if err != nil {
+		return err
+	}
+	r.PayerShippingAddress, r.PayerCity, r.PayerState, r.PayerZipCode = address.Street, address.City, address.State, address.ZipCode
+	return nil
+}
+
+func (r *ARecord) layout() map[string]config.SpecField {
+	return config.RecordLayout(r.TaxYear(), config.ARecordType)
+}
+
 // customized field validation functions
 // function name should be "Validate" + field name
 
@@ -214,6 +260,14 @@ func (r *ARecord) ValidateRecordSequenceNumber() error {
 	return nil
 }
 
+func (r *ARecord) ValidateTIN() error {
+	// TINs that aren't nine digits are left to the field validation
+	if len(r.TIN) != 9 || utils.IsNumeric(r.TIN) != nil {
+		return nil
+	}
+	return utils.ValidateTIN(r.TIN, "")
+}
+
 func (r *ARecord) ValidateCombinedFSFilingProgram() error {
 	if r.CombinedFSFilingProgram == config.FSFilingProgramApproved || len(r.CombinedFSFilingProgram) == 0 {
 		return nil
@@ -250,9 +304,15 @@ func (r *ARecord) ValidateTransferAgentIndicator() error {
 }
 
 func (r *ARecord) ValidatePayerState() error {
+	if r.ForeignEntityIndicator == config.ForeignEntityIndicator {
+		return nil
+	}
 	if _, ok := config.StateAbbreviationCodes[r.PayerState]; ok {
 		return nil
 	}
+	if _, ok := config.MilitaryStateCodes[r.PayerState]; ok {
+		return nil
+	}
 	return utils.NewErrValidValue("payer state")
 }
 
@@ -274,11 +334,12 @@ func (r *ARecord) ValidateAmountCodes() error {
 }
 
 func (r *ARecord) ValidatePayerZipCode() error {
-	if len(r.PayerZipCode) == 0 {
+	if len(r.PayerZipCode) == 0 || r.ForeignEntityIndicator == config.ForeignEntityIndicator {
 		return nil
 	}
-	if err := utils.IsNumeric(r.PayerZipCode); err == nil {
-		return nil
+	if err := utils.IsNumeric(r.PayerZipCode); err != nil {
+		return utils.NewErrValidValue("payer zip code")
 	}
-	return utils.NewErrValidValue("payer zip code")
+	// a ZIP Code of another state is suspicious, not invalid
+	return utils.WithSeverity(utils.ValidateZipCodeState(r.PayerZipCode, r.PayerState), utils.SeverityWarning)
 }
@@ -4,6 +4,14 @@
 
 package records
 
+import (
+	"strconv"
+	"strings"
+
+	"github.com/moov-io/irs/pkg/config"
+	"github.com/moov-io/irs/pkg/utils"
+)
+
 // General record interface
 type Record interface {
 	Type() string
@@ -14,6 +22,37 @@ type Record interface {
 	Validate() error
 }
 
+// FieldValidator is implemented by records that return every field error instead of the first one
+type FieldValidator interface {
+	ValidateFields() []*utils.FieldError
+}
+
+// TaxYearRecord is implemented by records whose layout revision depends on the tax year
+type TaxYearRecord interface {
+	TaxYear() int
+}
+
+// ValidateFields returns every field error of the record, records that don't implement
+// FieldValidator return the error of Validate
+func ValidateFields(record Record) []*utils.FieldError {
+	if validator, ok := record.(FieldValidator); ok {
+		return validator.ValidateFields()
+	}
+	if err := record.Validate(); err != nil {
+		return []*utils.FieldError{{Err: err, Severity: utils.Severity(err)}}
+	}
+	return nil
+}
+
+// TaxYear returns tax year of the record, records that don't implement TaxYearRecord return 0
+// and use the layout revision of config.DefaultTaxYear
+func TaxYear(record Record) int {
+	if r, ok := record.(TaxYearRecord); ok {
+		return r.TaxYear()
+	}
+	return 0
+}
+
 func NewARecord() Record {
 	return &ARecord{}
 }
@@ -38,3 +77,19 @@ func NewFRecord() Record {
 func NewKRecord() Record {
 	return &KRecord{}
 }
+
+// priorYearData returns true if the “T” record from fire ascii has prior year data,
+// position 6 is the same in every layout revision
+func priorYearData(record string) bool {
+	return len(record) > 5 && record[5:6] == config.PriorYearDataIndicator
+}
+
+// paymentYear returns payment year of “T”, “A” and “B” records from fire ascii,
+// positions 2-5 are the same in every layout revision
+func paymentYear(record string) int {
+	if len(record) < 5 {
+		return 0
+	}
+	year, _ := strconv.Atoi(strings.TrimSpace(record[1:5]))
+	return year
+}
@@ -0,0 +1,39 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package records
+
+import (
+	"gopkg.in/check.v1"
+
+	"github.com/moov-io/irs/pkg/utils"
+)
+
+// minimalRecord implements Record without the optional interfaces
+type minimalRecord struct {
+	err error
+}
+
+func (r *minimalRecord) Type() string          { return "X" }
+func (r *minimalRecord) SequenceNumber() int   { return 1 }
+func (r *minimalRecord) SetSequenceNumber(int) {}
+func (r *minimalRecord) Parse([]byte) error    { return nil }
+func (r *minimalRecord) Ascii() []byte         { return nil }
+func (r *minimalRecord) Validate() error       { return r.err }
+
+func (t *RecordTest) TestOptionalInterfaces(c *check.C) {
+	r := &minimalRecord{}
+	c.Assert(TaxYear(r), check.Equals, 0)
+	c.Assert(ValidateFields(r), check.HasLen, 0)
+
+	r.err = utils.ErrInvalidTCC
+	errs := ValidateFields(r)
+	c.Assert(errs, check.HasLen, 1)
+	c.Assert(errs[0].Err, check.Equals, utils.ErrInvalidTCC)
+	c.Assert(errs[0].Severity, check.Equals, utils.SeverityError)
+
+	tRecord := &TRecord{PaymentYear: 2020}
+	c.Assert(TaxYear(tRecord), check.Equals, 2020)
+	c.Assert(ValidateFields(tRecord), check.Not(check.HasLen), 0)
+}
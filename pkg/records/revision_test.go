@@ -0,0 +1,142 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package records
+
+import (
+	"encoding/json"
+	"strings"
+
+	"gopkg.in/check.v1"
+
+	"github.com/moov-io/irs/pkg/config"
+	"github.com/moov-io/irs/pkg/subrecords"
+)
+
+func (t *RecordTest) TestLayoutRevision(c *check.C) {
+	defer config.UnregisterRevision(2099)
+
+	c.Assert(config.RegisterRevision(nil), check.Equals, config.ErrInvalidRevision)
+	c.Assert(config.RegisterRevision(&config.Revision{TaxYear: 99}), check.Equals, config.ErrInvalidRevision)
+
+	spec := func(start, length, fieldType int, required string) config.SpecField {
+		return config.SpecField{Start: start, Length: length, Type: fieldType, Required: required}
+	}
+
+	// revision moving total number of payees of “F” record and dropping direct sales indicator of 1099-NEC
+	err := config.RegisterRevision(&config.Revision{
+		TaxYear: 2099,
+		Records: map[string]map[string]config.SpecField{
+			config.FRecordType: {
+				"RecordType":           spec(0, 1, config.Alphanumeric, config.Required),
+				"NumberPayerRecords":   spec(1, 8, config.ZeroNumeric, config.Required),
+				"Zero":                 spec(9, 21, config.ZeroNumeric, config.Applicable),
+				"TotalNumberPayees":    spec(30, 8, config.ZeroNumeric, config.Applicable),
+				"Blank2":               spec(38, 461, config.Alphanumeric, config.Nullable),
+				"RecordSequenceNumber": spec(499, 8, config.ZeroNumeric, config.Required),
+				"Blank4":               spec(507, 243, config.Alphanumeric, config.Nullable),
+			},
+		},
+		SubRecords: map[string]map[string]config.SpecField{
+			config.Sub1099NecType: {
+				"SecondTinNotice":        spec(0, 1, config.Alphanumeric, config.Applicable),
+				"Blank1":                 spec(1, 178, config.Alphanumeric, config.Nullable),
+				"StateIncomeTaxWithheld": spec(179, 12, config.ZeroNumeric, config.Applicable),
+				"LocalIncomeTaxWithheld": spec(191, 12, config.ZeroNumeric, config.Applicable),
+				"CombinedFSCode":         spec(203, 2, config.ZeroNumeric, config.Required),
+				"Blank3":                 spec(205, 2, config.Alphanumeric, config.Nullable),
+			},
+		},
+	})
+	c.Assert(err, check.IsNil)
+
+	c.Assert(config.RevisionYear(0), check.Equals, config.DefaultTaxYear)
+	c.Assert(config.RevisionYear(2017), check.Equals, config.DefaultTaxYear)
+	c.Assert(config.RevisionYear(2098), check.Equals, config.DefaultTaxYear)
+	c.Assert(config.RevisionYear(2100), check.Equals, 2099)
+	c.Assert(config.TaxYears(), check.DeepEquals, []int{config.DefaultTaxYear, 2099})
+
+	// layouts missing from the revision are taken from the previous revision
+	c.Assert(config.RecordLayout(2099, config.TRecordType), check.DeepEquals, config.TRecordLayout)
+	c.Assert(config.SubRecordLayout(2099, config.Sub1099MiscType), check.DeepEquals, config.Sub1099MISCLayout)
+	c.Assert(config.RecordLayout(2099, "?"), check.IsNil)
+
+	// “F” record
+	current := &FRecord{RecordType: config.FRecordType, NumberPayerRecords: 1, TotalNumberPayees: 7, RecordSequenceNumber: 4}
+	next := &FRecord{}
+	*next = *current
+	next.SetTaxYear(2099)
+	c.Assert(next.TaxYear(), check.Equals, 2099)
+	c.Assert(len(next.Ascii()), check.Equals, config.RecordLength)
+	c.Assert(string(current.Ascii()[49:57]), check.Equals, "00000007")
+	c.Assert(string(next.Ascii()[30:38]), check.Equals, "00000007")
+	c.Assert(next.Validate(), check.IsNil)
+
+	parsed := &FRecord{}
+	parsed.SetTaxYear(2099)
+	c.Assert(parsed.Parse(next.Ascii()), check.IsNil)
+	c.Assert(parsed.TotalNumberPayees, check.Equals, 7)
+
+	// “B” record selects revision of its extension block by payment year
+	r, err := NewBRecord(config.Sub1099NecType)
+	c.Assert(err, check.IsNil)
+	c.Assert(json.Unmarshal(t.bRecord1099NecJson, r), check.IsNil)
+	bRecord := r.(*BRecord)
+	extension := bRecord.Extension().(*subrecords.Sub1099NEC)
+	extension.DirectSalesIndicator = "1"
+	c.Assert(string(bRecord.Ascii()[546]), check.Equals, "1")
+
+	bRecord.PaymentYear = 2099
+	c.Assert(bRecord.TaxYear(), check.Equals, 2099)
+	ascii := bRecord.Ascii()
+	c.Assert(len(ascii), check.Equals, config.RecordLength)
+	c.Assert(string(ascii[546]), check.Equals, " ")
+	c.Assert(strings.HasPrefix(string(ascii), "B2099"), check.Equals, true)
+
+	parsedPayee, err := NewBRecord(config.Sub1099NecType)
+	c.Assert(err, check.IsNil)
+	c.Assert(parsedPayee.Parse(ascii), check.IsNil)
+	c.Assert(TaxYear(parsedPayee), check.Equals, 2099)
+	c.Assert(parsedPayee.(*BRecord).Extension().(*subrecords.Sub1099NEC).DirectSalesIndicator, check.Equals, "")
+
+	// prior year data uses the current revision instead of the revision of its payment year
+	c.Assert(config.CurrentTaxYear(), check.Equals, 2099)
+	c.Assert(config.LayoutYear(2017, true), check.Equals, 2099)
+	c.Assert(config.LayoutYear(2017, false), check.Equals, 2017)
+	transmitter := &TRecord{PaymentYear: 2017}
+	c.Assert(transmitter.TaxYear(), check.Equals, 2017)
+	transmitter.PriorYearDataIndicator = config.PriorYearDataIndicator
+	c.Assert(transmitter.TaxYear(), check.Equals, 2099)
+	parsedTransmitter := &TRecord{}
+	c.Assert(parsedTransmitter.Parse(t.tRecordAscii), check.IsNil)
+	c.Assert(parsedTransmitter.TaxYear(), check.Equals, 2099)
+
+	bRecord.PaymentYear = 2017
+	extension.DirectSalesIndicator = "1"
+	c.Assert(string(bRecord.Ascii()[546]), check.Equals, "1")
+	bRecord.SetPriorYearData(true)
+	c.Assert(bRecord.TaxYear(), check.Equals, 2099)
+	ascii = bRecord.Ascii()
+	c.Assert(string(ascii[546]), check.Equals, " ")
+	c.Assert(strings.HasPrefix(string(ascii), "B2017"), check.Equals, true)
+
+	parsedPayee, err = NewBRecord(config.Sub1099NecType)
+	c.Assert(err, check.IsNil)
+	parsedPayee.(*BRecord).SetPriorYearData(true)
+	c.Assert(parsedPayee.Parse(ascii), check.IsNil)
+	c.Assert(TaxYear(parsedPayee), check.Equals, 2099)
+
+	payer := &ARecord{PaymentYear: 2017}
+	payer.SetPriorYearData(true)
+	c.Assert(payer.TaxYear(), check.Equals, 2099)
+}
+
+func (t *RecordTest) TestUnregisterRevision(c *check.C) {
+	c.Assert(config.RegisterRevision(&config.Revision{TaxYear: 2098}), check.IsNil)
+	c.Assert(config.CurrentTaxYear(), check.Equals, 2098)
+	config.UnregisterRevision(2098)
+	config.UnregisterRevision(config.DefaultTaxYear)
+	c.Assert(config.TaxYears(), check.DeepEquals, []int{config.DefaultTaxYear})
+	c.Assert(config.CurrentTaxYear(), check.Equals, config.DefaultTaxYear)
+}
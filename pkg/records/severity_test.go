@@ -0,0 +1,50 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package records
+
+import (
+	"encoding/json"
+	"errors"
+
+	"gopkg.in/check.v1"
+
+	"github.com/moov-io/irs/pkg/config"
+	"github.com/moov-io/irs/pkg/utils"
+)
+
+func (t *RecordTest) TestSeverity(c *check.C) {
+	c.Assert(utils.Severity(utils.ErrNumeric), check.Equals, utils.SeverityError)
+	c.Assert(utils.WithSeverity(nil, utils.SeverityWarning), check.IsNil)
+
+	err := utils.WithSeverity(utils.NewErrZipCodeState("22222", "CA"), utils.SeverityInfo)
+	c.Assert(utils.Severity(err), check.Equals, utils.SeverityInfo)
+	c.Assert(utils.ErrorCode(err), check.Equals, utils.CodeZipCodeState)
+	err = utils.WithSeverity(utils.ErrNumeric, utils.SeverityWarning)
+	c.Assert(errors.Is(err, utils.ErrNumeric), check.Equals, true)
+
+	c.Assert(utils.FirstError(nil, err), check.IsNil)
+	c.Assert(utils.FirstError(err, utils.ErrEmail, utils.ErrNumeric), check.Equals, utils.ErrEmail)
+}
+
+func (t *RecordTest) TestValidateWithWarnings(c *check.C) {
+	r := &BRecord{}
+	c.Assert(r.SetTypeOfReturn(config.Sub1099MiscType), check.IsNil)
+	c.Assert(json.Unmarshal(t.bRecord1099MiscJson, r), check.IsNil)
+	r.PayeeState, r.PayeeZipCode = "CA", "22222"
+
+	// a ZIP Code of another state doesn't fail validation
+	c.Assert(r.Validate(), check.IsNil)
+	c.Assert(utils.Severity(r.ValidatePayeeZipCode()), check.Equals, utils.SeverityWarning)
+	errs := r.ValidateFields()
+	c.Assert(errs, check.HasLen, 1)
+	c.Assert(errs[0].FieldName, check.Equals, "PayeeZipCode")
+	c.Assert(errs[0].Severity, check.Equals, utils.SeverityWarning)
+
+	r.PayeeZipCode = "ABCDE"
+	c.Assert(r.Validate(), check.NotNil)
+	errs = r.ValidateFields()
+	c.Assert(errs, check.HasLen, 1)
+	c.Assert(errs[0].Severity, check.Equals, utils.SeverityError)
+}
@@ -81,6 +81,8 @@ type KRecord struct {
 	// Required. Enter the CF/SF code assigned to the state which
 	// is to receive the information.
 	CombinedFederalStateCode string `json:"combined_federal_state_code" validate:"required"`
+
+	taxYear int
 }
 
 // Type returns type of “K” record
@@ -100,13 +102,13 @@ func (r *KRecord) Parse(buf []byte) error {
 		return utils.ErrValidField
 	}
 
-	return utils.ParseValue(fields, config.KRecordLayout, record)
+	return utils.ParseValue(fields, r.layout(), record)
 }
 
 // Ascii returns fire ascii of “K” record
 func (r *KRecord) Ascii() []byte {
 	var buf bytes.Buffer
-	records := config.ToSpecifications(config.KRecordLayout)
+	records := config.ToSpecifications(r.layout())
 	fields := reflect.ValueOf(r).Elem()
 	if !fields.IsValid() {
 		return nil
@@ -123,7 +125,12 @@ func (r *KRecord) Ascii() []byte {
 
 // Validate performs some checks on the record and returns an error if not Validated
 func (r *KRecord) Validate() error {
-	return utils.Validate(r, config.KRecordLayout, config.KRecordType)
+	return utils.Validate(r, r.layout(), config.KRecordType)
+}
+
+// ValidateFields performs the checks of Validate and returns every field error
+func (r *KRecord) ValidateFields() []*utils.FieldError {
+	return utils.ValidateFields(r, r.layout(), config.KRecordType)
 }
 
 // SequenceNumber returns sequence number of the record
@@ -136,6 +143,20 @@ func (r *KRecord) SetSequenceNumber(number int) {
 	r.RecordSequenceNumber = number
 }
 
+// TaxYear returns tax year of the record that selects its layout revision
+func (r *KRecord) TaxYear() int {
+	return r.taxYear
+}
+
+// SetTaxYear sets tax year of the record that selects its layout revision
+func (r *KRecord) SetTaxYear(year int) {
+	r.taxYear = year
+}
+
+func (r *KRecord) layout() map[string]config.SpecField {
+	return config.RecordLayout(r.TaxYear(), config.KRecordType)
+}
+
 // ControlTotal returns total of any payment amount field
 func (r *KRecord) ControlTotal(index string) (int, error) {
 	value, err := utils.GetField(r, "ControlTotal"+index)
@@ -145,13 +166,23 @@ func (r *KRecord) ControlTotal(index string) (int, error) {
 	return int(value.Int()), nil
 }
 
+// SetControlTotal sets total of any payment amount field
+func (r *KRecord) SetControlTotal(index string, amount int) error {
+	value, err := utils.GetField(r, "ControlTotal"+index)
+	if err != nil {
+		return err
+	}
+	value.SetInt(int64(amount))
+	return nil
+}
+
 // PaymentAmount returns payment codes
 func (r *KRecord) PaymentCodes() string {
 	codes := ""
 	codeIndexes := []string{"1", "2", "3", "4", "5", "6", "7", "8", "9", "A", "B", "C", "D", "E", "F", "G"}
 	for _, index := range codeIndexes {
 		amount, err := r.ControlTotal(index)
-		if err == nil && amount > 0 {
+		if err == nil && amount != 0 {
 			codes += index
 		}
 	}
@@ -34,6 +34,10 @@ func (t *RecordTest) TestKRecord(c *check.C) {
 	c.Assert(len(codes), check.Not(check.Equals), 0)
 	_, err = kRecord.ControlTotal("1")
 	c.Assert(err, check.IsNil)
+	c.Assert(kRecord.SetControlTotal("2", 250), check.IsNil)
+	amount, _ := kRecord.ControlTotal("2")
+	c.Assert(amount, check.Equals, 250)
+	c.Assert(kRecord.SetControlTotal("k", 1), check.NotNil)
 }
 
 func (t *RecordTest) TestKRecordWithError(c *check.C) {
@@ -51,6 +51,8 @@ type RecordTest struct {
 	bRecord1099CAscii    []byte
 	bRecord1099CapJson   []byte
 	bRecord1099CapAscii  []byte
+	bRecord1099DaJson    []byte
+	bRecord1099DaAscii   []byte
 	bRecord1099DivJson   []byte
 	bRecord1099DivAscii  []byte
 	bRecord1099GJson     []byte
@@ -65,6 +67,8 @@ type RecordTest struct {
 	bRecord1099LtcAscii  []byte
 	bRecord1099QJson     []byte
 	bRecord1099QAscii    []byte
+	bRecord1099QaJson    []byte
+	bRecord1099QaAscii   []byte
 	bRecord1099RJson     []byte
 	bRecord1099RAscii    []byte
 	bRecord1099SJson     []byte
@@ -81,6 +85,8 @@ type RecordTest struct {
 	bRecord5498Ascii     []byte
 	bRecord5498EsaJson   []byte
 	bRecord5498EsaAscii  []byte
+	bRecord5498QaJson    []byte
+	bRecord5498QaAscii   []byte
 	bRecord5498SaJson    []byte
 	bRecord5498SaAscii   []byte
 	bRecordW2GJson       []byte
@@ -188,6 +194,11 @@ func (t *RecordTest) SetUpSuite(c *check.C) {
 	t.bRecord1099CapAscii, err = os.ReadFile(filepath.Join("..", "..", "test", "testdata", "payeeRecordWith1099Cap.ascii"))
 	c.Assert(err, check.IsNil)
 
+	t.bRecord1099DaJson, err = os.ReadFile(filepath.Join("..", "..", "test", "testdata", "payeeRecordWith1099Da.json"))
+	c.Assert(err, check.IsNil)
+	t.bRecord1099DaAscii, err = os.ReadFile(filepath.Join("..", "..", "test", "testdata", "payeeRecordWith1099Da.ascii"))
+	c.Assert(err, check.IsNil)
+
 	t.bRecord1099DivJson, err = os.ReadFile(filepath.Join("..", "..", "test", "testdata", "payeeRecordWith1099Div.json"))
 	c.Assert(err, check.IsNil)
 	t.bRecord1099DivAscii, err = os.ReadFile(filepath.Join("..", "..", "test", "testdata", "payeeRecordWith1099Div.ascii"))
@@ -223,6 +234,11 @@ func (t *RecordTest) SetUpSuite(c *check.C) {
 	t.bRecord1099QAscii, err = os.ReadFile(filepath.Join("..", "..", "test", "testdata", "payeeRecordWith1099Q.ascii"))
 	c.Assert(err, check.IsNil)
 
+	t.bRecord1099QaJson, err = os.ReadFile(filepath.Join("..", "..", "test", "testdata", "payeeRecordWith1099Qa.json"))
+	c.Assert(err, check.IsNil)
+	t.bRecord1099QaAscii, err = os.ReadFile(filepath.Join("..", "..", "test", "testdata", "payeeRecordWith1099Qa.ascii"))
+	c.Assert(err, check.IsNil)
+
 	t.bRecord1099RJson, err = os.ReadFile(filepath.Join("..", "..", "test", "testdata", "payeeRecordWith1099R.json"))
 	c.Assert(err, check.IsNil)
 	t.bRecord1099RAscii, err = os.ReadFile(filepath.Join("..", "..", "test", "testdata", "payeeRecordWith1099R.ascii"))
@@ -268,6 +284,11 @@ func (t *RecordTest) SetUpSuite(c *check.C) {
 	t.bRecord5498EsaAscii, err = os.ReadFile(filepath.Join("..", "..", "test", "testdata", "payeeRecordWith5498Esa.ascii"))
 	c.Assert(err, check.IsNil)
 
+	t.bRecord5498QaJson, err = os.ReadFile(filepath.Join("..", "..", "test", "testdata", "payeeRecordWith5498Qa.json"))
+	c.Assert(err, check.IsNil)
+	t.bRecord5498QaAscii, err = os.ReadFile(filepath.Join("..", "..", "test", "testdata", "payeeRecordWith5498Qa.ascii"))
+	c.Assert(err, check.IsNil)
+
 	t.bRecord5498SaJson, err = os.ReadFile(filepath.Join("..", "..", "test", "testdata", "payeeRecordWith5498Sa.json"))
 	c.Assert(err, check.IsNil)
 	t.bRecord5498SaAscii, err = os.ReadFile(filepath.Join("..", "..", "test", "testdata", "payeeRecordWith5498Sa.ascii"))
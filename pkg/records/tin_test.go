@@ -0,0 +1,129 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package records
+
+import (
+	"encoding/json"
+
+	"gopkg.in/check.v1"
+
+	"github.com/moov-io/irs/pkg/config"
+	"github.com/moov-io/irs/pkg/utils"
+)
+
+func (t *RecordTest) TestValidateTIN(c *check.C) {
+	cases := []struct {
+		tin       string
+		typeOfTIN string
+		code      string
+	}{
+		// valid numbers
+		{"123456789", config.TinType2, ""},
+		{"123456789", config.TinType1, ""},
+		{"987654321", config.TinType1, ""},
+		{"912701234", config.TinType2, ""},
+		{"912931234", config.TinType2, ""},
+		{"912931234", "", ""},
+		// invalid ssn
+		{"000123456", config.TinType2, utils.CodeInvalidTIN},
+		{"666123456", config.TinType2, utils.CodeInvalidTIN},
+		{"123001234", config.TinType2, utils.CodeInvalidTIN},
+		{"123450000", config.TinType2, utils.CodeInvalidTIN},
+		{"912401234", config.TinType2, utils.CodeInvalidTIN},
+		// invalid ein prefixes
+		{"071234567", config.TinType1, utils.CodeInvalidTIN},
+		{"891234567", config.TinType1, utils.CodeInvalidTIN},
+		// itin and atin with the ein type
+		{"972701234", config.TinType1, utils.CodeTINTypeMismatch},
+		{"972931234", config.TinType1, utils.CodeTINTypeMismatch},
+		// all same digits
+		{"111111111", config.TinType1, utils.CodeInvalidTIN},
+		{"999999999", "", utils.CodeInvalidTIN},
+		// neither ssn nor ein
+		{"000000001", "", utils.CodeInvalidTIN},
+		{"12345678A", config.TinType1, utils.CodeInvalidTIN},
+	}
+	for _, item := range cases {
+		err := utils.ValidateTIN(item.tin, item.typeOfTIN)
+		if len(item.code) == 0 {
+			c.Assert(err, check.IsNil, check.Commentf("tin %s", item.tin))
+			continue
+		}
+		c.Assert(err, check.NotNil, check.Commentf("tin %s", item.tin))
+		c.Assert(utils.ErrorCode(err), check.Equals, item.code, check.Commentf("tin %s", item.tin))
+	}
+
+	c.Assert(utils.ValidateTIN("972931234", config.TinType1).Error(), check.Equals, "has ATIN with type of tin 1")
+	c.Assert(utils.TINKinds("123456789"), check.DeepEquals, []string{utils.TINKindSSN, utils.TINKindEIN})
+	c.Assert(utils.TINKinds("962701234"), check.DeepEquals, []string{utils.TINKindITIN})
+	c.Assert(len(utils.TINKinds("555555555")), check.Equals, 0)
+}
+
+func (t *RecordTest) TestValidateTINSeverities(c *check.C) {
+	payee := &BRecord{}
+	c.Assert(payee.SetTypeOfReturn(config.Sub1099MiscType), check.IsNil)
+	c.Assert(json.Unmarshal(t.bRecord1099MiscJson, payee), check.IsNil)
+	c.Assert(payee.ValidateTIN(), check.IsNil)
+	// ZIP Code of the state of the payee
+	payee.PayeeZipCode = "92222"
+
+	// an ITIN with the EIN type of TIN doesn't fail validation
+	payee.TIN, payee.TypeOfTIN = "972701234", config.TinType1
+	c.Assert(payee.Validate(), check.IsNil)
+	c.Assert(utils.Severity(payee.ValidateTIN()), check.Equals, utils.SeverityWarning)
+	errs := payee.ValidateFields()
+	c.Assert(errs, check.HasLen, 1)
+	c.Assert(errs[0].FieldName, check.Equals, "TIN")
+	c.Assert(utils.ErrorCode(errs[0].Err), check.Equals, utils.CodeTINTypeMismatch)
+	c.Assert(errs[0].Severity, check.Equals, utils.SeverityWarning)
+
+	// impossible TINs fail validation
+	payee.TypeOfTIN = config.TinType2
+	for _, tin := range []string{"000123456", "666123456", "123001234", "123450000", "111111111"} {
+		payee.TIN = tin
+		err := payee.Validate()
+		c.Assert(utils.ErrorCode(err), check.Equals, utils.CodeInvalidTIN, check.Commentf("tin %s", tin))
+		c.Assert(utils.Severity(err), check.Equals, utils.SeverityError, check.Commentf("tin %s", tin))
+	}
+	payee.TIN, payee.TypeOfTIN = "071234567", config.TinType1
+	c.Assert(utils.ErrorCode(payee.Validate()), check.Equals, utils.CodeInvalidTIN)
+
+	// TINs that aren't nine digits are left to the field validation
+	payee.TIN = "1234"
+	c.Assert(payee.ValidateTIN(), check.IsNil)
+
+	transmitter := &TRecord{}
+	c.Assert(json.Unmarshal(t.tRecordJson, transmitter), check.IsNil)
+	transmitter.TIN = "000000001"
+	c.Assert(utils.ErrorCode(transmitter.Validate()), check.Equals, utils.CodeInvalidTIN)
+
+	payer := &ARecord{}
+	c.Assert(json.Unmarshal(t.aRecordJson, payer), check.IsNil)
+	payer.TIN = "000123456"
+	c.Assert(utils.ErrorCode(payer.Validate()), check.Equals, utils.CodeInvalidTIN)
+}
+
+func (t *RecordTest) TestDerivedTypeOfTIN(c *check.C) {
+	cases := []struct {
+		tin      string
+		name     string
+		expected string
+	}{
+		{"962701234", "JOHN SMITH", config.TinType2},
+		{"912701234", "JOHN SMITH", ""},
+		{"123001234", "ACME", config.TinType1},
+		{"071234567", "JOHN SMITH", config.TinType2},
+		{"123456789", "SMITH, JOHN", config.TinType2},
+		{"123456789", "ACME TOOLS INC", config.TinType1},
+		{"123456789", "ESTATE OF JOHN SMITH", config.TinType1},
+		{"123456789", "JOHN SMITH", ""},
+		{"000000001", "ACME TOOLS INC", ""},
+		{"", "ACME TOOLS INC", ""},
+	}
+	for _, item := range cases {
+		payee := &BRecord{TIN: item.tin, FirstPayeeNameLine: item.name}
+		c.Assert(payee.DerivedTypeOfTIN(), check.Equals, item.expected, check.Commentf("tin %s", item.tin))
+	}
+}
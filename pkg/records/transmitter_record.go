@@ -166,13 +166,13 @@ func (r *TRecord) Parse(buf []byte) error {
 		return utils.ErrValidField
 	}
 
-	return utils.ParseValue(fields, config.TRecordLayout, record)
+	return utils.ParseValue(fields, config.RecordLayout(config.LayoutYear(paymentYear(record), priorYearData(record)), config.TRecordType), record)
 }
 
 // Ascii returns fire ascii of “T” record
 func (r *TRecord) Ascii() []byte {
 	var buf bytes.Buffer
-	records := config.ToSpecifications(config.TRecordLayout)
+	records := config.ToSpecifications(r.layout())
 	fields := reflect.ValueOf(r).Elem()
 	if !fields.IsValid() {
 		return nil
@@ -189,7 +189,12 @@ func (r *TRecord) Ascii() []byte {
 
 // Validate performs some checks on the record and returns an error if not Validated
 func (r *TRecord) Validate() error {
-	return utils.Validate(r, config.TRecordLayout, config.TRecordType)
+	return utils.Validate(r, r.layout(), config.TRecordType)
+}
+
+// ValidateFields performs the checks of Validate and returns every field error
+func (r *TRecord) ValidateFields() []*utils.FieldError {
+	return utils.ValidateFields(r, r.layout(), config.TRecordType)
 }
 
 // SequenceNumber returns sequence number of the record
@@ -202,6 +207,16 @@ func (r *TRecord) SetSequenceNumber(number int) {
 	r.RecordSequenceNumber = number
 }
 
+// TaxYear returns tax year of the record that selects its layout revision,
+// prior year data uses the current revision
+func (r *TRecord) TaxYear() int {
+	return config.LayoutYear(r.PaymentYear, r.PriorYearDataIndicator == config.PriorYearDataIndicator)
+}
+
+func (r *TRecord) layout() map[string]config.SpecField {
+	return config.RecordLayout(r.TaxYear(), config.TRecordType)
+}
+
 // customized field validation functions
 // function name should be "Validate" + field name
 
@@ -212,6 +227,14 @@ func (r *TRecord) ValidateRecordSequenceNumber() error {
 	return nil
 }
 
+func (r *TRecord) ValidateTIN() error {
+	// TINs that aren't nine digits are left to the field validation
+	if len(r.TIN) != 9 || utils.IsNumeric(r.TIN) != nil {
+		return nil
+	}
+	return utils.ValidateTIN(r.TIN, "")
+}
+
 func (r *TRecord) ValidatePriorYearDataIndicator() error {
 	// “P” or a blank.
 	if r.PriorYearDataIndicator == config.PriorYearDataIndicator || len(r.PriorYearDataIndicator) == 0 {
@@ -0,0 +1,191 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package records1042s
+
+import (
+	"bytes"
+	"reflect"
+	"unicode/utf8"
+
+	"github.com/moov-io/irs/pkg/config"
+	"github.com/moov-io/irs/pkg/utils"
+)
+
+type WRecord struct {
+	// Required. Enter “W.”
+	RecordType string `json:"record_type" validate:"required"`
+
+	// Required. Enter “0” (zero) for an original return or “1” (one)
+	// for an amended return.
+	ReturnTypeIndicator string `json:"return_type_indicator" validate:"required"`
+
+	// Enter “1” (one) if the withholding agent is reporting on a
+	// pro-rata basis, “0” (zero) or a blank otherwise.
+	ProRataBasisReporting string `json:"pro_rata_basis_reporting"`
+
+	// Required. Enter the nine-digit U.S. Taxpayer Identification
+	// Number (TIN) of the withholding agent. Do not enter hyphens.
+	TIN string `json:"withholding_agent_tin" validate:"required"`
+
+	// Enter the chapter 3 status code of the withholding agent.
+	Chapter3StatusCode string `json:"chapter3_status_code"`
+
+	// Enter the chapter 4 status code of the withholding agent.
+	Chapter4StatusCode string `json:"chapter4_status_code"`
+
+	// Required. Enter the name of the withholding agent. Left justify
+	// the information and fill unused positions with blanks.
+	Name1 string `json:"name_line_1" validate:"required"`
+
+	// Enter additional name information, otherwise enter blanks.
+	Name2 string `json:"name_line_2"`
+
+	// Enter additional name information, otherwise enter blanks.
+	Name3 string `json:"name_line_3"`
+
+	// Required. Enter the mailing address of the withholding agent.
+	AddressLine1 string `json:"address_line_1" validate:"required"`
+
+	// Enter additional address information, otherwise enter blanks.
+	AddressLine2 string `json:"address_line_2"`
+
+	// Required. Enter the city, town or post office.
+	City string `json:"city" validate:"required"`
+
+	// Enter the U.S. Postal Service state abbreviation of a U.S.
+	// address, otherwise enter blanks.
+	StateCode string `json:"state_code"`
+
+	// Enter the province code of a Canadian address, otherwise enter
+	// blanks.
+	ProvinceCode string `json:"province_code"`
+
+	// Enter the country code of a foreign address, otherwise enter
+	// blanks.
+	CountryCode string `json:"country_code"`
+
+	// Enter the ZIP code of a U.S. address or the postal code of a
+	// foreign address.
+	PostalCode string `json:"postal_code"`
+
+	// Enter the name of the person to contact about the returns of
+	// the withholding agent.
+	ContactName string `json:"contact_name"`
+
+	// Enter the department title of the contact.
+	ContactDepartmentTitle string `json:"contact_department_title"`
+
+	// Enter the telephone number and extension of the contact. Omit
+	// hyphens.
+	ContactTelephoneNumber string `json:"contact_telephone_number_and_ext"`
+
+	// Enter “1” (one) if this is the last year the withholding agent
+	// will file Form 1042-S, otherwise enter a blank.
+	FinalReturnIndicator string `json:"final_return_indicator"`
+
+	// Enter the Global Intermediary Identification Number (GIIN) of the
+	// withholding agent, otherwise enter blanks.
+	GIIN string `json:"giin"`
+
+	// Required. Enter the number of the record as it appears within
+	// the file. Right justify numbers with leading zeros.
+	RecordSequenceNumber int `json:"record_sequence_number" validate:"required"`
+}
+
+// NewWRecord returns a new withholding agent “W” record
+func NewWRecord() *WRecord {
+	return &WRecord{RecordType: config.WRecordType}
+}
+
+// Type returns type of “W” record
+func (r *WRecord) Type() string {
+	return r.RecordType
+}
+
+// Parse parses the “W” record from Form 1042-S ascii
+func (r *WRecord) Parse(buf []byte) error {
+	record := string(buf)
+	if utf8.RuneCountInString(record) != config.RecordLength1042S {
+		return utils.ErrRecordLength
+	}
+
+	fields := reflect.ValueOf(r).Elem()
+	if !fields.IsValid() {
+		return utils.ErrValidField
+	}
+
+	return utils.ParseValue(fields, r.layout(), record)
+}
+
+// Ascii returns Form 1042-S ascii of “W” record
+func (r *WRecord) Ascii() []byte {
+	var buf bytes.Buffer
+	records := config.ToSpecifications(r.layout())
+	fields := reflect.ValueOf(r).Elem()
+	if !fields.IsValid() {
+		return nil
+	}
+
+	buf.Grow(config.RecordLength1042S)
+	for _, spec := range records {
+		value := utils.ToString(spec.Field, fields.FieldByName(spec.Name))
+		buf.WriteString(value)
+	}
+
+	return buf.Bytes()
+}
+
+// Validate performs some checks on the record and returns an error if not Validated
+func (r *WRecord) Validate() error {
+	return utils.Validate(r, r.layout(), config.WRecordType)
+}
+
+// ValidateFields performs the checks of Validate and returns every field error
+func (r *WRecord) ValidateFields() []*utils.FieldError {
+	return utils.ValidateFields(r, r.layout(), config.WRecordType)
+}
+
+// SequenceNumber returns sequence number of the record
+func (r *WRecord) SequenceNumber() int {
+	return r.RecordSequenceNumber
+}
+
+// SequenceNumber set sequence number of the record
+func (r *WRecord) SetSequenceNumber(number int) {
+	r.RecordSequenceNumber = number
+}
+
+func (r *WRecord) layout() map[string]config.SpecField {
+	return config.Record1042SLayouts[config.WRecordType]
+}
+
+// customized field validation functions
+// function name should be "Validate" + field name
+
+func (r *WRecord) ValidateRecordSequenceNumber() error {
+	if r.RecordSequenceNumber < 2 {
+		return utils.NewErrValidValue("sequence number")
+	}
+	return nil
+}
+
+func (r *WRecord) ValidateReturnTypeIndicator() error {
+	return validateReturnTypeIndicator(r.ReturnTypeIndicator)
+}
+
+func (r *WRecord) ValidateProRataBasisReporting() error {
+	return validateProRataBasisReporting(r.ProRataBasisReporting)
+}
+
+func (r *WRecord) ValidateStateCode() error {
+	return validateStateCode(r.StateCode, "state code")
+}
+
+func (r *WRecord) ValidateFinalReturnIndicator() error {
+	if r.FinalReturnIndicator == config.GeneralOneIndicator || len(r.FinalReturnIndicator) == 0 {
+		return nil
+	}
+	return utils.NewErrValidValue("final return indicator")
+}
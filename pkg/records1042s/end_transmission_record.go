@@ -0,0 +1,105 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package records1042s
+
+import (
+	"bytes"
+	"reflect"
+	"unicode/utf8"
+
+	"github.com/moov-io/irs/pkg/config"
+	"github.com/moov-io/irs/pkg/utils"
+)
+
+type FRecord struct {
+	// Required. Enter “F.”
+	RecordType string `json:"record_type" validate:"required"`
+
+	// Required. Enter the total number of “W” Records in the file.
+	// Right justify the information and fill unused positions with
+	// zeros.
+	NumberWRecords int `json:"number_of_w_records" validate:"required"`
+
+	// Required. Enter the number of the record as it appears within
+	// the file. Right justify numbers with leading zeros.
+	RecordSequenceNumber int `json:"record_sequence_number" validate:"required"`
+}
+
+// NewFRecord returns a new end of transmission “F” record
+func NewFRecord() *FRecord {
+	return &FRecord{RecordType: config.FRecordType}
+}
+
+// Type returns type of “F” record
+func (r *FRecord) Type() string {
+	return r.RecordType
+}
+
+// Parse parses the “F” record from Form 1042-S ascii
+func (r *FRecord) Parse(buf []byte) error {
+	record := string(buf)
+	if utf8.RuneCountInString(record) != config.RecordLength1042S {
+		return utils.ErrRecordLength
+	}
+
+	fields := reflect.ValueOf(r).Elem()
+	if !fields.IsValid() {
+		return utils.ErrValidField
+	}
+
+	return utils.ParseValue(fields, r.layout(), record)
+}
+
+// Ascii returns Form 1042-S ascii of “F” record
+func (r *FRecord) Ascii() []byte {
+	var buf bytes.Buffer
+	records := config.ToSpecifications(r.layout())
+	fields := reflect.ValueOf(r).Elem()
+	if !fields.IsValid() {
+		return nil
+	}
+
+	buf.Grow(config.RecordLength1042S)
+	for _, spec := range records {
+		value := utils.ToString(spec.Field, fields.FieldByName(spec.Name))
+		buf.WriteString(value)
+	}
+
+	return buf.Bytes()
+}
+
+// Validate performs some checks on the record and returns an error if not Validated
+func (r *FRecord) Validate() error {
+	return utils.Validate(r, r.layout(), config.FRecordType)
+}
+
+// ValidateFields performs the checks of Validate and returns every field error
+func (r *FRecord) ValidateFields() []*utils.FieldError {
+	return utils.ValidateFields(r, r.layout(), config.FRecordType)
+}
+
+// SequenceNumber returns sequence number of the record
+func (r *FRecord) SequenceNumber() int {
+	return r.RecordSequenceNumber
+}
+
+// SequenceNumber set sequence number of the record
+func (r *FRecord) SetSequenceNumber(number int) {
+	r.RecordSequenceNumber = number
+}
+
+func (r *FRecord) layout() map[string]config.SpecField {
+	return config.Record1042SLayouts[config.FRecordType]
+}
+
+// customized field validation functions
+// function name should be "Validate" + field name
+
+func (r *FRecord) ValidateRecordSequenceNumber() error {
+	if r.RecordSequenceNumber < 2 {
+		return utils.NewErrValidValue("sequence number")
+	}
+	return nil
+}
@@ -0,0 +1,290 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package records1042s
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/moov-io/irs/pkg/config"
+	"github.com/moov-io/irs/pkg/utils"
+)
+
+// File contains the records of a Form 1042-S file
+type File struct {
+	Transmitter    *TRecord            `json:"transmitter"`
+	Agents         []*WithholdingAgent `json:"withholding_agents"`
+	EndTransmitter *FRecord            `json:"end_transmitter"`
+}
+
+// WithholdingAgent contains the “W” record of a withholding agent, the “Q” records
+// of its recipients and its reconciliation “C” record
+type WithholdingAgent struct {
+	Agent          *WRecord   `json:"agent"`
+	Recipients     []*QRecord `json:"recipients"`
+	Reconciliation *CRecord   `json:"reconciliation"`
+}
+
+// NewFile constructs a Form 1042-S file template
+func NewFile() *File {
+	return &File{
+		Transmitter:    NewTRecord(),
+		EndTransmitter: NewFRecord(),
+	}
+}
+
+// IsFile returns true if the contents are a Form 1042-S file of json or ascii format
+//
+// A json file has withholding agents and an ascii file has a “W” record after
+// a 1020 position “T” record, the Pub 1220 “A” record would follow the first 750
+// positions.
+func IsFile(buf []byte) bool {
+	if json.Valid(buf) {
+		contents := make(map[string]json.RawMessage)
+		if err := json.Unmarshal(buf, &contents); err != nil {
+			return false
+		}
+		_, ok := contents["withholding_agents"]
+		return ok
+	}
+	return len(buf) > config.RecordLength1042S &&
+		string(buf[0]) == config.TRecordType &&
+		string(buf[config.RecordLength]) != config.ARecordType &&
+		string(buf[config.RecordLength1042S]) == config.WRecordType
+}
+
+// CreateFile attempts to parse Form 1042-S file contents of json or ascii format
+func CreateFile(buf []byte) (*File, error) {
+	f := &File{}
+	if json.Valid(buf) {
+		return f, json.Unmarshal(buf, f)
+	}
+	return f, f.Parse(buf)
+}
+
+// Parse parses the records of a Form 1042-S ascii file
+//
+// Positions 1019-1020 of every record may be a carriage return and line feed.
+func (f *File) Parse(buf []byte) error {
+	f.Transmitter, f.Agents, f.EndTransmitter = nil, nil, nil
+
+	var agent *WithholdingAgent
+	for index := 0; f.EndTransmitter == nil; index++ {
+		offset := index * config.RecordLength1042S
+		if offset >= len(buf) {
+			return utils.ErrIncompleteFile
+		}
+		if offset+config.RecordLength1042S > len(buf) {
+			return fmt.Errorf("record %d: %w", index+1, utils.ErrInvalidAscii)
+		}
+		line := recordLine(buf[offset : offset+config.RecordLength1042S])
+
+		record, err := f.nextRecord(string(line[0]), &agent)
+		if err != nil {
+			return fmt.Errorf("record %d: %w", index+1, err)
+		}
+		if err = record.Parse(line); err != nil {
+			return fmt.Errorf("record %d: %w", index+1, err)
+		}
+	}
+
+	return nil
+}
+
+// nextRecord returns a new record of the type and adds it to the file,
+// the record type must follow the previous record
+func (f *File) nextRecord(recordType string, agent **WithholdingAgent) (Record, error) {
+	current := *agent
+	switch {
+	case recordType == config.TRecordType && f.Transmitter == nil:
+		f.Transmitter = &TRecord{}
+		return f.Transmitter, nil
+	case recordType == config.WRecordType && f.Transmitter != nil && current == nil:
+		current = &WithholdingAgent{Agent: &WRecord{}}
+		f.Agents = append(f.Agents, current)
+		*agent = current
+		return current.Agent, nil
+	case recordType == config.QRecordType && current != nil:
+		recipient := &QRecord{}
+		current.Recipients = append(current.Recipients, recipient)
+		return recipient, nil
+	case recordType == config.CRecordType && current != nil:
+		current.Reconciliation = &CRecord{}
+		*agent = nil
+		return current.Reconciliation, nil
+	case recordType == config.FRecordType && f.Transmitter != nil && current == nil:
+		f.EndTransmitter = &FRecord{}
+		return f.EndTransmitter, nil
+	}
+	return nil, utils.ErrUnexpectedRecordOrder
+}
+
+// recordLine returns the record with blanks instead of a carriage return and line feed
+func recordLine(buf []byte) []byte {
+	if !bytes.HasSuffix(buf, []byte("\r\n")) {
+		return buf
+	}
+	line := make([]byte, len(buf))
+	copy(line, buf)
+	copy(line[len(line)-2:], "  ")
+	return line
+}
+
+// Ascii returns the Form 1042-S ascii file
+func (f *File) Ascii() []byte {
+	var buf bytes.Buffer
+	for _, record := range f.records() {
+		buf.Grow(config.RecordLength1042S)
+		buf.Write(record.Ascii())
+	}
+	return buf.Bytes()
+}
+
+// records returns the records of the file in file order
+func (f *File) records() []Record {
+	list := make([]Record, 0)
+	if f.Transmitter != nil {
+		list = append(list, f.Transmitter)
+	}
+	for _, agent := range f.Agents {
+		if agent == nil {
+			continue
+		}
+		if agent.Agent != nil {
+			list = append(list, agent.Agent)
+		}
+		for _, recipient := range agent.Recipients {
+			if recipient != nil {
+				list = append(list, recipient)
+			}
+		}
+		if agent.Reconciliation != nil {
+			list = append(list, agent.Reconciliation)
+		}
+	}
+	if f.EndTransmitter != nil {
+		list = append(list, f.EndTransmitter)
+	}
+	return list
+}
+
+// Validate performs some checks on the file and returns an error if not Validated
+//
+// Records are validated in file order, then record sequence numbers, the number of
+// “Q” records and totals of each “C” record and the number of “W” records of the
+// “F” record.
+func (f *File) Validate() error {
+	if err := f.validateRecords(); err != nil {
+		return err
+	}
+
+	for index, record := range f.records() {
+		if record.SequenceNumber() != index+1 {
+			return utils.NewErrRecordSequenceNumber(record.Type())
+		}
+	}
+
+	for _, agent := range f.Agents {
+		if err := agent.validateTotals(); err != nil {
+			return err
+		}
+	}
+
+	if f.EndTransmitter.NumberWRecords != len(f.Agents) {
+		return utils.ErrInvalidNumberPayers
+	}
+	return nil
+}
+
+func (f *File) validateRecords() error {
+	if f.Transmitter == nil || f.EndTransmitter == nil {
+		return utils.ErrInvalidFile
+	}
+	if len(f.Agents) == 0 {
+		return utils.ErrNonExistPayer
+	}
+	for _, agent := range f.Agents {
+		if agent == nil || agent.Agent == nil {
+			return utils.ErrNonExistPayer
+		}
+		if len(agent.Recipients) == 0 {
+			return utils.ErrNonExistPayee
+		}
+		for _, recipient := range agent.Recipients {
+			if recipient == nil {
+				return utils.ErrNonExistPayee
+			}
+		}
+		if agent.Reconciliation == nil {
+			return utils.ErrNonExistEndPayer
+		}
+	}
+
+	for _, record := range f.records() {
+		if err := record.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Finalize recomputes the number of records, totals of “C” records and record sequence numbers
+//
+// Missing “C” records are added and every record sequence number is assigned in file order.
+func (f *File) Finalize() error {
+	if f.Transmitter == nil || f.EndTransmitter == nil {
+		return utils.ErrInvalidFile
+	}
+
+	for _, agent := range f.Agents {
+		if agent == nil || agent.Agent == nil {
+			return utils.ErrNonExistPayer
+		}
+		if agent.Reconciliation == nil {
+			agent.Reconciliation = NewCRecord()
+		}
+		count, gross, withheld, credit := agent.totals()
+		agent.Reconciliation.TotalQRecords = count
+		agent.Reconciliation.TotalGrossIncome = gross
+		agent.Reconciliation.TotalFederalTaxWithheld = withheld
+		agent.Reconciliation.TotalWithholdingCredit = credit
+	}
+	f.EndTransmitter.NumberWRecords = len(f.Agents)
+
+	for index, record := range f.records() {
+		record.SetSequenceNumber(index + 1)
+	}
+	return nil
+}
+
+// totals returns the number of “Q” records and totals of their amounts
+func (a *WithholdingAgent) totals() (count, gross, withheld, credit int) {
+	for _, recipient := range a.Recipients {
+		if recipient == nil {
+			continue
+		}
+		count++
+		gross += recipient.GrossIncome
+		withheld += recipient.FederalTaxWithheld
+		credit += recipient.TotalWithholdingCredit
+	}
+	return count, gross, withheld, credit
+}
+
+// validateTotals checks the number of “Q” records and totals of the “C” record
+func (a *WithholdingAgent) validateTotals() error {
+	count, gross, withheld, credit := a.totals()
+	reconciliation := a.Reconciliation
+	if reconciliation.TotalQRecords != count {
+		return utils.ErrInvalidNumberPayees
+	}
+	if reconciliation.TotalGrossIncome != gross ||
+		reconciliation.TotalFederalTaxWithheld != withheld ||
+		reconciliation.TotalWithholdingCredit != credit {
+		return utils.ErrInvalidTotalAmounts
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package records1042s
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/check.v1"
+
+	"github.com/moov-io/irs/pkg/config"
+	"github.com/moov-io/irs/pkg/utils"
+)
+
+func (t *RecordTest) TestIsFile(c *check.C) {
+	c.Assert(IsFile(t.fileJson), check.Equals, true)
+	c.Assert(IsFile(t.fileAscii), check.Equals, true)
+
+	pub1220Json, err := os.ReadFile(filepath.Join("..", "..", "test", "testdata", "oneTransactionFile.json"))
+	c.Assert(err, check.IsNil)
+	c.Assert(IsFile(pub1220Json), check.Equals, false)
+	pub1220Ascii, err := os.ReadFile(filepath.Join("..", "..", "test", "testdata", "oneTransactionFile.ascii"))
+	c.Assert(err, check.IsNil)
+	c.Assert(IsFile(pub1220Ascii), check.Equals, false)
+	c.Assert(IsFile(nil), check.Equals, false)
+}
+
+func (t *RecordTest) TestFileJsonAndAscii(c *check.C) {
+	f, err := CreateFile(t.fileJson)
+	c.Assert(err, check.IsNil)
+	c.Assert(f.Validate(), check.IsNil)
+	c.Assert(string(f.Ascii()), check.Equals, string(t.fileAscii))
+
+	f, err = CreateFile(t.fileAscii)
+	c.Assert(err, check.IsNil)
+	c.Assert(f.Validate(), check.IsNil)
+	c.Assert(f.Agents, check.HasLen, 1)
+	c.Assert(f.Agents[0].Recipients, check.HasLen, 1)
+
+	buf, err := json.Marshal(f)
+	c.Assert(err, check.IsNil)
+	f, err = CreateFile(buf)
+	c.Assert(err, check.IsNil)
+	c.Assert(string(f.Ascii()), check.Equals, string(t.fileAscii))
+}
+
+func (t *RecordTest) TestParseWithLineFeeds(c *check.C) {
+	var buf bytes.Buffer
+	for offset := 0; offset < len(t.fileAscii); offset += config.RecordLength1042S {
+		buf.Write(t.fileAscii[offset : offset+config.RecordLength1042S-2])
+		buf.WriteString("\r\n")
+	}
+
+	f := NewFile()
+	c.Assert(f.Parse(buf.Bytes()), check.IsNil)
+	c.Assert(f.Validate(), check.IsNil)
+	c.Assert(string(f.Ascii()), check.Equals, string(t.fileAscii))
+}
+
+func (t *RecordTest) TestParseWithError(c *check.C) {
+	f := NewFile()
+	c.Assert(errors.Is(f.Parse(t.fileAscii[:len(t.fileAscii)-1]), utils.ErrInvalidAscii), check.Equals, true)
+	c.Assert(f.Parse(t.fileAscii[:4*config.RecordLength1042S]), check.Equals, utils.ErrIncompleteFile)
+
+	// “Q” record without a “W” record
+	buf := append([]byte{}, t.fileAscii[:config.RecordLength1042S]...)
+	buf = append(buf, t.fileAscii[2*config.RecordLength1042S:]...)
+	c.Assert(errors.Is(f.Parse(buf), utils.ErrUnexpectedRecordOrder), check.Equals, true)
+
+	// “F” record without a “C” record
+	buf = append([]byte{}, t.fileAscii[:3*config.RecordLength1042S]...)
+	buf = append(buf, t.fileAscii[4*config.RecordLength1042S:]...)
+	c.Assert(errors.Is(f.Parse(buf), utils.ErrUnexpectedRecordOrder), check.Equals, true)
+}
+
+func (t *RecordTest) TestValidateWithError(c *check.C) {
+	f, err := CreateFile(t.fileJson)
+	c.Assert(err, check.IsNil)
+	f.Agents[0].Recipients[0].GrossIncome++
+	c.Assert(f.Validate(), check.Equals, utils.ErrInvalidTotalAmounts)
+	f.Agents[0].Reconciliation.TotalQRecords++
+	c.Assert(f.Validate(), check.Equals, utils.ErrInvalidNumberPayees)
+	c.Assert(f.Finalize(), check.IsNil)
+	c.Assert(f.Validate(), check.IsNil)
+
+	f.EndTransmitter.NumberWRecords = 2
+	c.Assert(f.Validate(), check.Equals, utils.ErrInvalidNumberPayers)
+	c.Assert(f.Finalize(), check.IsNil)
+
+	f.Agents[0].Reconciliation.SetSequenceNumber(5)
+	c.Assert(f.Validate(), check.NotNil)
+
+	f.Agents[0].Reconciliation = nil
+	c.Assert(f.Validate(), check.Equals, utils.ErrNonExistEndPayer)
+	f.Agents[0].Recipients = nil
+	c.Assert(f.Validate(), check.Equals, utils.ErrNonExistPayee)
+	f.Agents = nil
+	c.Assert(f.Validate(), check.Equals, utils.ErrNonExistPayer)
+	f.EndTransmitter = nil
+	c.Assert(f.Validate(), check.Equals, utils.ErrInvalidFile)
+	c.Assert(f.Finalize(), check.Equals, utils.ErrInvalidFile)
+}
+
+func (t *RecordTest) TestFinalize(c *check.C) {
+	f, err := CreateFile(t.fileJson)
+	c.Assert(err, check.IsNil)
+	recipient := *f.Agents[0].Recipients[0]
+	recipient.GrossIncome = 500
+	recipient.FederalTaxWithheld = 150
+	recipient.TotalWithholdingCredit = 150
+	f.Agents[0].Recipients = append(f.Agents[0].Recipients, &recipient)
+	f.Agents[0].Reconciliation = nil
+
+	c.Assert(f.Finalize(), check.IsNil)
+	c.Assert(f.Validate(), check.IsNil)
+	reconciliation := f.Agents[0].Reconciliation
+	c.Assert(reconciliation.TotalQRecords, check.Equals, 2)
+	c.Assert(reconciliation.TotalGrossIncome, check.Equals, 1500)
+	c.Assert(reconciliation.TotalFederalTaxWithheld, check.Equals, 450)
+	c.Assert(reconciliation.SequenceNumber(), check.Equals, 5)
+	c.Assert(f.EndTransmitter.SequenceNumber(), check.Equals, 6)
+}
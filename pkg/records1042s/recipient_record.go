@@ -0,0 +1,268 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package records1042s
+
+import (
+	"bytes"
+	"reflect"
+	"time"
+	"unicode/utf8"
+
+	"github.com/moov-io/irs/pkg/config"
+	"github.com/moov-io/irs/pkg/utils"
+)
+
+type QRecord struct {
+	// Required. Enter “Q.”
+	RecordType string `json:"record_type" validate:"required"`
+
+	// Required. Enter “0” (zero) for an original return or “1” (one)
+	// for an amended return.
+	ReturnTypeIndicator string `json:"return_type_indicator" validate:"required"`
+
+	// Enter “1” (one) if the return is reported on a pro-rata basis,
+	// “0” (zero) or a blank otherwise.
+	ProRataBasisReporting string `json:"pro_rata_basis_reporting"`
+
+	// Required. Enter the unique number of the form, which must not
+	// be used for any other form of the withholding agent for the tax
+	// year. An amended form has the identifier of the original form.
+	UniqueFormIdentifier string `json:"unique_form_identifier" validate:"required"`
+
+	// Enter the number of the amendment of an amended form, zero for
+	// an original form.
+	AmendmentNumber int `json:"amendment_number"`
+
+	// Required. Enter the two-digit income code of the payment.
+	IncomeCode string `json:"income_code" validate:"required"`
+
+	// Enter the gross income paid to the recipient in whole dollars.
+	// Right justify the information and fill unused positions with
+	// zeros.
+	GrossIncome int `json:"gross_income"`
+
+	// Enter the withholding allowance in whole dollars.
+	WithholdingAllowance int `json:"withholding_allowance"`
+
+	// Enter the net income in whole dollars.
+	NetIncome int `json:"net_income"`
+
+	// Enter the tax rate with two decimal positions and without a
+	// decimal point, “3000” for 30 percent.
+	TaxRate int `json:"tax_rate"`
+
+	// Enter the exemption code if the tax rate is zero or reduced,
+	// otherwise enter blanks.
+	ExemptionCode string `json:"exemption_code"`
+
+	// Required. Enter “3” (three) for withholding under chapter 3 or
+	// “4” (four) for withholding under chapter 4.
+	ChapterIndicator string `json:"chapter_indicator" validate:"required"`
+
+	// Enter the U.S. federal tax withheld in whole dollars.
+	FederalTaxWithheld int `json:"federal_tax_withheld"`
+
+	// Enter the tax withheld by other agents in whole dollars.
+	WithholdingByOtherAgents int `json:"withholding_by_other_agents"`
+
+	// Enter the total withholding credit in whole dollars.
+	TotalWithholdingCredit int `json:"total_withholding_credit"`
+
+	// Enter the amount repaid to the recipient in whole dollars.
+	AmountRepaid int `json:"amount_repaid"`
+
+	// Enter the nine-digit U.S. TIN of the recipient, otherwise enter
+	// blanks.
+	TIN string `json:"recipient_tin"`
+
+	// Enter the type of the U.S. TIN of the recipient.
+	// 0: No TIN
+	// 1: SSN or ITIN
+	// 2: EIN
+	// 3: QI-EIN, WP-EIN or WT-EIN
+	TINType string `json:"recipient_tin_type"`
+
+	// Enter the foreign tax identifying number of the recipient,
+	// otherwise enter blanks.
+	ForeignTIN string `json:"recipient_foreign_tin"`
+
+	// Enter the Global Intermediary Identification Number (GIIN) of the
+	// recipient, otherwise enter blanks.
+	GIIN string `json:"recipient_giin"`
+
+	// Enter the chapter 3 status code of the recipient.
+	Chapter3StatusCode string `json:"chapter3_status_code"`
+
+	// Enter the chapter 4 status code of the recipient.
+	Chapter4StatusCode string `json:"chapter4_status_code"`
+
+	// Required. Enter the name of the recipient. Left justify the
+	// information and fill unused positions with blanks.
+	Name1 string `json:"name_line_1" validate:"required"`
+
+	// Enter additional name information, otherwise enter blanks.
+	Name2 string `json:"name_line_2"`
+
+	// Required. Enter the mailing address of the recipient.
+	AddressLine1 string `json:"address_line_1" validate:"required"`
+
+	// Enter additional address information, otherwise enter blanks.
+	AddressLine2 string `json:"address_line_2"`
+
+	// Required. Enter the city, town or post office.
+	City string `json:"city" validate:"required"`
+
+	// Enter the U.S. Postal Service state abbreviation of a U.S.
+	// address, otherwise enter blanks.
+	StateCode string `json:"state_code"`
+
+	// Enter the province code of a Canadian address, otherwise enter
+	// blanks.
+	ProvinceCode string `json:"province_code"`
+
+	// Required. Enter the country code of the address of the
+	// recipient.
+	CountryCode string `json:"country_code" validate:"required"`
+
+	// Enter the ZIP code of a U.S. address or the postal code of a
+	// foreign address.
+	PostalCode string `json:"postal_code"`
+
+	// Enter the country code of the residence of the recipient for
+	// tax purposes.
+	CountryOfResidence string `json:"country_of_residence"`
+
+	// Enter the date of birth of an individual recipient in
+	// YYYYMMDD format, otherwise enter blanks.
+	DateOfBirth time.Time `json:"date_of_birth"`
+
+	// Enter the account number of the recipient assigned by the
+	// withholding agent, otherwise enter blanks.
+	AccountNumber string `json:"account_number"`
+
+	// Required. Enter the number of the record as it appears within
+	// the file. Right justify numbers with leading zeros.
+	RecordSequenceNumber int `json:"record_sequence_number" validate:"required"`
+}
+
+// NewQRecord returns a new recipient “Q” record
+func NewQRecord() *QRecord {
+	return &QRecord{RecordType: config.QRecordType}
+}
+
+// Type returns type of “Q” record
+func (r *QRecord) Type() string {
+	return r.RecordType
+}
+
+// Parse parses the “Q” record from Form 1042-S ascii
+func (r *QRecord) Parse(buf []byte) error {
+	record := string(buf)
+	if utf8.RuneCountInString(record) != config.RecordLength1042S {
+		return utils.ErrRecordLength
+	}
+
+	fields := reflect.ValueOf(r).Elem()
+	if !fields.IsValid() {
+		return utils.ErrValidField
+	}
+
+	return utils.ParseValue(fields, r.layout(), record)
+}
+
+// Ascii returns Form 1042-S ascii of “Q” record
+func (r *QRecord) Ascii() []byte {
+	var buf bytes.Buffer
+	records := config.ToSpecifications(r.layout())
+	fields := reflect.ValueOf(r).Elem()
+	if !fields.IsValid() {
+		return nil
+	}
+
+	buf.Grow(config.RecordLength1042S)
+	for _, spec := range records {
+		value := utils.ToString(spec.Field, fields.FieldByName(spec.Name))
+		buf.WriteString(value)
+	}
+
+	return buf.Bytes()
+}
+
+// Validate performs some checks on the record and returns an error if not Validated
+func (r *QRecord) Validate() error {
+	return utils.Validate(r, r.layout(), config.QRecordType)
+}
+
+// ValidateFields performs the checks of Validate and returns every field error
+func (r *QRecord) ValidateFields() []*utils.FieldError {
+	return utils.ValidateFields(r, r.layout(), config.QRecordType)
+}
+
+// SequenceNumber returns sequence number of the record
+func (r *QRecord) SequenceNumber() int {
+	return r.RecordSequenceNumber
+}
+
+// SequenceNumber set sequence number of the record
+func (r *QRecord) SetSequenceNumber(number int) {
+	r.RecordSequenceNumber = number
+}
+
+func (r *QRecord) layout() map[string]config.SpecField {
+	return config.Record1042SLayouts[config.QRecordType]
+}
+
+// customized field validation functions
+// function name should be "Validate" + field name
+
+func (r *QRecord) ValidateRecordSequenceNumber() error {
+	if r.RecordSequenceNumber < 2 {
+		return utils.NewErrValidValue("sequence number")
+	}
+	return nil
+}
+
+func (r *QRecord) ValidateReturnTypeIndicator() error {
+	return validateReturnTypeIndicator(r.ReturnTypeIndicator)
+}
+
+func (r *QRecord) ValidateProRataBasisReporting() error {
+	return validateProRataBasisReporting(r.ProRataBasisReporting)
+}
+
+func (r *QRecord) ValidateIncomeCode() error {
+	if _, ok := config.IncomeCodes1042S[r.IncomeCode]; ok {
+		return nil
+	}
+	return utils.NewErrValidValue("income code")
+}
+
+func (r *QRecord) ValidateTaxRate() error {
+	if r.TaxRate < 0 || r.TaxRate > 10000 {
+		return utils.NewErrValidValue("tax rate")
+	}
+	return nil
+}
+
+func (r *QRecord) ValidateChapterIndicator() error {
+	if r.ChapterIndicator == config.Chapter3Indicator || r.ChapterIndicator == config.Chapter4Indicator {
+		return nil
+	}
+	return utils.NewErrValidValue("chapter indicator")
+}
+
+func (r *QRecord) ValidateTINType() error {
+	if len(r.TINType) == 0 {
+		return nil
+	}
+	if _, ok := config.TINTypes1042S[r.TINType]; ok {
+		return nil
+	}
+	return utils.NewErrValidValue("recipient tin type")
+}
+
+func (r *QRecord) ValidateStateCode() error {
+	return validateStateCode(r.StateCode, "state code")
+}
@@ -0,0 +1,117 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package records1042s
+
+import (
+	"bytes"
+	"reflect"
+	"unicode/utf8"
+
+	"github.com/moov-io/irs/pkg/config"
+	"github.com/moov-io/irs/pkg/utils"
+)
+
+type CRecord struct {
+	// Required. Enter “C.”
+	RecordType string `json:"record_type" validate:"required"`
+
+	// Required. Enter the total number of “Q” Records covered by the
+	// preceding “W” Record. Right justify the information and fill
+	// unused positions with zeros.
+	TotalQRecords int `json:"total_q_records" validate:"required"`
+
+	// Enter the total of the gross income of the “Q” Records covered
+	// by the preceding “W” Record in whole dollars.
+	TotalGrossIncome int `json:"total_gross_income"`
+
+	// Enter the total of the U.S. federal tax withheld of the “Q”
+	// Records covered by the preceding “W” Record in whole dollars.
+	TotalFederalTaxWithheld int `json:"total_federal_tax_withheld"`
+
+	// Enter the total of the total withholding credit of the “Q”
+	// Records covered by the preceding “W” Record in whole dollars.
+	TotalWithholdingCredit int `json:"total_withholding_credit"`
+
+	// Required. Enter the number of the record as it appears within
+	// the file. Right justify numbers with leading zeros.
+	RecordSequenceNumber int `json:"record_sequence_number" validate:"required"`
+}
+
+// NewCRecord returns a new reconciliation “C” record
+func NewCRecord() *CRecord {
+	return &CRecord{RecordType: config.CRecordType}
+}
+
+// Type returns type of “C” record
+func (r *CRecord) Type() string {
+	return r.RecordType
+}
+
+// Parse parses the “C” record from Form 1042-S ascii
+func (r *CRecord) Parse(buf []byte) error {
+	record := string(buf)
+	if utf8.RuneCountInString(record) != config.RecordLength1042S {
+		return utils.ErrRecordLength
+	}
+
+	fields := reflect.ValueOf(r).Elem()
+	if !fields.IsValid() {
+		return utils.ErrValidField
+	}
+
+	return utils.ParseValue(fields, r.layout(), record)
+}
+
+// Ascii returns Form 1042-S ascii of “C” record
+func (r *CRecord) Ascii() []byte {
+	var buf bytes.Buffer
+	records := config.ToSpecifications(r.layout())
+	fields := reflect.ValueOf(r).Elem()
+	if !fields.IsValid() {
+		return nil
+	}
+
+	buf.Grow(config.RecordLength1042S)
+	for _, spec := range records {
+		value := utils.ToString(spec.Field, fields.FieldByName(spec.Name))
+		buf.WriteString(value)
+	}
+
+	return buf.Bytes()
+}
+
+// Validate performs some checks on the record and returns an error if not Validated
+func (r *CRecord) Validate() error {
+	return utils.Validate(r, r.layout(), config.CRecordType)
+}
+
+// ValidateFields performs the checks of Validate and returns every field error
+func (r *CRecord) ValidateFields() []*utils.FieldError {
+	return utils.ValidateFields(r, r.layout(), config.CRecordType)
+}
+
+// SequenceNumber returns sequence number of the record
+func (r *CRecord) SequenceNumber() int {
+	return r.RecordSequenceNumber
+}
+
+// SequenceNumber set sequence number of the record
+func (r *CRecord) SetSequenceNumber(number int) {
+	r.RecordSequenceNumber = number
+}
+
+func (r *CRecord) layout() map[string]config.SpecField {
+	return config.Record1042SLayouts[config.CRecordType]
+}
+
+// customized field validation functions
+// function name should be "Validate" + field name
+
+func (r *CRecord) ValidateRecordSequenceNumber() error {
+	if r.RecordSequenceNumber < 2 {
+		return utils.NewErrValidValue("sequence number")
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+// Package records1042s implements the records of Form 1042-S files of Publication 1187.
+//
+// A Form 1042-S file has a transmitter “T” record, then for each withholding agent
+// a “W” record, its recipient “Q” records and a reconciliation “C” record, and
+// ends with an end of transmission “F” record. Every record is 1020 positions.
+package records1042s
+
+import (
+	"github.com/moov-io/irs/pkg/config"
+	"github.com/moov-io/irs/pkg/utils"
+)
+
+// General record interface of Form 1042-S files
+type Record interface {
+	Type() string
+	SequenceNumber() int
+	SetSequenceNumber(int)
+	Parse([]byte) error
+	Ascii() []byte
+	Validate() error
+}
+
+// FieldValidator is implemented by records that return every field error instead of the first one
+type FieldValidator interface {
+	ValidateFields() []*utils.FieldError
+}
+
+// ValidateFields returns every field error of the record, records that don't implement
+// FieldValidator return the error of Validate
+func ValidateFields(record Record) []*utils.FieldError {
+	if validator, ok := record.(FieldValidator); ok {
+		return validator.ValidateFields()
+	}
+	if err := record.Validate(); err != nil {
+		return []*utils.FieldError{{Err: err, Severity: utils.Severity(err)}}
+	}
+	return nil
+}
+
+// validateStateCode checks the state abbreviation of an address, which is blank for foreign addresses
+func validateStateCode(code, field string) error {
+	if len(code) == 0 {
+		return nil
+	}
+	if _, ok := config.StateAbbreviationCodes[code]; ok {
+		return nil
+	}
+	return utils.NewErrValidValue(field)
+}
+
+// validateReturnTypeIndicator checks the return type indicator of “W” and “Q” records
+func validateReturnTypeIndicator(indicator string) error {
+	if indicator == config.OriginalReturnIndicator1042S || indicator == config.AmendedReturnIndicator1042S {
+		return nil
+	}
+	return utils.NewErrValidValue("return type indicator")
+}
+
+// validateProRataBasisReporting checks the pro-rata basis reporting indicator of “W” and “Q” records
+func validateProRataBasisReporting(indicator string) error {
+	if len(indicator) == 0 || indicator == "0" || indicator == "1" {
+		return nil
+	}
+	return utils.NewErrValidValue("pro-rata basis reporting")
+}
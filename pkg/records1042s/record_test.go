@@ -0,0 +1,111 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package records1042s
+
+import (
+	"errors"
+
+	"gopkg.in/check.v1"
+
+	"github.com/moov-io/irs/pkg/config"
+	"github.com/moov-io/irs/pkg/utils"
+)
+
+// minimalRecord implements Record without the optional interfaces
+type minimalRecord struct {
+	err error
+}
+
+func (r *minimalRecord) Type() string          { return "X" }
+func (r *minimalRecord) SequenceNumber() int   { return 1 }
+func (r *minimalRecord) SetSequenceNumber(int) {}
+func (r *minimalRecord) Parse([]byte) error    { return nil }
+func (r *minimalRecord) Ascii() []byte         { return nil }
+func (r *minimalRecord) Validate() error       { return r.err }
+
+func (t *RecordTest) TestOptionalInterfaces(c *check.C) {
+	r := &minimalRecord{}
+	c.Assert(ValidateFields(r), check.HasLen, 0)
+
+	r.err = utils.ErrInvalidTCC
+	errs := ValidateFields(r)
+	c.Assert(errs, check.HasLen, 1)
+	c.Assert(errs[0].Err, check.Equals, utils.ErrInvalidTCC)
+	c.Assert(errs[0].Severity, check.Equals, utils.SeverityError)
+
+	c.Assert(ValidateFields(&TRecord{}), check.Not(check.HasLen), 0)
+}
+
+func (t *RecordTest) record(index int) []byte {
+	offset := index * config.RecordLength1042S
+	return t.fileAscii[offset : offset+config.RecordLength1042S]
+}
+
+func (t *RecordTest) testRecord(c *check.C, r Record, recordType string, index int) {
+	c.Assert(r.Validate(), check.NotNil)
+	c.Assert(r.Parse(t.record(index)[1:]), check.Equals, utils.ErrRecordLength)
+	c.Assert(r.Parse(t.record(index)), check.IsNil)
+	c.Assert(r.Validate(), check.IsNil)
+	c.Assert(ValidateFields(r), check.HasLen, 0)
+	c.Assert(string(r.Ascii()), check.Equals, string(t.record(index)))
+	c.Assert(r.Type(), check.Equals, recordType)
+	c.Assert(r.SequenceNumber(), check.Equals, index+1)
+	r.SetSequenceNumber(-1)
+	c.Assert(r.Validate(), check.NotNil)
+}
+
+func (t *RecordTest) TestTRecord(c *check.C) {
+	t.testRecord(c, NewTRecord(), config.TRecordType, 0)
+
+	r := NewTRecord()
+	c.Assert(r.Parse(t.record(0)), check.IsNil)
+	r.TCC = "A1B2"
+	c.Assert(errors.Is(r.Validate(), utils.ErrInvalidTCC), check.Equals, true)
+	r.TCC = "A1B2C"
+	r.TestIndicator = "PROD"
+	c.Assert(r.Validate(), check.NotNil)
+	r.TestIndicator = ""
+	r.StateCode = "ZZ"
+	c.Assert(r.Validate(), check.NotNil)
+}
+
+func (t *RecordTest) TestWRecord(c *check.C) {
+	t.testRecord(c, NewWRecord(), config.WRecordType, 1)
+
+	r := NewWRecord()
+	c.Assert(r.Parse(t.record(1)), check.IsNil)
+	r.ReturnTypeIndicator = "2"
+	c.Assert(r.Validate(), check.NotNil)
+	r.ReturnTypeIndicator = config.AmendedReturnIndicator1042S
+	r.ProRataBasisReporting = "2"
+	c.Assert(r.Validate(), check.NotNil)
+}
+
+func (t *RecordTest) TestQRecord(c *check.C) {
+	t.testRecord(c, NewQRecord(), config.QRecordType, 2)
+
+	r := NewQRecord()
+	c.Assert(r.Parse(t.record(2)), check.IsNil)
+	c.Assert(r.DateOfBirth.Year(), check.Equals, 1980)
+	r.IncomeCode = "99"
+	c.Assert(r.Validate(), check.NotNil)
+	r.IncomeCode = "06"
+	r.TaxRate = 10001
+	c.Assert(r.Validate(), check.NotNil)
+	r.TaxRate = 3000
+	r.ChapterIndicator = "5"
+	c.Assert(r.Validate(), check.NotNil)
+	r.ChapterIndicator = config.Chapter4Indicator
+	r.TINType = "4"
+	c.Assert(r.Validate(), check.NotNil)
+}
+
+func (t *RecordTest) TestCRecord(c *check.C) {
+	t.testRecord(c, NewCRecord(), config.CRecordType, 3)
+}
+
+func (t *RecordTest) TestFRecord(c *check.C) {
+	t.testRecord(c, NewFRecord(), config.FRecordType, 4)
+}
@@ -0,0 +1,31 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package records1042s
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) { check.TestingT(t) }
+
+type RecordTest struct {
+	fileJson  []byte
+	fileAscii []byte
+}
+
+var _ = check.Suite(&RecordTest{})
+
+func (t *RecordTest) SetUpSuite(c *check.C) {
+	var err error
+
+	t.fileJson, err = os.ReadFile(filepath.Join("..", "..", "test", "testdata", "form1042s.json"))
+	c.Assert(err, check.IsNil)
+	t.fileAscii, err = os.ReadFile(filepath.Join("..", "..", "test", "testdata", "form1042s.ascii"))
+	c.Assert(err, check.IsNil)
+}
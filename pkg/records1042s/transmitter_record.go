@@ -0,0 +1,189 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package records1042s
+
+import (
+	"bytes"
+	"reflect"
+	"unicode/utf8"
+
+	"github.com/moov-io/irs/pkg/config"
+	"github.com/moov-io/irs/pkg/utils"
+)
+
+type TRecord struct {
+	// Required. Enter “T.”
+	RecordType string `json:"record_type" validate:"required"`
+
+	// Required. Enter “2024” (or the tax year being reported) in
+	// YYYY format.
+	TaxYear int `json:"tax_year" validate:"required"`
+
+	// Enter “P” only if reporting prior year data; otherwise, enter a
+	// blank.
+	PriorYearDataIndicator string `json:"prior_year_data_indicator"`
+
+	// Required. Enter the transmitter’s nine-digit Employer
+	// Identification Number (EIN). Do not enter blanks, hyphens
+	// or alpha characters.
+	TIN string `json:"transmitter_tin" validate:"required"`
+
+	// Required. Enter the name of the transmitter. Left justify the
+	// information and fill unused positions with blanks.
+	TransmitterName string `json:"transmitter_name" validate:"required"`
+
+	// Required. Enter the mailing address of the transmitter.
+	AddressLine1 string `json:"address_line_1" validate:"required"`
+
+	// Enter additional address information, otherwise enter blanks.
+	AddressLine2 string `json:"address_line_2"`
+
+	// Required. Enter the city, town or post office.
+	City string `json:"city" validate:"required"`
+
+	// Enter the U.S. Postal Service state abbreviation of a U.S.
+	// address, otherwise enter blanks.
+	StateCode string `json:"state_code"`
+
+	// Enter the province code of a Canadian address, otherwise enter
+	// blanks.
+	ProvinceCode string `json:"province_code"`
+
+	// Enter the country code of a foreign address, otherwise enter
+	// blanks.
+	CountryCode string `json:"country_code"`
+
+	// Enter the ZIP code of a U.S. address or the postal code of a
+	// foreign address.
+	PostalCode string `json:"postal_code"`
+
+	// Required. Enter the name of the person to contact about the
+	// file.
+	ContactName string `json:"contact_name" validate:"required"`
+
+	// Enter the department title of the contact.
+	ContactDepartmentTitle string `json:"contact_department_title"`
+
+	// Required. Enter the telephone number and extension of the
+	// contact. Omit hyphens.
+	ContactTelephoneNumber string `json:"contact_telephone_number_and_ext" validate:"required"`
+
+	// Enter the email address of the contact.
+	ContactEmailAddress string `json:"contact_email_address"`
+
+	// Required. Enter the five-character alphanumeric Transmitter
+	// Control Code (TCC) assigned by the IRS.
+	TCC string `json:"transmitter_control_code" validate:"required"`
+
+	// Enter “TEST” if this is a test file, otherwise enter blanks.
+	TestIndicator string `json:"test_indicator"`
+
+	// Required. Enter the number of the record as it appears within
+	// the file. The record sequence number for the “T” Record will
+	// always be “1” (one). Right justify numbers with leading zeros.
+	RecordSequenceNumber int `json:"record_sequence_number" validate:"required"`
+}
+
+// NewTRecord returns a new transmitter “T” record
+func NewTRecord() *TRecord {
+	return &TRecord{RecordType: config.TRecordType}
+}
+
+// Type returns type of “T” record
+func (r *TRecord) Type() string {
+	return r.RecordType
+}
+
+// Parse parses the “T” record from Form 1042-S ascii
+func (r *TRecord) Parse(buf []byte) error {
+	record := string(buf)
+	if utf8.RuneCountInString(record) != config.RecordLength1042S {
+		return utils.ErrRecordLength
+	}
+
+	fields := reflect.ValueOf(r).Elem()
+	if !fields.IsValid() {
+		return utils.ErrValidField
+	}
+
+	return utils.ParseValue(fields, r.layout(), record)
+}
+
+// Ascii returns Form 1042-S ascii of “T” record
+func (r *TRecord) Ascii() []byte {
+	var buf bytes.Buffer
+	records := config.ToSpecifications(r.layout())
+	fields := reflect.ValueOf(r).Elem()
+	if !fields.IsValid() {
+		return nil
+	}
+
+	buf.Grow(config.RecordLength1042S)
+	for _, spec := range records {
+		value := utils.ToString(spec.Field, fields.FieldByName(spec.Name))
+		buf.WriteString(value)
+	}
+
+	return buf.Bytes()
+}
+
+// Validate performs some checks on the record and returns an error if not Validated
+func (r *TRecord) Validate() error {
+	return utils.Validate(r, r.layout(), config.TRecordType)
+}
+
+// ValidateFields performs the checks of Validate and returns every field error
+func (r *TRecord) ValidateFields() []*utils.FieldError {
+	return utils.ValidateFields(r, r.layout(), config.TRecordType)
+}
+
+// SequenceNumber returns sequence number of the record
+func (r *TRecord) SequenceNumber() int {
+	return r.RecordSequenceNumber
+}
+
+// SequenceNumber set sequence number of the record
+func (r *TRecord) SetSequenceNumber(number int) {
+	r.RecordSequenceNumber = number
+}
+
+func (r *TRecord) layout() map[string]config.SpecField {
+	return config.Record1042SLayouts[config.TRecordType]
+}
+
+// customized field validation functions
+// function name should be "Validate" + field name
+
+func (r *TRecord) ValidateRecordSequenceNumber() error {
+	if r.RecordSequenceNumber < 1 {
+		return utils.NewErrValidValue("sequence number")
+	}
+	return nil
+}
+
+func (r *TRecord) ValidatePriorYearDataIndicator() error {
+	if r.PriorYearDataIndicator == config.PriorYearDataIndicator || len(r.PriorYearDataIndicator) == 0 {
+		return nil
+	}
+	return utils.NewErrValidValue("prior year data indicator")
+}
+
+func (r *TRecord) ValidateTCC() error {
+	if len(r.TCC) != 5 {
+		return utils.ErrInvalidTCC
+	}
+	return nil
+}
+
+func (r *TRecord) ValidateTestIndicator() error {
+	if r.TestIndicator == config.TestIndicator1042S || len(r.TestIndicator) == 0 {
+		return nil
+	}
+	return utils.NewErrValidValue("test indicator")
+}
+
+func (r *TRecord) ValidateStateCode() error {
+	return validateStateCode(r.StateCode, "state code")
+}
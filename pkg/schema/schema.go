@@ -0,0 +1,323 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+// Package schema generates JSON Schema and OpenAPI components of irs files
+// from the record types and the record layouts of pkg/config.
+package schema
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/moov-io/irs/pkg/config"
+	"github.com/moov-io/irs/pkg/records"
+	"github.com/moov-io/irs/pkg/subrecords"
+)
+
+// Output formats of the generator
+const (
+	FormatJSONSchema = "json-schema"
+	FormatOpenAPI    = "openapi"
+)
+
+// JSONSchemaDialect is the JSON Schema version of generated schemas
+const JSONSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+const (
+	jsonSchemaRefPrefix = "#/$defs/"
+	openAPIRefPrefix    = "#/components/schemas/"
+)
+
+// Schema is a JSON Schema, or an OpenAPI schema object for the subset OpenAPI supports
+type Schema struct {
+	Schema      string             `json:"$schema,omitempty"`
+	Ref         string             `json:"$ref,omitempty"`
+	Title       string             `json:"title,omitempty"`
+	Description string             `json:"description,omitempty"`
+	Type        string             `json:"type,omitempty"`
+	Format      string             `json:"format,omitempty"`
+	Pattern     string             `json:"pattern,omitempty"`
+	MinLength   *int               `json:"minLength,omitempty"`
+	MaxLength   *int               `json:"maxLength,omitempty"`
+	Minimum     *int64             `json:"minimum,omitempty"`
+	Maximum     *int64             `json:"maximum,omitempty"`
+	Const       string             `json:"const,omitempty"`
+	Enum        []string           `json:"enum,omitempty"`
+	Properties  map[string]*Schema `json:"properties,omitempty"`
+	Required    []string           `json:"required,omitempty"`
+	Items       *Schema            `json:"items,omitempty"`
+	AllOf       []*Schema          `json:"allOf,omitempty"`
+	AnyOf       []*Schema          `json:"anyOf,omitempty"`
+	If          *Schema            `json:"if,omitempty"`
+	Then        *Schema            `json:"then,omitempty"`
+	Defs        map[string]*Schema `json:"$defs,omitempty"`
+}
+
+// Components are the components of an OpenAPI document
+type Components struct {
+	Schemas map[string]*Schema `json:"schemas"`
+}
+
+// fieldCodes are the allowed codes of fields by record type or extension block type
+var fieldCodes = map[string]map[string][]string{
+	config.ARecordType: {
+		"TypeOfReturn": codes(config.TypeOfReturns),
+	},
+	config.Sub1097BtcType: {
+		"IssuerIndicator": codes(config.BtcIssuerIndicator),
+		"Code":            codes(config.BtcCode),
+		"BondType":        codes(config.BtcBondType),
+	},
+	config.Sub1099RType: {
+		"DistributionCode": config.DistributionCodes,
+	},
+}
+
+// recordTypes are the records of files in file order, with the names of their schemas
+var recordTypes = []struct {
+	name       string
+	recordType string
+	record     interface{}
+}{
+	{"TRecord", config.TRecordType, &records.TRecord{}},
+	{"ARecord", config.ARecordType, &records.ARecord{}},
+	{"BRecord", config.BRecordType, &records.BRecord{}},
+	{"CRecord", config.CRecordType, &records.CRecord{}},
+	{"KRecord", config.KRecordType, &records.KRecord{}},
+	{"FRecord", config.FRecordType, &records.FRecord{}},
+}
+
+// JSONSchema returns the JSON Schema of json irs files of the tax year
+//
+// Every record and payee “B” record with extension block is a definition of
+// $defs. Payees are checked with the extension block of the type of return of
+// their payer.
+func JSONSchema(taxYear int) *Schema {
+	schema := fileSchema(jsonSchemaRefPrefix)
+	schema.Schema = JSONSchemaDialect
+	schema.Title = fmt.Sprintf("IRS FIRE file (tax year %d)", taxYear)
+	schema.Defs = definitions(taxYear, jsonSchemaRefPrefix)
+
+	person := schema.Defs["PaymentPerson"]
+	for _, code := range codes(config.TypeOfReturns) {
+		subType, ok := subRecordType(config.TypeOfReturns[code])
+		if !ok {
+			continue
+		}
+		person.AllOf = append(person.AllOf, &Schema{
+			If: &Schema{
+				Properties: map[string]*Schema{
+					"payer": {Properties: map[string]*Schema{"type_of_return": {Const: code}}, Required: []string{"type_of_return"}},
+				},
+			},
+			Then: &Schema{
+				Properties: map[string]*Schema{
+					"payees": {Items: &Schema{Ref: jsonSchemaRefPrefix + ComponentName(subType)}},
+				},
+			},
+		})
+	}
+	return schema
+}
+
+// OpenAPIComponents returns the OpenAPI components of json irs files of the tax year
+//
+// The component names are those of api/api.yml. OpenAPI can't express the
+// extension block of a payee by the type of return of its payer, so payees
+// are any payee “B” record with extension block.
+func OpenAPIComponents(taxYear int) *Components {
+	schemas := definitions(taxYear, openAPIRefPrefix)
+	schemas["File"] = fileSchema(openAPIRefPrefix)
+
+	var payees []*Schema
+	for _, subType := range subRecordTypes() {
+		payees = append(payees, &Schema{Ref: openAPIRefPrefix + ComponentName(subType)})
+	}
+	schemas["PaymentPerson"].Properties["payees"].Items = &Schema{AnyOf: payees}
+	return &Components{Schemas: schemas}
+}
+
+// Generate returns the schema of the format for the tax year
+func Generate(format string, taxYear int) (interface{}, error) {
+	switch format {
+	case FormatJSONSchema:
+		return JSONSchema(taxYear), nil
+	case FormatOpenAPI:
+		return map[string]interface{}{"components": OpenAPIComponents(taxYear)}, nil
+	}
+	return nil, fmt.Errorf("unsupported schema format %s", format)
+}
+
+// ComponentName returns the name of the schema of payee “B” records with the extension block type
+//
+// The names are those of api/api.yml, “1099-MISC” is BRecordWith1099Misc.
+func ComponentName(subType string) string {
+	name := strings.ReplaceAll(subType, "-", "")
+	index := strings.IndexFunc(name, func(r rune) bool { return !unicode.IsDigit(r) })
+	if index <= 0 {
+		return "BRecordWith" + name
+	}
+	return "BRecordWith" + name[:index+1] + strings.ToLower(name[index+1:])
+}
+
+// RecordSchema returns the schema of the record with the layout
+//
+// Fields are the exported fields of the record with json names. Strings have
+// the length of their layout field as maximum length, numbers the largest
+// number of that many digits as maximum. Only signed amounts may be negative.
+// Required fields of the layout are required and strings of them may not be
+// blank.
+func RecordSchema(record interface{}, layout map[string]config.SpecField, codes map[string][]string) *Schema {
+	schema := &Schema{Type: "object", Properties: map[string]*Schema{}}
+	recordType := reflect.TypeOf(record)
+	if recordType.Kind() == reflect.Ptr {
+		recordType = recordType.Elem()
+	}
+	for i := 0; i < recordType.NumField(); i++ {
+		field := recordType.Field(i)
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if !field.IsExported() || len(name) == 0 || name == "-" {
+			continue
+		}
+		spec, ok := layout[field.Name]
+		property := fieldSchema(field.Type, spec, ok)
+		if allowed, ok := codes[field.Name]; ok {
+			property.Enum = allowed
+		}
+		if ok && spec.Required == config.Required {
+			schema.Required = append(schema.Required, name)
+			if property.Type == "string" {
+				property.MinLength = intPtr(1)
+			}
+		}
+		schema.Properties[name] = property
+	}
+	return schema
+}
+
+func fileSchema(refPrefix string) *Schema {
+	return &Schema{
+		Type: "object",
+		Properties: map[string]*Schema{
+			"transmitter":     {Ref: refPrefix + "TRecord"},
+			"payment_persons": {Type: "array", Items: &Schema{Ref: refPrefix + "PaymentPerson"}},
+			"end_transmitter": {Ref: refPrefix + "FRecord"},
+		},
+		Required: []string{"transmitter", "end_transmitter"},
+	}
+}
+
+func definitions(taxYear int, refPrefix string) map[string]*Schema {
+	defs := map[string]*Schema{}
+	for _, item := range recordTypes {
+		record := RecordSchema(item.record, config.RecordLayout(taxYear, item.recordType), fieldCodes[item.recordType])
+		record.Properties["record_type"].Enum = []string{item.recordType}
+		defs[item.name] = record
+	}
+
+	for _, subType := range subRecordTypes() {
+		extension, _ := subrecords.NewSubRecord(subType)
+		ext := RecordSchema(extension, config.SubRecordLayout(taxYear, subType), fieldCodes[subType])
+		ext.Title = subType
+		defs[ComponentName(subType)] = &Schema{
+			AllOf: []*Schema{{Ref: refPrefix + "BRecord"}, ext},
+		}
+	}
+
+	defs["PaymentPerson"] = &Schema{
+		Type: "object",
+		Properties: map[string]*Schema{
+			"payer":     {Ref: refPrefix + "ARecord"},
+			"payees":    {Type: "array", Items: &Schema{Ref: refPrefix + "BRecord"}},
+			"end_payer": {Ref: refPrefix + "CRecord"},
+			"states":    {Type: "array", Items: &Schema{Ref: refPrefix + "KRecord"}},
+		},
+		Required: []string{"payer", "end_payer"},
+	}
+	return defs
+}
+
+func fieldSchema(fieldType reflect.Type, spec config.SpecField, hasSpec bool) *Schema {
+	if fieldType == reflect.TypeOf(time.Time{}) {
+		return &Schema{Type: "string", Format: "date-time"}
+	}
+
+	switch fieldType.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		schema := &Schema{Type: "integer", Minimum: int64Ptr(0)}
+		if hasSpec && spec.Length > 0 && spec.Length < 19 {
+			maximum := int64(1)
+			for i := 0; i < spec.Length; i++ {
+				maximum *= 10
+			}
+			schema.Maximum = int64Ptr(maximum - 1)
+			// negative amounts have a sign in the left-most position
+			if spec.Type == config.SignedNumeric {
+				schema.Minimum = int64Ptr(1 - maximum/10)
+			}
+		}
+		return schema
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.String:
+		schema := &Schema{Type: "string"}
+		if !hasSpec {
+			return schema
+		}
+		schema.MaxLength = intPtr(spec.Length)
+		switch spec.Type {
+		case config.Numeric, config.TelephoneNumber:
+			schema.Pattern = "^[0-9]*$"
+		case config.Email:
+			schema.Format = "email"
+		case config.Date:
+			schema.Pattern = "^([0-9]{8})?$"
+		}
+		return schema
+	}
+	return &Schema{}
+}
+
+// subRecordTypes returns the extension block types of the layouts in order
+func subRecordTypes() []string {
+	types := make([]string, 0, len(config.SubRecordLayouts))
+	for subType := range config.SubRecordLayouts {
+		types = append(types, subType)
+	}
+	sort.Strings(types)
+	return types
+}
+
+// subRecordType returns the extension block type of the form of a type of return
+//
+// Forms are named like “W-2G” and “5498-ESA”, some extension block types without hyphen.
+func subRecordType(form string) (string, bool) {
+	for _, subType := range []string{form, strings.ReplaceAll(form, "-", "")} {
+		if _, ok := config.SubRecordLayouts[subType]; ok {
+			return subType, true
+		}
+	}
+	return "", false
+}
+
+func codes(values map[string]string) []string {
+	list := make([]string, 0, len(values))
+	for code := range values {
+		list = append(list, code)
+	}
+	sort.Strings(list)
+	return list
+}
+
+func intPtr(value int) *int {
+	return &value
+}
+
+func int64Ptr(value int64) *int64 {
+	return &value
+}
@@ -0,0 +1,211 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package schema
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/moov-io/irs/pkg/config"
+)
+
+var testDataPath = filepath.Join("..", "..", "test", "testdata")
+
+// fixtureQuirks are fields of fixtures that the json decoder of records accepts
+// but that don't follow the record layouts
+var fixtureQuirks = map[string]string{
+	"payeeRecordWith1099Oid.json": "description",
+	"payeeRecordWith1099S.json":   "date_closing",
+	"payeeRecordWith1099Ltc.json": "date_certified",
+}
+
+func TestComponentName(t *testing.T) {
+	names := map[string]string{
+		config.Sub1099MiscType: "BRecordWith1099Misc",
+		config.Sub1098Type:     "BRecordWith1098",
+		config.Sub5498EsaType:  "BRecordWith5498Esa",
+		config.SubW2GType:      "BRecordWithW2G",
+		config.Sub1099CapType:  "BRecordWith1099Cap",
+	}
+	for subType, name := range names {
+		if got := ComponentName(subType); got != name {
+			t.Errorf("%s: got %s, expected %s", subType, got, name)
+		}
+	}
+}
+
+func TestJSONSchema(t *testing.T) {
+	schema := JSONSchema(config.DefaultTaxYear)
+	if schema.Schema != JSONSchemaDialect {
+		t.Errorf("unexpected dialect %s", schema.Schema)
+	}
+
+	tin := schema.Defs["TRecord"].Properties["transmitter_tin"]
+	if tin.Type != "string" || *tin.MaxLength != 9 || *tin.MinLength != 1 || tin.Pattern != "^[0-9]*$" {
+		t.Errorf("unexpected transmitter tin %+v", tin)
+	}
+	if !contains(schema.Defs["ARecord"].Required, "payer_tin") || contains(schema.Defs["ARecord"].Required, "second_payer_name") {
+		t.Error("unexpected required fields of payer")
+	}
+	if !contains(schema.Defs["ARecord"].Properties["type_of_return"].Enum, "NE") {
+		t.Error("type of return should allow 1099-NEC")
+	}
+	ext := schema.Defs["BRecordWith1099R"].AllOf[1]
+	if len(ext.Properties["distribution_code"].Enum) != len(config.DistributionCodes) {
+		t.Error("distribution code should allow distribution codes")
+	}
+	if !contains(schema.Defs["BRecordWith1097Btc"].AllOf[1].Properties["code"].Enum, "C") {
+		t.Error("1097-BTC code should allow BTC codes")
+	}
+	if date := schema.Defs["BRecordWith1099S"].AllOf[1].Properties["date_closing"]; date.Pattern != "^([0-9]{8})?$" {
+		t.Errorf("unexpected date %+v", date)
+	}
+	if date := schema.Defs["BRecordWith3922"].AllOf[1].Properties["date_option_granted"]; date.Format != "date-time" {
+		t.Errorf("unexpected date %+v", date)
+	}
+
+	found := false
+	for _, condition := range schema.Defs["PaymentPerson"].AllOf {
+		if condition.If.Properties["payer"].Properties["type_of_return"].Const == "A" {
+			found = condition.Then.Properties["payees"].Items.Ref == "#/$defs/BRecordWith1099Misc"
+		}
+	}
+	if !found {
+		t.Error("payees of 1099-MISC payers should have 1099-MISC extension blocks")
+	}
+
+	buf, err := json.Marshal(schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(buf), "#/components/") {
+		t.Error("json schema should refer to $defs")
+	}
+}
+
+func TestOpenAPIComponents(t *testing.T) {
+	components := OpenAPIComponents(config.DefaultTaxYear)
+	for _, name := range []string{"File", "PaymentPerson", "TRecord", "ARecord", "BRecordWith1099Nec", "CRecord", "KRecord", "FRecord"} {
+		if _, ok := components.Schemas[name]; !ok {
+			t.Errorf("missing component %s", name)
+		}
+	}
+	payees := components.Schemas["PaymentPerson"].Properties["payees"].Items
+	if len(payees.AnyOf) != len(config.SubRecordLayouts) {
+		t.Error("payees should be any payee record")
+	}
+
+	buf, err := json.Marshal(components)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(buf), "$defs") || strings.Contains(string(buf), `"if"`) {
+		t.Error("components should only use openapi schema objects")
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	if _, err := Generate(FormatJSONSchema, config.DefaultTaxYear); err != nil {
+		t.Error(err)
+	}
+	if _, err := Generate(FormatOpenAPI, config.DefaultTaxYear); err != nil {
+		t.Error(err)
+	}
+	if _, err := Generate("xml", config.DefaultTaxYear); err == nil {
+		t.Error("xml should be unsupported")
+	}
+}
+
+// TestRecordFixtures checks json fixtures of records against their schemas
+func TestRecordFixtures(t *testing.T) {
+	schema := JSONSchema(config.DefaultTaxYear)
+	fixtures := map[string]*Schema{
+		"transmitterRecord.json":    schema.Defs["TRecord"],
+		"payerRecord.json":          schema.Defs["ARecord"],
+		"endPayerRecord.json":       schema.Defs["CRecord"],
+		"stateRecord.json":          schema.Defs["KRecord"],
+		"endTransmitterRecord.json": schema.Defs["FRecord"],
+	}
+	for _, subType := range subRecordTypes() {
+		name := strings.Replace(ComponentName(subType), "BRecord", "payeeRecord", 1) + ".json"
+		fixtures[name] = merge(schema.Defs["BRecord"], schema.Defs[ComponentName(subType)].AllOf[1])
+	}
+
+	for name, record := range fixtures {
+		buf, err := os.ReadFile(filepath.Join(testDataPath, name))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		var data map[string]interface{}
+		if err := json.Unmarshal(buf, &data); err != nil {
+			t.Fatal(err)
+		}
+		delete(data, fixtureQuirks[name])
+		for _, problem := range check(record, data) {
+			t.Errorf("%s: %s", name, problem)
+		}
+	}
+}
+
+// merge returns the properties and required fields of the schemas as one schema
+func merge(schemas ...*Schema) *Schema {
+	merged := &Schema{Properties: map[string]*Schema{}}
+	for _, schema := range schemas {
+		for name, property := range schema.Properties {
+			merged.Properties[name] = property
+		}
+		merged.Required = append(merged.Required, schema.Required...)
+	}
+	return merged
+}
+
+// check returns the problems of a flat json object with the schema
+func check(schema *Schema, data map[string]interface{}) []string {
+	var problems []string
+	for _, name := range schema.Required {
+		if _, ok := data[name]; !ok {
+			problems = append(problems, name+" is required")
+		}
+	}
+	for name, value := range data {
+		property, ok := schema.Properties[name]
+		if !ok {
+			problems = append(problems, name+" is not a property")
+			continue
+		}
+		switch v := value.(type) {
+		case string:
+			if property.Type != "string" {
+				problems = append(problems, name+" should be "+property.Type)
+			} else if property.MaxLength != nil && len(v) > *property.MaxLength {
+				problems = append(problems, name+" is too long")
+			} else if len(property.Enum) > 0 && !contains(property.Enum, v) {
+				problems = append(problems, name+" is not an allowed code")
+			}
+		case float64:
+			if property.Type != "integer" {
+				problems = append(problems, name+" should be "+property.Type)
+			} else if property.Maximum != nil && v > float64(*property.Maximum) {
+				problems = append(problems, name+" is too large")
+			}
+		}
+	}
+	return problems
+}
+
+func contains(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
@@ -3,6 +3,8 @@ package service
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"regexp"
@@ -11,6 +13,8 @@ import (
 	"github.com/gorilla/mux"
 	"github.com/moov-io/irs/pkg/config"
 	"github.com/moov-io/irs/pkg/file"
+	"github.com/moov-io/irs/pkg/records1042s"
+	"github.com/moov-io/irs/pkg/utils"
 )
 
 func parseInputFromRequest(r *http.Request) (file.File, error) {
@@ -27,13 +31,66 @@ func parseInputFromRequest(r *http.Request) (file.File, error) {
 
 	space := regexp.MustCompile(`\s+`)
 	buf := space.ReplaceAllString(input.String(), " ")
-	mf, err := file.CreateFile([]byte(buf))
+	create := file.CreateFile
+	if dollarsFromRequest(r) {
+		create = file.CreateFileFromDollars
+	}
+	mf, err := create([]byte(buf))
 	if err != nil {
 		return nil, err
 	}
 	return mf, nil
 }
 
+// form1042SFromRequest returns the Form 1042-S file of the request, or nil if it isn't a Form 1042-S file
+//
+// Form 1042-S records are read before whitespace is collapsed, their positions depend on blanks.
+func form1042SFromRequest(r *http.Request) (*records1042s.File, error) {
+	src, _, err := r.FormFile("file")
+	if err != nil {
+		return nil, err
+	}
+	defer src.Close()
+
+	input, err := io.ReadAll(src)
+	if err != nil {
+		return nil, err
+	}
+	if !records1042s.IsFile(input) {
+		return nil, nil
+	}
+	if dollarsFromRequest(r) || len(r.FormValue("mask")) > 0 {
+		return nil, errors.New("dollars and mask parameters are not supported for form 1042-S files")
+	}
+	return records1042s.CreateFile(input)
+}
+
+// dollarsFromRequest returns true if json amounts of the request are decimal dollars instead of cents
+func dollarsFromRequest(r *http.Request) bool {
+	return strings.EqualFold(r.FormValue("dollars"), "true")
+}
+
+// marshalFromRequest returns the json encoding of the file, amounts are decimal dollars with the dollars parameter
+func marshalFromRequest(r *http.Request, mf file.File) ([]byte, error) {
+	if dollarsFromRequest(r) {
+		return file.MarshalDollarJSON(mf)
+	}
+	return json.Marshal(mf)
+}
+
+// maskFromRequest returns the file masked with the kinds of personal data of the mask parameter, if it is set
+func maskFromRequest(r *http.Request, mf file.File) (file.File, error) {
+	value := r.FormValue("mask")
+	if len(value) == 0 {
+		return mf, nil
+	}
+	options, err := file.ParseMaskOptions(value)
+	if err != nil {
+		return nil, err
+	}
+	return file.Mask(mf, options)
+}
+
 func outputString(w http.ResponseWriter, output string) {
 	w.WriteHeader(http.StatusOK)
 	w.Write([]byte(output))
@@ -46,12 +103,46 @@ func outputJson(w http.ResponseWriter, output interface{}) {
 
 // validator - validate the file based on publication 1220
 func validator(w http.ResponseWriter, r *http.Request) {
+	f, err := form1042SFromRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if f != nil {
+		validator1042S(w, r, f)
+		return
+	}
+
 	mf, err := parseInputFromRequest(r)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
+	report := strings.EqualFold(r.FormValue("report"), "true")
+	if name := r.FormValue("profile"); len(name) > 0 {
+		profile, err := file.LookupProfile(name)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		result := mf.ValidateProfile(profile)
+		switch {
+		case report:
+			outputReport(w, result)
+		case !result.Valid():
+			http.Error(w, result.Error(), http.StatusNotImplemented)
+		default:
+			outputString(w, "valid file")
+		}
+		return
+	}
+
+	if report {
+		outputReport(w, mf.ValidateAll())
+		return
+	}
+
 	err = mf.Validate()
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusNotImplemented)
@@ -61,19 +152,58 @@ func validator(w http.ResponseWriter, r *http.Request) {
 	outputString(w, "valid file")
 }
 
+// outputReport writes the validation report as json, with status 501 if the file is invalid
+func outputReport(w http.ResponseWriter, report *file.ValidationReport) {
+	status := http.StatusOK
+	if !report.Valid() {
+		status = http.StatusNotImplemented
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(report)
+}
+
 // validator - print file with ascii or json format
 func print(w http.ResponseWriter, r *http.Request) {
+	f, err := form1042SFromRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if f != nil {
+		print1042S(w, r, f)
+		return
+	}
+
 	mf, err := parseInputFromRequest(r)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
+	mf, err = maskFromRequest(r, mf)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	format := r.FormValue("format")
 	if strings.EqualFold(format, config.OutputIrsFormat) {
 		outputString(w, string(mf.Ascii()))
+	} else if strings.EqualFold(format, config.OutputIrisFormat) {
+		buf, err := mf.Iris()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusNotImplemented)
+			return
+		}
+		outputString(w, string(buf))
 	} else if strings.EqualFold(format, config.OutputJsonFormat) || len(format) == 0 {
-		outputJson(w, mf)
+		buf, err := marshalFromRequest(r, mf)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusNotImplemented)
+			return
+		}
+		outputString(w, string(buf))
 	} else {
 		http.Error(w, "invalid print format", http.StatusBadRequest)
 	}
@@ -81,14 +211,31 @@ func print(w http.ResponseWriter, r *http.Request) {
 
 // convert - convert file with ascii or json format
 func convert(w http.ResponseWriter, r *http.Request) {
+	f, err := form1042SFromRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if f != nil {
+		convert1042S(w, r, f)
+		return
+	}
+
 	mf, err := parseInputFromRequest(r)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
+	file.FillNameControls(mf)
+	mf, err = maskFromRequest(r, mf)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	format := r.FormValue("format")
-	buf, err := json.Marshal(mf)
+	buf, err := marshalFromRequest(r, mf)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusNotImplemented)
 		return
@@ -99,8 +246,21 @@ func convert(w http.ResponseWriter, r *http.Request) {
 	if strings.EqualFold(format, config.OutputIrsFormat) {
 		output = string(mf.Ascii())
 		filename = "irs"
+	} else if strings.EqualFold(format, config.OutputIrisFormat) {
+		buf, err = mf.Iris()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusNotImplemented)
+			return
+		}
+		output = string(buf)
+		filename = "irs.xml"
 	}
 
+	outputAttachment(w, filename, output)
+}
+
+// outputAttachment writes the converted file as an attachment
+func outputAttachment(w http.ResponseWriter, filename, output string) {
 	w.Header().Set("Content-Type", "application/octet-stream")
 	w.Header().Set("Content-Disposition", "attachment; filename="+filename)
 	w.Header().Set("Content-Transfer-Encoding", "binary")
@@ -109,6 +269,78 @@ func convert(w http.ResponseWriter, r *http.Request) {
 	w.Write([]byte(output))
 }
 
+// validator1042S - validate the file based on publication 1187
+func validator1042S(w http.ResponseWriter, r *http.Request, f *records1042s.File) {
+	if len(r.FormValue("report")) > 0 || len(r.FormValue("profile")) > 0 {
+		http.Error(w, "report and profile parameters are not supported for form 1042-S files", http.StatusBadRequest)
+		return
+	}
+	if err := f.Validate(); err != nil {
+		http.Error(w, err.Error(), http.StatusNotImplemented)
+		return
+	}
+	outputString(w, "valid file")
+}
+
+// print1042S - print Form 1042-S file with ascii or json format
+func print1042S(w http.ResponseWriter, r *http.Request, f *records1042s.File) {
+	format := r.FormValue("format")
+	if strings.EqualFold(format, config.OutputIrsFormat) {
+		outputString(w, string(f.Ascii()))
+	} else if strings.EqualFold(format, config.OutputIrisFormat) {
+		http.Error(w, fmt.Errorf("form 1042-S %w", utils.ErrUnsupportedIris).Error(), http.StatusNotImplemented)
+	} else if strings.EqualFold(format, config.OutputJsonFormat) || len(format) == 0 {
+		buf, err := json.Marshal(f)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusNotImplemented)
+			return
+		}
+		outputString(w, string(buf))
+	} else {
+		http.Error(w, "invalid print format", http.StatusBadRequest)
+	}
+}
+
+// convert1042S - convert Form 1042-S file with ascii or json format
+func convert1042S(w http.ResponseWriter, r *http.Request, f *records1042s.File) {
+	format := r.FormValue("format")
+	if strings.EqualFold(format, config.OutputIrsFormat) {
+		outputAttachment(w, "irs", string(f.Ascii()))
+		return
+	}
+	if strings.EqualFold(format, config.OutputIrisFormat) {
+		http.Error(w, fmt.Errorf("form 1042-S %w", utils.ErrUnsupportedIris).Error(), http.StatusNotImplemented)
+		return
+	}
+
+	buf, err := json.Marshal(f)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotImplemented)
+		return
+	}
+	outputAttachment(w, "irs.json", string(buf))
+}
+
+// export - export payees of file with csv format
+func export(w http.ResponseWriter, r *http.Request) {
+	mf, err := parseInputFromRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	format := r.FormValue("format")
+	if len(format) > 0 && !strings.EqualFold(format, config.OutputCsvFormat) {
+		http.Error(w, "invalid export format", http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Disposition", "attachment; filename=irs.csv")
+	w.WriteHeader(http.StatusOK)
+	file.ExportCSV(w, mf)
+}
+
 // health - health check
 func health(w http.ResponseWriter, r *http.Request) {
 	outputJson(w, map[string]bool{"health": true})
@@ -120,6 +352,7 @@ func ConfigureHandlers(r *mux.Router) error {
 	r.HandleFunc("/print", print).Methods("POST")
 	r.HandleFunc("/validator", validator).Methods("POST")
 	r.HandleFunc("/convert", convert).Methods("POST")
+	r.HandleFunc("/export", export).Methods("POST")
 	return nil
 }
 
@@ -130,5 +363,6 @@ func TestConfigureHandlers() (http.Handler, error) {
 	r.HandleFunc("/print", print).Methods("POST")
 	r.HandleFunc("/validator", validator).Methods("POST")
 	r.HandleFunc("/convert", convert).Methods("POST")
+	r.HandleFunc("/export", export).Methods("POST")
 	return r, nil
 }
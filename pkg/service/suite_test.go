@@ -15,6 +15,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/moov-io/irs/pkg/config"
 	"github.com/moov-io/irs/pkg/service"
 	"gopkg.in/check.v1"
 )
@@ -179,3 +180,196 @@ func (t *ServerTest) TestValidateWithInvalidData(c *check.C) {
 	t.testServer.ServeHTTP(recorder, request)
 	c.Assert(recorder.Code, check.Equals, http.StatusNotImplemented)
 }
+
+func (t *ServerTest) TestValidatorReport(c *check.C) {
+	writer, body := t.getWriter("fileWithInvalidPayment.json", c)
+	err := writer.WriteField("report", "true")
+	c.Assert(err, check.IsNil)
+	err = writer.Close()
+	c.Assert(err, check.IsNil)
+	recorder, request := t.makeRequest(http.MethodPost, "/validator", body.String(), c)
+	request.Header.Set("Content-Type", writer.FormDataContentType())
+	t.testServer.ServeHTTP(recorder, request)
+	c.Assert(recorder.Code, check.Equals, http.StatusNotImplemented)
+	c.Assert(strings.Contains(recorder.Body.String(), "unexpected_total_amount"), check.Equals, true)
+}
+
+func (t *ServerTest) TestValidatorProfile(c *check.C) {
+	validate := func(fields map[string]string) *httptest.ResponseRecorder {
+		writer, body := t.getWriter("oneTransactionFile.json", c)
+		for name, value := range fields {
+			c.Assert(writer.WriteField(name, value), check.IsNil)
+		}
+		c.Assert(writer.Close(), check.IsNil)
+		recorder, request := t.makeRequest(http.MethodPost, "/validator", body.String(), c)
+		request.Header.Set("Content-Type", writer.FormDataContentType())
+		t.testServer.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	recorder := validate(map[string]string{"profile": "fire-test"})
+	c.Assert(recorder.Code, check.Equals, http.StatusOK)
+	recorder = validate(map[string]string{"profile": "fire-production"})
+	c.Assert(recorder.Code, check.Equals, http.StatusNotImplemented)
+	c.Assert(strings.Contains(recorder.Body.String(), "test_file_in_production"), check.Equals, true)
+	recorder = validate(map[string]string{"profile": "fire-test", "report": "true"})
+	c.Assert(recorder.Code, check.Equals, http.StatusOK)
+	c.Assert(strings.Contains(recorder.Body.String(), `"severity":"info"`), check.Equals, true)
+	recorder = validate(map[string]string{"profile": "unknown"})
+	c.Assert(recorder.Code, check.Equals, http.StatusBadRequest)
+}
+
+func (t *ServerTest) TestMask(c *check.C) {
+	writer, body := t.getWriter("oneTransactionFile.json", c)
+	c.Assert(writer.WriteField("format", "json"), check.IsNil)
+	c.Assert(writer.Close(), check.IsNil)
+	recorder, request := t.makeRequest(http.MethodPost, "/print?mask=names", body.String(), c)
+	request.Header.Set("Content-Type", writer.FormDataContentType())
+	t.testServer.ServeHTTP(recorder, request)
+	c.Assert(recorder.Code, check.Equals, http.StatusOK)
+	c.Assert(strings.Contains(recorder.Body.String(), "*****4321"), check.Equals, true)
+	c.Assert(strings.Contains(recorder.Body.String(), "SPACELEY"), check.Equals, false)
+
+	writer, body = t.getWriter("oneTransactionFile.json", c)
+	c.Assert(writer.WriteField("format", "irs"), check.IsNil)
+	c.Assert(writer.Close(), check.IsNil)
+	recorder, request = t.makeRequest(http.MethodPost, "/convert?mask=true", body.String(), c)
+	request.Header.Set("Content-Type", writer.FormDataContentType())
+	t.testServer.ServeHTTP(recorder, request)
+	c.Assert(recorder.Code, check.Equals, http.StatusOK)
+	c.Assert(strings.Contains(recorder.Body.String(), "987654321"), check.Equals, false)
+
+	writer, body = t.getWriter("oneTransactionFile.json", c)
+	c.Assert(writer.Close(), check.IsNil)
+	recorder, request = t.makeRequest(http.MethodPost, "/print?mask=phones", body.String(), c)
+	request.Header.Set("Content-Type", writer.FormDataContentType())
+	t.testServer.ServeHTTP(recorder, request)
+	c.Assert(recorder.Code, check.Equals, http.StatusBadRequest)
+}
+
+func (t *ServerTest) TestDollars(c *check.C) {
+	writer, body := t.getWriter("oneTransactionFileDollars.json", c)
+	c.Assert(writer.WriteField("format", "json"), check.IsNil)
+	c.Assert(writer.Close(), check.IsNil)
+	recorder, request := t.makeRequest(http.MethodPost, "/print?dollars=true", body.String(), c)
+	request.Header.Set("Content-Type", writer.FormDataContentType())
+	t.testServer.ServeHTTP(recorder, request)
+	c.Assert(recorder.Code, check.Equals, http.StatusOK)
+	c.Assert(strings.Contains(recorder.Body.String(), `"payment_amount_7":"7.00"`), check.Equals, true)
+	c.Assert(strings.Contains(recorder.Body.String(), `"control_total_7":"14.00"`), check.Equals, true)
+
+	writer, body = t.getWriter("oneTransactionFileDollars.json", c)
+	c.Assert(writer.WriteField("format", "json"), check.IsNil)
+	c.Assert(writer.Close(), check.IsNil)
+	recorder, request = t.makeRequest(http.MethodPost, "/convert?dollars=false", body.String(), c)
+	request.Header.Set("Content-Type", writer.FormDataContentType())
+	t.testServer.ServeHTTP(recorder, request)
+	c.Assert(recorder.Code, check.Equals, http.StatusBadRequest)
+
+	writer, body = t.getWriter("oneTransactionFileDollars.json", c)
+	c.Assert(writer.Close(), check.IsNil)
+	recorder, request = t.makeRequest(http.MethodPost, "/validator?dollars=true", body.String(), c)
+	request.Header.Set("Content-Type", writer.FormDataContentType())
+	t.testServer.ServeHTTP(recorder, request)
+	c.Assert(recorder.Code, check.Equals, http.StatusOK)
+}
+
+func (t *ServerTest) TestIris(c *check.C) {
+	writer, body := t.getWriter("oneTransactionFileIris.json", c)
+	c.Assert(writer.WriteField("format", "iris"), check.IsNil)
+	c.Assert(writer.Close(), check.IsNil)
+	recorder, request := t.makeRequest(http.MethodPost, "/print", body.String(), c)
+	request.Header.Set("Content-Type", writer.FormDataContentType())
+	t.testServer.ServeHTTP(recorder, request)
+	c.Assert(recorder.Code, check.Equals, http.StatusOK)
+	c.Assert(strings.Contains(recorder.Body.String(), "<OtherIncomeAmt>7.00</OtherIncomeAmt>"), check.Equals, true)
+
+	writer, body = t.getWriter("oneTransactionFileIris.json", c)
+	c.Assert(writer.WriteField("format", "iris"), check.IsNil)
+	c.Assert(writer.Close(), check.IsNil)
+	recorder, request = t.makeRequest(http.MethodPost, "/convert", body.String(), c)
+	request.Header.Set("Content-Type", writer.FormDataContentType())
+	t.testServer.ServeHTTP(recorder, request)
+	c.Assert(recorder.Code, check.Equals, http.StatusOK)
+	c.Assert(recorder.Header().Get("Content-Disposition"), check.Equals, "attachment; filename=irs.xml")
+
+	// amount code 7 of 1099-MISC has no IRIS element
+	writer, body = t.getWriter("oneTransactionFile.json", c)
+	c.Assert(writer.WriteField("format", "iris"), check.IsNil)
+	c.Assert(writer.Close(), check.IsNil)
+	recorder, request = t.makeRequest(http.MethodPost, "/convert", body.String(), c)
+	request.Header.Set("Content-Type", writer.FormDataContentType())
+	t.testServer.ServeHTTP(recorder, request)
+	c.Assert(recorder.Code, check.Equals, http.StatusNotImplemented)
+}
+
+func (t *ServerTest) TestForm1042S(c *check.C) {
+	writer, body := t.getWriter("form1042s.ascii", c)
+	c.Assert(writer.Close(), check.IsNil)
+	recorder, request := t.makeRequest(http.MethodPost, "/validator", body.String(), c)
+	request.Header.Set("Content-Type", writer.FormDataContentType())
+	t.testServer.ServeHTTP(recorder, request)
+	c.Assert(recorder.Code, check.Equals, http.StatusOK)
+
+	writer, body = t.getWriter("form1042s.ascii", c)
+	c.Assert(writer.WriteField("format", "json"), check.IsNil)
+	c.Assert(writer.Close(), check.IsNil)
+	recorder, request = t.makeRequest(http.MethodPost, "/print", body.String(), c)
+	request.Header.Set("Content-Type", writer.FormDataContentType())
+	t.testServer.ServeHTTP(recorder, request)
+	c.Assert(recorder.Code, check.Equals, http.StatusOK)
+	c.Assert(strings.Contains(recorder.Body.String(), `"withholding_agents"`), check.Equals, true)
+
+	writer, body = t.getWriter("form1042s.json", c)
+	c.Assert(writer.WriteField("format", "irs"), check.IsNil)
+	c.Assert(writer.Close(), check.IsNil)
+	recorder, request = t.makeRequest(http.MethodPost, "/convert", body.String(), c)
+	request.Header.Set("Content-Type", writer.FormDataContentType())
+	t.testServer.ServeHTTP(recorder, request)
+	c.Assert(recorder.Code, check.Equals, http.StatusOK)
+	c.Assert(recorder.Header().Get("Content-Disposition"), check.Equals, "attachment; filename=irs")
+	c.Assert(recorder.Body.Len(), check.Equals, 5*config.RecordLength1042S)
+
+	writer, body = t.getWriter("form1042s.json", c)
+	c.Assert(writer.WriteField("format", "iris"), check.IsNil)
+	c.Assert(writer.Close(), check.IsNil)
+	recorder, request = t.makeRequest(http.MethodPost, "/print", body.String(), c)
+	request.Header.Set("Content-Type", writer.FormDataContentType())
+	t.testServer.ServeHTTP(recorder, request)
+	c.Assert(recorder.Code, check.Equals, http.StatusNotImplemented)
+
+	writer, body = t.getWriter("form1042s.json", c)
+	c.Assert(writer.WriteField("report", "true"), check.IsNil)
+	c.Assert(writer.Close(), check.IsNil)
+	recorder, request = t.makeRequest(http.MethodPost, "/validator", body.String(), c)
+	request.Header.Set("Content-Type", writer.FormDataContentType())
+	t.testServer.ServeHTTP(recorder, request)
+	c.Assert(recorder.Code, check.Equals, http.StatusBadRequest)
+}
+
+func (t *ServerTest) TestExport(c *check.C) {
+	writer, body := t.getWriter("oneTransactionFile.json", c)
+	err := writer.WriteField("format", "csv")
+	c.Assert(err, check.IsNil)
+	err = writer.Close()
+	c.Assert(err, check.IsNil)
+	recorder, request := t.makeRequest(http.MethodPost, "/export", body.String(), c)
+	request.Header.Set("Content-Type", writer.FormDataContentType())
+	t.testServer.ServeHTTP(recorder, request)
+	c.Assert(recorder.Code, check.Equals, http.StatusOK)
+	c.Assert(recorder.Header().Get("Content-Type"), check.Equals, "text/csv")
+	c.Assert(strings.HasPrefix(recorder.Body.String(), "payer_tin,"), check.Equals, true)
+	c.Assert(strings.Count(recorder.Body.String(), "\n"), check.Equals, 3)
+}
+
+func (t *ServerTest) TestExportWithInvalidFormat(c *check.C) {
+	writer, body := t.getWriter("oneTransactionFile.json", c)
+	err := writer.WriteField("format", "xml")
+	c.Assert(err, check.IsNil)
+	err = writer.Close()
+	c.Assert(err, check.IsNil)
+	recorder, request := t.makeRequest(http.MethodPost, "/export", body.String(), c)
+	request.Header.Set("Content-Type", writer.FormDataContentType())
+	t.testServer.ServeHTTP(recorder, request)
+	c.Assert(recorder.Code, check.Equals, http.StatusBadRequest)
+}
@@ -49,6 +49,8 @@ type Sub1097BTC struct {
 	// revenue departments for filing requirements. You may enter
 	// comments here. If this field is not used, enter blanks.
 	SpecialDataEntries string `json:"special_data_entries"`
+
+	taxYear int
 }
 
 // Type returns type of “1097-BTC” record
@@ -56,6 +58,15 @@ func (r *Sub1097BTC) Type() string {
 	return config.Sub1097BtcType
 }
 
+// SetTaxYear sets tax year of the record that selects its layout revision
+func (r *Sub1097BTC) SetTaxYear(year int) {
+	r.taxYear = year
+}
+
+func (r *Sub1097BTC) layout() map[string]config.SpecField {
+	return config.SubRecordLayout(r.taxYear, config.Sub1097BtcType)
+}
+
 // Type returns FS code of “1097-BTC” record
 func (r *Sub1097BTC) FederalState() int {
 	return 0
@@ -73,13 +84,13 @@ func (r *Sub1097BTC) Parse(buf []byte) error {
 		return utils.ErrValidField
 	}
 
-	return utils.ParseValue(fields, config.Sub1097BTCLayout, record)
+	return utils.ParseValue(fields, r.layout(), record)
 }
 
 // Ascii returns fire ascii of “1097-BTC” record
 func (r *Sub1097BTC) Ascii() []byte {
 	var buf bytes.Buffer
-	records := config.ToSpecifications(config.Sub1097BTCLayout)
+	records := config.ToSpecifications(r.layout())
 	fields := reflect.ValueOf(r).Elem()
 	if !fields.IsValid() {
 		return nil
@@ -96,7 +107,7 @@ func (r *Sub1097BTC) Ascii() []byte {
 
 // Validate performs some checks on the record and returns an error if not Validated
 func (r *Sub1097BTC) Validate() error {
-	return utils.Validate(r, config.Sub1097BTCLayout, config.Sub1097BtcType)
+	return utils.Validate(r, r.layout(), config.Sub1097BtcType)
 }
 
 // customized field validation functions
@@ -60,6 +60,8 @@ type Sub1098 struct {
 	// acquisition. (for example, January 5, 2019, would be
 	// 20190105)
 	MortgageAcquisitionDate time.Time `json:"mortgage_acquisition_date"`
+
+	taxYear int
 }
 
 // Type returns type of “1098” record
@@ -67,6 +69,15 @@ func (r *Sub1098) Type() string {
 	return config.Sub1098Type
 }
 
+// SetTaxYear sets tax year of the record that selects its layout revision
+func (r *Sub1098) SetTaxYear(year int) {
+	r.taxYear = year
+}
+
+func (r *Sub1098) layout() map[string]config.SpecField {
+	return config.SubRecordLayout(r.taxYear, config.Sub1098Type)
+}
+
 // Type returns FS code of “1098” record
 func (r *Sub1098) FederalState() int {
 	return 0
@@ -84,13 +95,13 @@ func (r *Sub1098) Parse(buf []byte) error {
 		return utils.ErrValidField
 	}
 
-	return utils.ParseValue(fields, config.Sub1098Layout, record)
+	return utils.ParseValue(fields, r.layout(), record)
 }
 
 // Ascii returns fire ascii of “1098” record
 func (r *Sub1098) Ascii() []byte {
 	var buf bytes.Buffer
-	records := config.ToSpecifications(config.Sub1098Layout)
+	records := config.ToSpecifications(r.layout())
 	fields := reflect.ValueOf(r).Elem()
 	if !fields.IsValid() {
 		return nil
@@ -107,7 +118,7 @@ func (r *Sub1098) Ascii() []byte {
 
 // Validate performs some checks on the record and returns an error if not Validated
 func (r *Sub1098) Validate() error {
-	return utils.Validate(r, config.Sub1098Layout, config.Sub1098Type)
+	return utils.Validate(r, r.layout(), config.Sub1098Type)
 }
 
 // customized field validation functions
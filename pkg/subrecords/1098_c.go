@@ -91,6 +91,8 @@ type Sub1098C struct {
 	// the vehicle. Otherwise, enter blanks.
 	// Left justify information and fill unused positions with blanks.
 	GoodsServices string `json:"goods_services"`
+
+	taxYear int
 }
 
 // Type returns type of “1098-C” record
@@ -98,6 +100,15 @@ func (r *Sub1098C) Type() string {
 	return config.Sub1098CType
 }
 
+// SetTaxYear sets tax year of the record that selects its layout revision
+func (r *Sub1098C) SetTaxYear(year int) {
+	r.taxYear = year
+}
+
+func (r *Sub1098C) layout() map[string]config.SpecField {
+	return config.SubRecordLayout(r.taxYear, config.Sub1098CType)
+}
+
 // Type returns FS code of “1098-C” record
 func (r *Sub1098C) FederalState() int {
 	return 0
@@ -115,13 +126,13 @@ func (r *Sub1098C) Parse(buf []byte) error {
 		return utils.ErrValidField
 	}
 
-	return utils.ParseValue(fields, config.Sub1098CLayout, record)
+	return utils.ParseValue(fields, r.layout(), record)
 }
 
 // Ascii returns fire ascii of “1098-C” record
 func (r *Sub1098C) Ascii() []byte {
 	var buf bytes.Buffer
-	records := config.ToSpecifications(config.Sub1098CLayout)
+	records := config.ToSpecifications(r.layout())
 	fields := reflect.ValueOf(r).Elem()
 	if !fields.IsValid() {
 		return nil
@@ -138,7 +149,7 @@ func (r *Sub1098C) Ascii() []byte {
 
 // Validate performs some checks on the record and returns an error if not Validated
 func (r *Sub1098C) Validate() error {
-	return utils.Validate(r, config.Sub1098CLayout, config.Sub1098CType)
+	return utils.Validate(r, r.layout(), config.Sub1098CType)
 }
 
 // customized field validation functions
@@ -26,6 +26,8 @@ type Sub1098E struct {
 	// local revenue departments for the filing requirements. If
 	// this field is not use, enter blanks.
 	SpecialDataEntries string `json:"special_data_entries"`
+
+	taxYear int
 }
 
 // Type returns type of “1098-E” record
@@ -33,6 +35,15 @@ func (r *Sub1098E) Type() string {
 	return config.Sub1098EType
 }
 
+// SetTaxYear sets tax year of the record that selects its layout revision
+func (r *Sub1098E) SetTaxYear(year int) {
+	r.taxYear = year
+}
+
+func (r *Sub1098E) layout() map[string]config.SpecField {
+	return config.SubRecordLayout(r.taxYear, config.Sub1098EType)
+}
+
 // Type returns FS code of “1098-E” record
 func (r *Sub1098E) FederalState() int {
 	return 0
@@ -50,13 +61,13 @@ func (r *Sub1098E) Parse(buf []byte) error {
 		return utils.ErrValidField
 	}
 
-	return utils.ParseValue(fields, config.Sub1098ELayout, record)
+	return utils.ParseValue(fields, r.layout(), record)
 }
 
 // Ascii returns fire ascii of “1098-E” record
 func (r *Sub1098E) Ascii() []byte {
 	var buf bytes.Buffer
-	records := config.ToSpecifications(config.Sub1098ELayout)
+	records := config.ToSpecifications(r.layout())
 	fields := reflect.ValueOf(r).Elem()
 	if !fields.IsValid() {
 		return nil
@@ -73,7 +84,7 @@ func (r *Sub1098E) Ascii() []byte {
 
 // Validate performs some checks on the record and returns an error if not Validated
 func (r *Sub1098E) Validate() error {
-	return utils.Validate(r, config.Sub1098ELayout, config.Sub1098EType)
+	return utils.Validate(r, r.layout(), config.Sub1098EType)
 }
 
 // customized field validation functions
@@ -46,6 +46,8 @@ type Sub1098F struct {
 	// local revenue departments for the filing requirements. If this
 	// field is not used, enter blanks.
 	SpecialDataEntries string `json:"special_data_entries"`
+
+	taxYear int
 }
 
 // Type returns type of “1098-F” record
@@ -53,6 +55,15 @@ func (r *Sub1098F) Type() string {
 	return config.Sub1098FType
 }
 
+// SetTaxYear sets tax year of the record that selects its layout revision
+func (r *Sub1098F) SetTaxYear(year int) {
+	r.taxYear = year
+}
+
+func (r *Sub1098F) layout() map[string]config.SpecField {
+	return config.SubRecordLayout(r.taxYear, config.Sub1098FType)
+}
+
 // Type returns FS code of “1098-F” record
 func (r *Sub1098F) FederalState() int {
 	return 0
@@ -70,13 +81,13 @@ func (r *Sub1098F) Parse(buf []byte) error {
 		return utils.ErrValidField
 	}
 
-	return utils.ParseValue(fields, config.Sub1098FLayout, record)
+	return utils.ParseValue(fields, r.layout(), record)
 }
 
 // Ascii returns fire ascii of “1098-F” record
 func (r *Sub1098F) Ascii() []byte {
 	var buf bytes.Buffer
-	records := config.ToSpecifications(config.Sub1098FLayout)
+	records := config.ToSpecifications(r.layout())
 	fields := reflect.ValueOf(r).Elem()
 	if !fields.IsValid() {
 		return nil
@@ -93,7 +104,7 @@ func (r *Sub1098F) Ascii() []byte {
 
 // Validate performs some checks on the record and returns an error if not Validated
 func (r *Sub1098F) Validate() error {
-	return utils.Validate(r, config.Sub1098FLayout, config.Sub1098FType)
+	return utils.Validate(r, r.layout(), config.Sub1098FType)
 }
 
 // customized field validation functions
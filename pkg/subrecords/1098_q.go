@@ -72,6 +72,8 @@ type Sub1098Q struct {
 	// employer identification number of the plan sponsor. Otherwise,
 	// enter blanks.
 	EmployerIdentificationNumber string `json:"employer_identification_number"`
+
+	taxYear int
 }
 
 // Type returns type of “1098-Q” record
@@ -79,6 +81,15 @@ func (r *Sub1098Q) Type() string {
 	return config.Sub1098QType
 }
 
+// SetTaxYear sets tax year of the record that selects its layout revision
+func (r *Sub1098Q) SetTaxYear(year int) {
+	r.taxYear = year
+}
+
+func (r *Sub1098Q) layout() map[string]config.SpecField {
+	return config.SubRecordLayout(r.taxYear, config.Sub1098QType)
+}
+
 // Type returns FS code of “1098-Q” record
 func (r *Sub1098Q) FederalState() int {
 	return 0
@@ -96,13 +107,13 @@ func (r *Sub1098Q) Parse(buf []byte) error {
 		return utils.ErrValidField
 	}
 
-	return utils.ParseValue(fields, config.Sub1098QLayout, record)
+	return utils.ParseValue(fields, r.layout(), record)
 }
 
 // Ascii returns fire ascii of “1098-Q” record
 func (r *Sub1098Q) Ascii() []byte {
 	var buf bytes.Buffer
-	records := config.ToSpecifications(config.Sub1098QLayout)
+	records := config.ToSpecifications(r.layout())
 	fields := reflect.ValueOf(r).Elem()
 	if !fields.IsValid() {
 		return nil
@@ -119,7 +130,7 @@ func (r *Sub1098Q) Ascii() []byte {
 
 // Validate performs some checks on the record and returns an error if not Validated
 func (r *Sub1098Q) Validate() error {
-	return utils.Validate(r, config.Sub1098QLayout, config.Sub1098QType)
+	return utils.Validate(r, r.layout(), config.Sub1098QType)
 }
 
 // customized field validation functions
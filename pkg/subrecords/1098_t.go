@@ -55,6 +55,8 @@ type Sub1098T struct {
 	// local revenue departments for the filing requirements. If
 	// this field is not use, enter blanks.
 	SpecialDataEntries string `json:"special_data_entries"`
+
+	taxYear int
 }
 
 // Type returns type of “1098-T” record
@@ -62,6 +64,15 @@ func (r *Sub1098T) Type() string {
 	return config.Sub1098TType
 }
 
+// SetTaxYear sets tax year of the record that selects its layout revision
+func (r *Sub1098T) SetTaxYear(year int) {
+	r.taxYear = year
+}
+
+func (r *Sub1098T) layout() map[string]config.SpecField {
+	return config.SubRecordLayout(r.taxYear, config.Sub1098TType)
+}
+
 // Type returns FS code of “1098-T” record
 func (r *Sub1098T) FederalState() int {
 	return 0
@@ -79,13 +90,13 @@ func (r *Sub1098T) Parse(buf []byte) error {
 		return utils.ErrValidField
 	}
 
-	return utils.ParseValue(fields, config.Sub1098TLayout, record)
+	return utils.ParseValue(fields, r.layout(), record)
 }
 
 // Ascii returns fire ascii of “1098-T” record
 func (r *Sub1098T) Ascii() []byte {
 	var buf bytes.Buffer
-	records := config.ToSpecifications(config.Sub1098TLayout)
+	records := config.ToSpecifications(r.layout())
 	fields := reflect.ValueOf(r).Elem()
 	if !fields.IsValid() {
 		return nil
@@ -102,7 +113,7 @@ func (r *Sub1098T) Ascii() []byte {
 
 // Validate performs some checks on the record and returns an error if not Validated
 func (r *Sub1098T) Validate() error {
-	return utils.Validate(r, config.Sub1098TLayout, config.Sub1098TType)
+	return utils.Validate(r, r.layout(), config.Sub1098TType)
 }
 
 // customized field validation functions
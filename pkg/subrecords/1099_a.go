@@ -43,6 +43,8 @@ type Sub1099A struct {
 	// revenue departments for the filing requirements. If this field is
 	// not used, enter blanks.
 	SpecialDataEntries string `json:"special_data_entries"`
+
+	taxYear int
 }
 
 // Type returns type of “1099-A” record
@@ -50,6 +52,15 @@ func (r *Sub1099A) Type() string {
 	return config.Sub1099AType
 }
 
+// SetTaxYear sets tax year of the record that selects its layout revision
+func (r *Sub1099A) SetTaxYear(year int) {
+	r.taxYear = year
+}
+
+func (r *Sub1099A) layout() map[string]config.SpecField {
+	return config.SubRecordLayout(r.taxYear, config.Sub1099AType)
+}
+
 // Type returns FS code of “1099-A” record
 func (r *Sub1099A) FederalState() int {
 	return 0
@@ -67,13 +78,13 @@ func (r *Sub1099A) Parse(buf []byte) error {
 		return utils.ErrValidField
 	}
 
-	return utils.ParseValue(fields, config.Sub1099ALayout, record)
+	return utils.ParseValue(fields, r.layout(), record)
 }
 
 // Ascii returns fire ascii of “1099-A” record
 func (r *Sub1099A) Ascii() []byte {
 	var buf bytes.Buffer
-	records := config.ToSpecifications(config.Sub1099ALayout)
+	records := config.ToSpecifications(r.layout())
 	fields := reflect.ValueOf(r).Elem()
 	if !fields.IsValid() {
 		return nil
@@ -90,7 +101,7 @@ func (r *Sub1099A) Ascii() []byte {
 
 // Validate performs some checks on the record and returns an error if not Validated
 func (r *Sub1099A) Validate() error {
-	return utils.Validate(r, config.Sub1099ALayout, config.Sub1099AType)
+	return utils.Validate(r, r.layout(), config.Sub1099AType)
 }
 
 // customized field validation functions
@@ -107,6 +107,8 @@ type Sub1099B struct {
 	// revenue departments for the filing requirements. If this field is
 	// not used, enter blanks.
 	SpecialDataEntries string `json:"special_data_entries"`
+
+	taxYear int
 }
 
 // Type returns type of “1099-B” record
@@ -114,6 +116,15 @@ func (r *Sub1099B) Type() string {
 	return config.Sub1099BType
 }
 
+// SetTaxYear sets tax year of the record that selects its layout revision
+func (r *Sub1099B) SetTaxYear(year int) {
+	r.taxYear = year
+}
+
+func (r *Sub1099B) layout() map[string]config.SpecField {
+	return config.SubRecordLayout(r.taxYear, config.Sub1099BType)
+}
+
 // Type returns FS code of “1099-B” record
 func (r *Sub1099B) FederalState() int {
 	return 0
@@ -131,13 +142,13 @@ func (r *Sub1099B) Parse(buf []byte) error {
 		return utils.ErrValidField
 	}
 
-	return utils.ParseValue(fields, config.Sub1099BLayout, record)
+	return utils.ParseValue(fields, r.layout(), record)
 }
 
 // Ascii returns fire ascii of “1099-B” record
 func (r *Sub1099B) Ascii() []byte {
 	var buf bytes.Buffer
-	records := config.ToSpecifications(config.Sub1099BLayout)
+	records := config.ToSpecifications(r.layout())
 	fields := reflect.ValueOf(r).Elem()
 	if !fields.IsValid() {
 		return nil
@@ -154,7 +165,7 @@ func (r *Sub1099B) Ascii() []byte {
 
 // Validate performs some checks on the record and returns an error if not Validated
 func (r *Sub1099B) Validate() error {
-	return utils.Validate(r, config.Sub1099BLayout, config.Sub1099BType)
+	return utils.Validate(r, r.layout(), config.Sub1099BType)
 }
 
 // customized field validation functions
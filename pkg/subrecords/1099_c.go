@@ -48,6 +48,8 @@ type Sub1099C struct {
 	// revenue departments for the filing requirements. If this field is
 	// not used, enter blanks.
 	SpecialDataEntries string `json:"special_data_entries"`
+
+	taxYear int
 }
 
 // Type returns type of “1099-C” record
@@ -55,6 +57,15 @@ func (r *Sub1099C) Type() string {
 	return config.Sub1099CType
 }
 
+// SetTaxYear sets tax year of the record that selects its layout revision
+func (r *Sub1099C) SetTaxYear(year int) {
+	r.taxYear = year
+}
+
+func (r *Sub1099C) layout() map[string]config.SpecField {
+	return config.SubRecordLayout(r.taxYear, config.Sub1099CType)
+}
+
 // Type returns FS code of “1099-C” record
 func (r *Sub1099C) FederalState() int {
 	return 0
@@ -72,13 +83,13 @@ func (r *Sub1099C) Parse(buf []byte) error {
 		return utils.ErrValidField
 	}
 
-	return utils.ParseValue(fields, config.Sub1099CLayout, record)
+	return utils.ParseValue(fields, r.layout(), record)
 }
 
 // Ascii returns fire ascii of “1099-C” record
 func (r *Sub1099C) Ascii() []byte {
 	var buf bytes.Buffer
-	records := config.ToSpecifications(config.Sub1099CLayout)
+	records := config.ToSpecifications(r.layout())
 	fields := reflect.ValueOf(r).Elem()
 	if !fields.IsValid() {
 		return nil
@@ -95,7 +106,7 @@ func (r *Sub1099C) Ascii() []byte {
 
 // Validate performs some checks on the record and returns an error if not Validated
 func (r *Sub1099C) Validate() error {
-	return utils.Validate(r, config.Sub1099CLayout, config.Sub1099CType)
+	return utils.Validate(r, r.layout(), config.Sub1099CType)
 }
 
 // customized field validation functions
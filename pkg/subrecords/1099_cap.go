@@ -38,6 +38,8 @@ type Sub1099CAP struct {
 	// revenue departments for the filing requirements. If this field is
 	// not used, enter blanks.
 	SpecialDataEntries string `json:"special_data_entries"`
+
+	taxYear int
 }
 
 // Type returns type of “1099-CAP” record
@@ -45,6 +47,15 @@ func (r *Sub1099CAP) Type() string {
 	return config.Sub1099CapType
 }
 
+// SetTaxYear sets tax year of the record that selects its layout revision
+func (r *Sub1099CAP) SetTaxYear(year int) {
+	r.taxYear = year
+}
+
+func (r *Sub1099CAP) layout() map[string]config.SpecField {
+	return config.SubRecordLayout(r.taxYear, config.Sub1099CapType)
+}
+
 // Type returns FS code of “1099-CAP” record
 func (r *Sub1099CAP) FederalState() int {
 	return 0
@@ -62,13 +73,13 @@ func (r *Sub1099CAP) Parse(buf []byte) error {
 		return utils.ErrValidField
 	}
 
-	return utils.ParseValue(fields, config.Sub1099CAPLayout, record)
+	return utils.ParseValue(fields, r.layout(), record)
 }
 
 // Ascii returns fire ascii of “1099-CAP” record
 func (r *Sub1099CAP) Ascii() []byte {
 	var buf bytes.Buffer
-	records := config.ToSpecifications(config.Sub1099CAPLayout)
+	records := config.ToSpecifications(r.layout())
 	fields := reflect.ValueOf(r).Elem()
 	if !fields.IsValid() {
 		return nil
@@ -85,5 +96,5 @@ func (r *Sub1099CAP) Ascii() []byte {
 
 // Validate performs some checks on the record and returns an error if not Validated
 func (r *Sub1099CAP) Validate() error {
-	return utils.Validate(r, config.Sub1099CAPLayout, config.Sub1099CapType)
+	return utils.Validate(r, r.layout(), config.Sub1099CapType)
 }
@@ -0,0 +1,275 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package subrecords
+
+import (
+	"bytes"
+	"reflect"
+	"regexp"
+	"time"
+	"unicode/utf8"
+
+	"github.com/moov-io/irs/pkg/config"
+	"github.com/moov-io/irs/pkg/utils"
+)
+
+// numberUnitsPattern matches a number of units of a digital asset like “0.004137”
+var numberUnitsPattern = regexp.MustCompile(`^[0-9]+(\.[0-9]+)?$`)
+
+type Sub1099DA struct {
+	// Enter “2” (two) to indicate notification by the IRS twice within
+	// three calendar years that the payee provided an incorrect
+	// name and/or TIN combination. Otherwise, enter a blank.
+	SecondTinNotice string `json:"second_tin_notice"`
+
+	// Enter the appropriate indicator from the following table, to
+	// identify a noncovered digital asset. If not a noncovered digital
+	// asset, enter a blank.
+	// 1: Noncovered digital asset Basis not reported to the IRS
+	// 2: Noncovered digital asset Basis reported to the IRS
+	// Blank: Not a noncovered digital asset
+	NoncoveredSecurityIndicator string `json:"noncovered_security_indicator"`
+
+	// Enter the appropriate indicator from the following table to
+	// identify the gain or loss of the amount reported in Amount
+	// Code 1. Otherwise, enter a blank.
+	// 1: Short Term
+	// 2: Long Term
+	// 3: Ordinary
+	TypeGainLossIndicator string `json:"type_gain_loss_indicator"`
+
+	// Enter the appropriate indicator from the following table to
+	// identify the amount reported in Amount Code 1. Otherwise,
+	// enter a blank.
+	// 1: Gross proceeds
+	// 2: Gross proceeds less transaction costs
+	GrossProceedsIndicator string `json:"gross_proceeds_indicator"`
+
+	// Enter blanks if this is an aggregate transaction. Otherwise,
+	// enter the date the digital asset was sold or disposed of in
+	// YYYYMMDD format (for example, January 5, 2025, would be
+	// 20250105). Do not enter hyphens or slashes.
+	DateSoldDisposed time.Time `json:"date_sold_disposed"`
+
+	// Enter the digital token identifier (DTI) of the digital asset
+	// reported for Amount Code 1 (Proceeds), or blanks if there is
+	// none. Left justify the information and fill unused positions
+	// with blanks.
+	DigitalAssetCode string `json:"digital_asset_code"`
+
+	// Enter the name of the digital asset (e.g., Bitcoin). If fewer
+	// than 39 characters are required, left justify information and
+	// fill unused positions with blanks.
+	DigitalAssetName string `json:"digital_asset_name"`
+
+	// Enter the date of acquisition in the format YYYYMMDD (for
+	// example, January 5, 2025, would be 20250105). Do not enter
+	// hyphens or slashes.
+	// Enter blanks if this is an aggregate transaction.
+	DateAcquired time.Time `json:"date_acquired"`
+
+	// Enter “1” (one) if the recipient is unable to claim a loss on
+	// their tax return based on dollar amount in Amount Code 1
+	// (Proceeds). Otherwise, enter a blank.
+	LossNotAllowedIndicator string `json:"loss_not_allowed_indicator"`
+
+	// Enter one of the following indicators. Otherwise, enter a blank.
+	// G: Short-term transaction for which the cost or other basis is being reported to the IRS
+	// H: Short-term transaction for which the cost or other basis is not being reported to the IRS
+	// J: Long-term transaction for which the cost or other basis is being reported to the IRS
+	// K: Long-term transaction for which the cost or other basis is not being reported to the IRS
+	// X: Transaction - if you cannot determine whether the recipient should check box H or Box K on Form 8949 because the holding period is unknown
+	ApplicableCheckboxForm8949 string `json:"applicable_checkbox_form8949"`
+
+	// Enter “1” (one) if reporting proceeds from Collectibles.
+	// Otherwise enter blank.
+	ApplicableCheckboxCollectables string `json:"applicable_checkbox_collectables"`
+
+	// Enter "1" (one) if there is a FATCA Filing Requirement.
+	// Otherwise, enter a blank.
+	FATCA string `json:"fatca_requirement_indicator"`
+
+	// Enter a “1” (one) if reporting proceeds from QOF. Otherwise,
+	// enter a blank.
+	ApplicableCheckboxQOF string `json:"applicable_checkbox_qof"`
+
+	// Enter the number of units of the digital asset sold or disposed
+	// of with a decimal point if needed (e.g., 0.004137). Enter blanks
+	// if this is an aggregate transaction. Left justify the information
+	// and fill unused positions with blanks.
+	NumberUnits string `json:"number_of_units"`
+
+	// This portion of the “B” Record may be used to record
+	// information for state or local government reporting or for the
+	// filer’s own purposes. Payers should contact the state or local
+	// revenue departments for the filing requirements. If this field is
+	// not used, enter blanks.
+	SpecialDataEntries string `json:"special_data_entries"`
+
+	// State income tax withheld is for the convenience of the filers.
+	// This information does not need to be reported to the IRS.
+	// The payment amount must be right justified and unused
+	// positions must be zero-filled. If not reporting state income tax
+	// withheld, this field may be used as a continuation of the
+	// Special Data Entries field.
+	StateIncomeTaxWithheld int `json:"state_income_tax_withheld"`
+
+	// Local income tax withheld is for the convenience of the filers.
+	// This information does not need to be reported to the IRS.
+	// The payment amount must be right justified and unused
+	// positions must be zero-filled. If not reporting local tax
+	// withheld, this field may be used as a continuation of the
+	// Special Data Entries Field.
+	LocalIncomeTaxWithheld int `json:"local_income_tax_withheld"`
+
+	taxYear int
+}
+
+// Type returns type of “1099-DA” record
+func (r *Sub1099DA) Type() string {
+	return config.Sub1099DaType
+}
+
+// SetTaxYear sets tax year of the record that selects its layout revision
+func (r *Sub1099DA) SetTaxYear(year int) {
+	r.taxYear = year
+}
+
+func (r *Sub1099DA) layout() map[string]config.SpecField {
+	return config.SubRecordLayout(r.taxYear, config.Sub1099DaType)
+}
+
+// Type returns FS code of “1099-DA” record
+func (r *Sub1099DA) FederalState() int {
+	return 0
+}
+
+// Parse parses the “1099-DA” record from fire ascii
+func (r *Sub1099DA) Parse(buf []byte) error {
+	record := string(buf)
+	if utf8.RuneCountInString(record) != config.SubRecordLength {
+		return utils.ErrRecordLength
+	}
+
+	fields := reflect.ValueOf(r).Elem()
+	if !fields.IsValid() {
+		return utils.ErrValidField
+	}
+
+	return utils.ParseValue(fields, r.layout(), record)
+}
+
+// Ascii returns fire ascii of “1099-DA” record
+func (r *Sub1099DA) Ascii() []byte {
+	var buf bytes.Buffer
+	records := config.ToSpecifications(r.layout())
+	fields := reflect.ValueOf(r).Elem()
+	if !fields.IsValid() {
+		return nil
+	}
+
+	buf.Grow(config.SubRecordLength)
+	for _, spec := range records {
+		value := utils.ToString(spec.Field, fields.FieldByName(spec.Name))
+		buf.WriteString(value)
+	}
+
+	return buf.Bytes()
+}
+
+// Validate performs some checks on the record and returns an error if not Validated
+func (r *Sub1099DA) Validate() error {
+	return utils.Validate(r, r.layout(), config.Sub1099DaType)
+}
+
+// customized field validation functions
+// function name should be "Validate" + field name
+
+func (r *Sub1099DA) ValidateSecondTinNotice() error {
+	if len(r.SecondTinNotice) > 0 &&
+		r.SecondTinNotice != config.SecondTINNotice {
+		return utils.NewErrValidValue("second tin notice")
+	}
+	return nil
+}
+
+func (r *Sub1099DA) ValidateNoncoveredSecurityIndicator() error {
+	if len(r.NoncoveredSecurityIndicator) > 0 &&
+		(r.NoncoveredSecurityIndicator != config.GeneralOneIndicator && r.NoncoveredSecurityIndicator != config.GeneralTwoIndicator) {
+		return utils.NewErrValidValue("noncovered security indicator")
+	}
+	return nil
+}
+
+func (r *Sub1099DA) ValidateTypeGainLossIndicator() error {
+	if len(r.TypeGainLossIndicator) > 0 {
+		switch r.TypeGainLossIndicator {
+		case "1", "2", "3":
+			break
+		default:
+			return utils.NewErrValidValue("type gain loss indicator")
+		}
+	}
+	return nil
+}
+
+func (r *Sub1099DA) ValidateGrossProceedsIndicator() error {
+	if len(r.GrossProceedsIndicator) > 0 &&
+		(r.GrossProceedsIndicator != config.GeneralOneIndicator && r.GrossProceedsIndicator != config.GeneralTwoIndicator) {
+		return utils.NewErrValidValue("gross proceeds indicator")
+	}
+	return nil
+}
+
+func (r *Sub1099DA) ValidateLossNotAllowedIndicator() error {
+	if len(r.LossNotAllowedIndicator) > 0 &&
+		r.LossNotAllowedIndicator != config.GeneralOneIndicator {
+		return utils.NewErrValidValue("loss not allowed indicator")
+	}
+	return nil
+}
+
+func (r *Sub1099DA) ValidateApplicableCheckboxForm8949() error {
+	if len(r.ApplicableCheckboxForm8949) > 0 {
+		switch r.ApplicableCheckboxForm8949 {
+		case "G", "H", "J", "K", "X":
+			break
+		default:
+			return utils.NewErrValidValue("applicable checkbox form8949")
+		}
+	}
+	return nil
+}
+
+func (r *Sub1099DA) ValidateApplicableCheckboxCollectables() error {
+	if len(r.ApplicableCheckboxCollectables) > 0 &&
+		r.ApplicableCheckboxCollectables != config.GeneralOneIndicator {
+		return utils.NewErrValidValue("applicable checkbox collectables")
+	}
+	return nil
+}
+
+func (r *Sub1099DA) ValidateFATCA() error {
+	if len(r.FATCA) > 0 &&
+		r.FATCA != config.FatcaFilingRequirementIndicator {
+		return utils.NewErrValidValue("fatca filing requirement indicator")
+	}
+	return nil
+}
+
+func (r *Sub1099DA) ValidateApplicableCheckboxQOF() error {
+	if len(r.ApplicableCheckboxQOF) > 0 &&
+		r.ApplicableCheckboxQOF != config.GeneralOneIndicator {
+		return utils.NewErrValidValue("applicable checkbox qof")
+	}
+	return nil
+}
+
+func (r *Sub1099DA) ValidateNumberUnits() error {
+	if len(r.NumberUnits) > 0 && !numberUnitsPattern.MatchString(r.NumberUnits) {
+		return utils.NewErrValidValue("number of units")
+	}
+	return nil
+}
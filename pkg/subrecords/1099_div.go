@@ -54,6 +54,8 @@ type Sub1099DIV struct {
 	// Enter the valid CF/SF code if this payee record is to be
 	// forwarded to a state agency as part of the CF/SF Program.
 	CombinedFSCode int `json:"combined_federal_state_code"`
+
+	taxYear int
 }
 
 // Type returns type of “1099-DIV” record
@@ -61,6 +63,15 @@ func (r *Sub1099DIV) Type() string {
 	return config.Sub1099DivType
 }
 
+// SetTaxYear sets tax year of the record that selects its layout revision
+func (r *Sub1099DIV) SetTaxYear(year int) {
+	r.taxYear = year
+}
+
+func (r *Sub1099DIV) layout() map[string]config.SpecField {
+	return config.SubRecordLayout(r.taxYear, config.Sub1099DivType)
+}
+
 // Type returns FS code of “1099-DIV” record
 func (r *Sub1099DIV) FederalState() int {
 	return r.CombinedFSCode
@@ -78,13 +89,13 @@ func (r *Sub1099DIV) Parse(buf []byte) error {
 		return utils.ErrValidField
 	}
 
-	return utils.ParseValue(fields, config.Sub1099DIVLayout, record)
+	return utils.ParseValue(fields, r.layout(), record)
 }
 
 // Ascii returns fire ascii of “1099-DIV” record
 func (r *Sub1099DIV) Ascii() []byte {
 	var buf bytes.Buffer
-	records := config.ToSpecifications(config.Sub1099DIVLayout)
+	records := config.ToSpecifications(r.layout())
 	fields := reflect.ValueOf(r).Elem()
 	if !fields.IsValid() {
 		return nil
@@ -101,7 +112,7 @@ func (r *Sub1099DIV) Ascii() []byte {
 
 // Validate performs some checks on the record and returns an error if not Validated
 func (r *Sub1099DIV) Validate() error {
-	return utils.Validate(r, config.Sub1099DIVLayout, config.Sub1099DivType)
+	return utils.Validate(r, r.layout(), config.Sub1099DivType)
 }
 
 // customized field validation functions
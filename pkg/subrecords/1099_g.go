@@ -64,6 +64,8 @@ type Sub1099G struct {
 	// Enter the valid CF/SF code if this payee record is to be
 	// forwarded to a state agency as part of the CF/SF Program.
 	CombinedFSCode int `json:"combined_federal_state_code"`
+
+	taxYear int
 }
 
 // Type returns type of “1099-G” record
@@ -71,6 +73,15 @@ func (r *Sub1099G) Type() string {
 	return config.Sub1099GType
 }
 
+// SetTaxYear sets tax year of the record that selects its layout revision
+func (r *Sub1099G) SetTaxYear(year int) {
+	r.taxYear = year
+}
+
+func (r *Sub1099G) layout() map[string]config.SpecField {
+	return config.SubRecordLayout(r.taxYear, config.Sub1099GType)
+}
+
 // Type returns FS code of “1099-G” record
 func (r *Sub1099G) FederalState() int {
 	return r.CombinedFSCode
@@ -88,13 +99,13 @@ func (r *Sub1099G) Parse(buf []byte) error {
 		return utils.ErrValidField
 	}
 
-	return utils.ParseValue(fields, config.Sub1099GLayout, record)
+	return utils.ParseValue(fields, r.layout(), record)
 }
 
 // Ascii returns fire ascii of “1099-G” record
 func (r *Sub1099G) Ascii() []byte {
 	var buf bytes.Buffer
-	records := config.ToSpecifications(config.Sub1099GLayout)
+	records := config.ToSpecifications(r.layout())
 	fields := reflect.ValueOf(r).Elem()
 	if !fields.IsValid() {
 		return nil
@@ -111,7 +122,7 @@ func (r *Sub1099G) Ascii() []byte {
 
 // Validate performs some checks on the record and returns an error if not Validated
 func (r *Sub1099G) Validate() error {
-	return utils.Validate(r, config.Sub1099GLayout, config.Sub1099GType)
+	return utils.Validate(r, r.layout(), config.Sub1099GType)
 }
 
 // customized field validation functions
@@ -25,6 +25,8 @@ type Sub1099H struct {
 	// revenue departments for the filing requirements. If this field is
 	// not used, enter blanks.
 	SpecialDataEntries string `json:"special_data_entries"`
+
+	taxYear int
 }
 
 // Type returns type of “1099-H” record
@@ -32,6 +34,15 @@ func (r *Sub1099H) Type() string {
 	return config.Sub1099HType
 }
 
+// SetTaxYear sets tax year of the record that selects its layout revision
+func (r *Sub1099H) SetTaxYear(year int) {
+	r.taxYear = year
+}
+
+func (r *Sub1099H) layout() map[string]config.SpecField {
+	return config.SubRecordLayout(r.taxYear, config.Sub1099HType)
+}
+
 // Type returns FS code of “1099-H” record
 func (r *Sub1099H) FederalState() int {
 	return 0
@@ -49,13 +60,13 @@ func (r *Sub1099H) Parse(buf []byte) error {
 		return utils.ErrValidField
 	}
 
-	return utils.ParseValue(fields, config.Sub1099HLayout, record)
+	return utils.ParseValue(fields, r.layout(), record)
 }
 
 // Ascii returns fire ascii of “1099-H” record
 func (r *Sub1099H) Ascii() []byte {
 	var buf bytes.Buffer
-	records := config.ToSpecifications(config.Sub1099HLayout)
+	records := config.ToSpecifications(r.layout())
 	fields := reflect.ValueOf(r).Elem()
 	if !fields.IsValid() {
 		return nil
@@ -72,5 +83,5 @@ func (r *Sub1099H) Ascii() []byte {
 
 // Validate performs some checks on the record and returns an error if not Validated
 func (r *Sub1099H) Validate() error {
-	return utils.Validate(r, config.Sub1099HLayout, config.Sub1099HType)
+	return utils.Validate(r, r.layout(), config.Sub1099HType)
 }
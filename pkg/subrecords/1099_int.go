@@ -61,6 +61,8 @@ type Sub1099INT struct {
 	// Enter the valid CF/SF code if this payee record is to be
 	// forwarded to a state agency as part of the CF/SF Program.
 	CombinedFSCode int `json:"combined_federal_state_code"`
+
+	taxYear int
 }
 
 // Type returns type of “1099-INT” record
@@ -68,6 +70,15 @@ func (r *Sub1099INT) Type() string {
 	return config.Sub1099IntType
 }
 
+// SetTaxYear sets tax year of the record that selects its layout revision
+func (r *Sub1099INT) SetTaxYear(year int) {
+	r.taxYear = year
+}
+
+func (r *Sub1099INT) layout() map[string]config.SpecField {
+	return config.SubRecordLayout(r.taxYear, config.Sub1099IntType)
+}
+
 // Type returns FS code of “1099-INT” record
 func (r *Sub1099INT) FederalState() int {
 	return r.CombinedFSCode
@@ -85,13 +96,13 @@ func (r *Sub1099INT) Parse(buf []byte) error {
 		return utils.ErrValidField
 	}
 
-	return utils.ParseValue(fields, config.Sub1099INTLayout, record)
+	return utils.ParseValue(fields, r.layout(), record)
 }
 
 // Ascii returns fire ascii of “1099-INT” record
 func (r *Sub1099INT) Ascii() []byte {
 	var buf bytes.Buffer
-	records := config.ToSpecifications(config.Sub1099INTLayout)
+	records := config.ToSpecifications(r.layout())
 	fields := reflect.ValueOf(r).Elem()
 	if !fields.IsValid() {
 		return nil
@@ -108,7 +119,7 @@ func (r *Sub1099INT) Ascii() []byte {
 
 // Validate performs some checks on the record and returns an error if not Validated
 func (r *Sub1099INT) Validate() error {
-	return utils.Validate(r, config.Sub1099INTLayout, config.Sub1099IntType)
+	return utils.Validate(r, r.layout(), config.Sub1099IntType)
 }
 
 // customized field validation functions
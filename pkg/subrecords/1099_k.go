@@ -75,6 +75,8 @@ type Sub1099K struct {
 	// Enter the valid CF/SF code if this payee record is to be
 	// forwarded to a state agency as part of the CF/SF Program.
 	CombinedFSCode int `json:"combined_federal_state_code"`
+
+	taxYear int
 }
 
 // Type returns type of “1099-K” record
@@ -82,6 +84,15 @@ func (r *Sub1099K) Type() string {
 	return config.Sub1099KType
 }
 
+// SetTaxYear sets tax year of the record that selects its layout revision
+func (r *Sub1099K) SetTaxYear(year int) {
+	r.taxYear = year
+}
+
+func (r *Sub1099K) layout() map[string]config.SpecField {
+	return config.SubRecordLayout(r.taxYear, config.Sub1099KType)
+}
+
 // Type returns FS code of “1099-K” record
 func (r *Sub1099K) FederalState() int {
 	return r.CombinedFSCode
@@ -99,13 +110,13 @@ func (r *Sub1099K) Parse(buf []byte) error {
 		return utils.ErrValidField
 	}
 
-	return utils.ParseValue(fields, config.Sub1099KLayout, record)
+	return utils.ParseValue(fields, r.layout(), record)
 }
 
 // Ascii returns fire ascii of “1099-K” record
 func (r *Sub1099K) Ascii() []byte {
 	var buf bytes.Buffer
-	records := config.ToSpecifications(config.Sub1099KLayout)
+	records := config.ToSpecifications(r.layout())
 	fields := reflect.ValueOf(r).Elem()
 	if !fields.IsValid() {
 		return nil
@@ -122,7 +133,7 @@ func (r *Sub1099K) Ascii() []byte {
 
 // Validate performs some checks on the record and returns an error if not Validated
 func (r *Sub1099K) Validate() error {
-	return utils.Validate(r, config.Sub1099KLayout, config.Sub1099KType)
+	return utils.Validate(r, r.layout(), config.Sub1099KType)
 }
 
 // customized field validation functions
@@ -22,6 +22,8 @@ type Sub1099LS struct {
 
 	// Enter Issuer’s Contact Name.
 	IssuersInformation string `json:"issuers_information"`
+
+	taxYear int
 }
 
 // Type returns type of “1099-LS” record
@@ -29,6 +31,15 @@ func (r *Sub1099LS) Type() string {
 	return config.Sub1099LsType
 }
 
+// SetTaxYear sets tax year of the record that selects its layout revision
+func (r *Sub1099LS) SetTaxYear(year int) {
+	r.taxYear = year
+}
+
+func (r *Sub1099LS) layout() map[string]config.SpecField {
+	return config.SubRecordLayout(r.taxYear, config.Sub1099LsType)
+}
+
 // Type returns FS code of “1099-LS” record
 func (r *Sub1099LS) FederalState() int {
 	return 0
@@ -46,13 +57,13 @@ func (r *Sub1099LS) Parse(buf []byte) error {
 		return utils.ErrValidField
 	}
 
-	return utils.ParseValue(fields, config.Sub1099LSLayout, record)
+	return utils.ParseValue(fields, r.layout(), record)
 }
 
 // Ascii returns fire ascii of “1099-LS” record
 func (r *Sub1099LS) Ascii() []byte {
 	var buf bytes.Buffer
-	records := config.ToSpecifications(config.Sub1099LSLayout)
+	records := config.ToSpecifications(r.layout())
 	fields := reflect.ValueOf(r).Elem()
 	if !fields.IsValid() {
 		return nil
@@ -69,5 +80,5 @@ func (r *Sub1099LS) Ascii() []byte {
 
 // Validate performs some checks on the record and returns an error if not Validated
 func (r *Sub1099LS) Validate() error {
-	return utils.Validate(r, config.Sub1099LSLayout, config.Sub1099LsType)
+	return utils.Validate(r, r.layout(), config.Sub1099LsType)
 }
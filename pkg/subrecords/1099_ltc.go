@@ -98,6 +98,8 @@ type Sub1099LTC struct {
 	// amount must be right justified and unused positions
 	// zero-filled.
 	LocalIncomeTaxWithheld int `json:"local_income_tax_withheld"`
+
+	taxYear int
 }
 
 // Type returns type of “1099-LTC” record
@@ -105,6 +107,15 @@ func (r *Sub1099LTC) Type() string {
 	return config.Sub1099LtcType
 }
 
+// SetTaxYear sets tax year of the record that selects its layout revision
+func (r *Sub1099LTC) SetTaxYear(year int) {
+	r.taxYear = year
+}
+
+func (r *Sub1099LTC) layout() map[string]config.SpecField {
+	return config.SubRecordLayout(r.taxYear, config.Sub1099LtcType)
+}
+
 // Type returns FS code of “1099-LTC” record
 func (r *Sub1099LTC) FederalState() int {
 	return 0
@@ -122,13 +133,13 @@ func (r *Sub1099LTC) Parse(buf []byte) error {
 		return utils.ErrValidField
 	}
 
-	return utils.ParseValue(fields, config.Sub1099LTCLayout, record)
+	return utils.ParseValue(fields, r.layout(), record)
 }
 
 // Ascii returns fire ascii of “1099-LTC” record
 func (r *Sub1099LTC) Ascii() []byte {
 	var buf bytes.Buffer
-	records := config.ToSpecifications(config.Sub1099LTCLayout)
+	records := config.ToSpecifications(r.layout())
 	fields := reflect.ValueOf(r).Elem()
 	if !fields.IsValid() {
 		return nil
@@ -145,7 +156,7 @@ func (r *Sub1099LTC) Ascii() []byte {
 
 // Validate performs some checks on the record and returns an error if not Validated
 func (r *Sub1099LTC) Validate() error {
-	return utils.Validate(r, config.Sub1099LTCLayout, config.Sub1099LtcType)
+	return utils.Validate(r, r.layout(), config.Sub1099LtcType)
 }
 
 // customized field validation functions
@@ -59,6 +59,8 @@ type Sub1099MISC struct {
 	// Enter the valid CF/SF code if this payee record is to be
 	// forwarded to a state agency as part of the CF/SF Program.
 	CombinedFSCode int `json:"combined_federal_state_code"`
+
+	taxYear int
 }
 
 // Type returns type of “1099-MISC” record
@@ -66,6 +68,15 @@ func (r *Sub1099MISC) Type() string {
 	return config.Sub1099MiscType
 }
 
+// SetTaxYear sets tax year of the record that selects its layout revision
+func (r *Sub1099MISC) SetTaxYear(year int) {
+	r.taxYear = year
+}
+
+func (r *Sub1099MISC) layout() map[string]config.SpecField {
+	return config.SubRecordLayout(r.taxYear, config.Sub1099MiscType)
+}
+
 // Type returns FS code of “1099-MISC” record
 func (r *Sub1099MISC) FederalState() int {
 	return r.CombinedFSCode
@@ -83,13 +94,13 @@ func (r *Sub1099MISC) Parse(buf []byte) error {
 		return utils.ErrValidField
 	}
 
-	return utils.ParseValue(fields, config.Sub1099MISCLayout, record)
+	return utils.ParseValue(fields, r.layout(), record)
 }
 
 // Ascii returns fire ascii of “1099-MISC” record
 func (r *Sub1099MISC) Ascii() []byte {
 	var buf bytes.Buffer
-	records := config.ToSpecifications(config.Sub1099MISCLayout)
+	records := config.ToSpecifications(r.layout())
 	fields := reflect.ValueOf(r).Elem()
 	if !fields.IsValid() {
 		return nil
@@ -106,7 +117,7 @@ func (r *Sub1099MISC) Ascii() []byte {
 
 // Validate performs some checks on the record and returns an error if not Validated
 func (r *Sub1099MISC) Validate() error {
-	return utils.Validate(r, config.Sub1099MISCLayout, config.Sub1099MiscType)
+	return utils.Validate(r, r.layout(), config.Sub1099MiscType)
 }
 
 // customized field validation functions
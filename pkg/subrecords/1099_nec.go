@@ -51,6 +51,8 @@ type Sub1099NEC struct {
 	// Participating States and Codes. Enter Blanks for issuers or
 	// states not participating in this program.
 	CombinedFSCode int `json:"combined_federal_state_code"`
+
+	taxYear int
 }
 
 // Type returns type of “1099-NEC” record
@@ -58,6 +60,15 @@ func (r *Sub1099NEC) Type() string {
 	return config.Sub1099NecType
 }
 
+// SetTaxYear sets tax year of the record that selects its layout revision
+func (r *Sub1099NEC) SetTaxYear(year int) {
+	r.taxYear = year
+}
+
+func (r *Sub1099NEC) layout() map[string]config.SpecField {
+	return config.SubRecordLayout(r.taxYear, config.Sub1099NecType)
+}
+
 // Type returns FS code of “1099-NEC” record
 func (r *Sub1099NEC) FederalState() int {
 	return r.CombinedFSCode
@@ -75,13 +86,13 @@ func (r *Sub1099NEC) Parse(buf []byte) error {
 		return utils.ErrValidField
 	}
 
-	return utils.ParseValue(fields, config.Sub1099NECLayout, record)
+	return utils.ParseValue(fields, r.layout(), record)
 }
 
 // Ascii returns fire ascii of “1099-NEC” record
 func (r *Sub1099NEC) Ascii() []byte {
 	var buf bytes.Buffer
-	records := config.ToSpecifications(config.Sub1099NECLayout)
+	records := config.ToSpecifications(r.layout())
 	fields := reflect.ValueOf(r).Elem()
 	if !fields.IsValid() {
 		return nil
@@ -98,7 +109,7 @@ func (r *Sub1099NEC) Ascii() []byte {
 
 // Validate performs some checks on the record and returns an error if not Validated
 func (r *Sub1099NEC) Validate() error {
-	return utils.Validate(r, config.Sub1099NECLayout, config.Sub1099NecType)
+	return utils.Validate(r, r.layout(), config.Sub1099NecType)
 }
 
 // customized field validation functions
@@ -58,6 +58,8 @@ type Sub1099OID struct {
 	// Enter the valid CF/SF code if this payee record is to be
 	// forwarded to a state agency as part of the CF/SF Program.
 	CombinedFSCode int `json:"combined_federal_state_code"`
+
+	taxYear int
 }
 
 // Type returns type of “1099-OID” record
@@ -65,6 +67,15 @@ func (r *Sub1099OID) Type() string {
 	return config.Sub1099OidType
 }
 
+// SetTaxYear sets tax year of the record that selects its layout revision
+func (r *Sub1099OID) SetTaxYear(year int) {
+	r.taxYear = year
+}
+
+func (r *Sub1099OID) layout() map[string]config.SpecField {
+	return config.SubRecordLayout(r.taxYear, config.Sub1099OidType)
+}
+
 // Type returns FS code of “1099-OID” record
 func (r *Sub1099OID) FederalState() int {
 	return r.CombinedFSCode
@@ -82,13 +93,13 @@ func (r *Sub1099OID) Parse(buf []byte) error {
 		return utils.ErrValidField
 	}
 
-	return utils.ParseValue(fields, config.Sub1099OIDLayout, record)
+	return utils.ParseValue(fields, r.layout(), record)
 }
 
 // Ascii returns fire ascii of “1099-OID” record
 func (r *Sub1099OID) Ascii() []byte {
 	var buf bytes.Buffer
-	records := config.ToSpecifications(config.Sub1099OIDLayout)
+	records := config.ToSpecifications(r.layout())
 	fields := reflect.ValueOf(r).Elem()
 	if !fields.IsValid() {
 		return nil
@@ -105,7 +116,7 @@ func (r *Sub1099OID) Ascii() []byte {
 
 // Validate performs some checks on the record and returns an error if not Validated
 func (r *Sub1099OID) Validate() error {
-	return utils.Validate(r, config.Sub1099OIDLayout, config.Sub1099OidType)
+	return utils.Validate(r, r.layout(), config.Sub1099OidType)
 }
 
 // customized field validation functions
@@ -45,6 +45,8 @@ type Sub1099PATR struct {
 	// Enter the valid CF/SF code if this payee record is to be
 	// forwarded to a state agency as part of the CF/SF Program.
 	CombinedFSCode int `json:"combined_federal_state_code"`
+
+	taxYear int
 }
 
 // Type returns type of “1099-PATR” record
@@ -52,6 +54,15 @@ func (r *Sub1099PATR) Type() string {
 	return config.Sub1099PatrType
 }
 
+// SetTaxYear sets tax year of the record that selects its layout revision
+func (r *Sub1099PATR) SetTaxYear(year int) {
+	r.taxYear = year
+}
+
+func (r *Sub1099PATR) layout() map[string]config.SpecField {
+	return config.SubRecordLayout(r.taxYear, config.Sub1099PatrType)
+}
+
 // Type returns FS code of “1099-PATR” record
 func (r *Sub1099PATR) FederalState() int {
 	return r.CombinedFSCode
@@ -69,13 +80,13 @@ func (r *Sub1099PATR) Parse(buf []byte) error {
 		return utils.ErrValidField
 	}
 
-	return utils.ParseValue(fields, config.Sub1099PATRLayout, record)
+	return utils.ParseValue(fields, r.layout(), record)
 }
 
 // Ascii returns fire ascii of “1099-PATR” record
 func (r *Sub1099PATR) Ascii() []byte {
 	var buf bytes.Buffer
-	records := config.ToSpecifications(config.Sub1099PATRLayout)
+	records := config.ToSpecifications(r.layout())
 	fields := reflect.ValueOf(r).Elem()
 	if !fields.IsValid() {
 		return nil
@@ -92,7 +103,7 @@ func (r *Sub1099PATR) Ascii() []byte {
 
 // Validate performs some checks on the record and returns an error if not Validated
 func (r *Sub1099PATR) Validate() error {
-	return utils.Validate(r, config.Sub1099PATRLayout, config.Sub1099PatrType)
+	return utils.Validate(r, r.layout(), config.Sub1099PatrType)
 }
 
 // customized field validation functions
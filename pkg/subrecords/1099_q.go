@@ -37,6 +37,8 @@ type Sub1099Q struct {
 	// your routing and transit number (RTN) here. If this field is not
 	// used, enter blanks.
 	SpecialDataEntries string `json:"special_data_entries"`
+
+	taxYear int
 }
 
 // Type returns type of “1099-Q” record
@@ -44,6 +46,15 @@ func (r *Sub1099Q) Type() string {
 	return config.Sub1099QType
 }
 
+// SetTaxYear sets tax year of the record that selects its layout revision
+func (r *Sub1099Q) SetTaxYear(year int) {
+	r.taxYear = year
+}
+
+func (r *Sub1099Q) layout() map[string]config.SpecField {
+	return config.SubRecordLayout(r.taxYear, config.Sub1099QType)
+}
+
 // Type returns FS code of “1099-Q” record
 func (r *Sub1099Q) FederalState() int {
 	return 0
@@ -61,13 +72,13 @@ func (r *Sub1099Q) Parse(buf []byte) error {
 		return utils.ErrValidField
 	}
 
-	return utils.ParseValue(fields, config.Sub1099QLayout, record)
+	return utils.ParseValue(fields, r.layout(), record)
 }
 
 // Ascii returns fire ascii of “1099-Q” record
 func (r *Sub1099Q) Ascii() []byte {
 	var buf bytes.Buffer
-	records := config.ToSpecifications(config.Sub1099QLayout)
+	records := config.ToSpecifications(r.layout())
 	fields := reflect.ValueOf(r).Elem()
 	if !fields.IsValid() {
 		return nil
@@ -84,7 +95,7 @@ func (r *Sub1099Q) Ascii() []byte {
 
 // Validate performs some checks on the record and returns an error if not Validated
 func (r *Sub1099Q) Validate() error {
-	return utils.Validate(r, config.Sub1099QLayout, config.Sub1099QType)
+	return utils.Validate(r, r.layout(), config.Sub1099QType)
 }
 
 // customized field validation functions
@@ -0,0 +1,121 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package subrecords
+
+import (
+	"bytes"
+	"reflect"
+	"unicode/utf8"
+
+	"github.com/moov-io/irs/pkg/config"
+	"github.com/moov-io/irs/pkg/utils"
+)
+
+type Sub1099QA struct {
+	// Enter “1” (one) if reporting a program-to-program transfer
+	// from one ABLE account to another. Otherwise, enter a blank.
+	ProgramTransferIndicator string `json:"program_transfer_indicator"`
+
+	// Enter “1” (one) if the ABLE account was terminated during the
+	// calendar year. Otherwise, enter a blank.
+	AccountTerminatedIndicator string `json:"account_terminated_indicator"`
+
+	// Enter “1” (one) if the recipient is not the designated
+	// beneficiary. Otherwise, enter a blank.
+	DesignatedBeneficiary string `json:"designated_beneficiary"`
+
+	// This portion of the “B” Record may be used to record
+	// information for state or local government reporting or for the
+	// filer’s own purposes. Payers should contact the state or local
+	// revenue departments for filing requirements. If this field is not
+	// used, enter blanks.
+	SpecialDataEntries string `json:"special_data_entries"`
+
+	taxYear int
+}
+
+// Type returns type of “1099-QA” record
+func (r *Sub1099QA) Type() string {
+	return config.Sub1099QaType
+}
+
+// SetTaxYear sets tax year of the record that selects its layout revision
+func (r *Sub1099QA) SetTaxYear(year int) {
+	r.taxYear = year
+}
+
+func (r *Sub1099QA) layout() map[string]config.SpecField {
+	return config.SubRecordLayout(r.taxYear, config.Sub1099QaType)
+}
+
+// Type returns FS code of “1099-QA” record
+func (r *Sub1099QA) FederalState() int {
+	return 0
+}
+
+// Parse parses the “1099-QA” record from fire ascii
+func (r *Sub1099QA) Parse(buf []byte) error {
+	record := string(buf)
+	if utf8.RuneCountInString(record) != config.SubRecordLength {
+		return utils.ErrRecordLength
+	}
+
+	fields := reflect.ValueOf(r).Elem()
+	if !fields.IsValid() {
+		return utils.ErrValidField
+	}
+
+	return utils.ParseValue(fields, r.layout(), record)
+}
+
+// Ascii returns fire ascii of “1099-QA” record
+func (r *Sub1099QA) Ascii() []byte {
+	var buf bytes.Buffer
+	records := config.ToSpecifications(r.layout())
+	fields := reflect.ValueOf(r).Elem()
+	if !fields.IsValid() {
+		return nil
+	}
+
+	buf.Grow(config.SubRecordLength)
+	for _, spec := range records {
+		value := utils.ToString(spec.Field, fields.FieldByName(spec.Name))
+		buf.WriteString(value)
+	}
+
+	return buf.Bytes()
+}
+
+// Validate performs some checks on the record and returns an error if not Validated
+func (r *Sub1099QA) Validate() error {
+	return utils.Validate(r, r.layout(), config.Sub1099QaType)
+}
+
+// customized field validation functions
+// function name should be "Validate" + field name
+
+func (r *Sub1099QA) ValidateProgramTransferIndicator() error {
+	if len(r.ProgramTransferIndicator) > 0 &&
+		r.ProgramTransferIndicator != config.GeneralOneIndicator {
+		return utils.NewErrValidValue("program transfer indicator")
+	}
+	return nil
+}
+
+func (r *Sub1099QA) ValidateAccountTerminatedIndicator() error {
+	if len(r.AccountTerminatedIndicator) > 0 &&
+		r.AccountTerminatedIndicator != config.GeneralOneIndicator {
+		return utils.NewErrValidValue("account terminated indicator")
+	}
+	return nil
+}
+
+func (r *Sub1099QA) ValidateDesignatedBeneficiary() error {
+	if len(r.DesignatedBeneficiary) > 0 &&
+		r.DesignatedBeneficiary != config.GeneralOneIndicator {
+		return utils.NewErrValidValue("designated beneficiary")
+	}
+	return nil
+}
@@ -156,6 +156,8 @@ type Sub1099R struct {
 	// Enter the valid CF/SF code if this payee record is to be
 	// forwarded to a state agency as part of the CF/SF Program.
 	CombinedFSCode int `json:"combined_federal_state_code"`
+
+	taxYear int
 }
 
 // Type returns type of “1099-R” record
@@ -163,6 +165,15 @@ func (r *Sub1099R) Type() string {
 	return config.Sub1099RType
 }
 
+// SetTaxYear sets tax year of the record that selects its layout revision
+func (r *Sub1099R) SetTaxYear(year int) {
+	r.taxYear = year
+}
+
+func (r *Sub1099R) layout() map[string]config.SpecField {
+	return config.SubRecordLayout(r.taxYear, config.Sub1099RType)
+}
+
 // Type returns FS code of “1099-R” record
 func (r *Sub1099R) FederalState() int {
 	return r.CombinedFSCode
@@ -180,13 +191,13 @@ func (r *Sub1099R) Parse(buf []byte) error {
 		return utils.ErrValidField
 	}
 
-	return utils.ParseValue(fields, config.Sub1099RLayout, record)
+	return utils.ParseValue(fields, r.layout(), record)
 }
 
 // Ascii returns fire ascii of “1099-R” record
 func (r *Sub1099R) Ascii() []byte {
 	var buf bytes.Buffer
-	records := config.ToSpecifications(config.Sub1099RLayout)
+	records := config.ToSpecifications(r.layout())
 	fields := reflect.ValueOf(r).Elem()
 	if !fields.IsValid() {
 		return nil
@@ -203,7 +214,7 @@ func (r *Sub1099R) Ascii() []byte {
 
 // Validate performs some checks on the record and returns an error if not Validated
 func (r *Sub1099R) Validate() error {
-	return utils.Validate(r, config.Sub1099RLayout, config.Sub1099RType)
+	return utils.Validate(r, r.layout(), config.Sub1099RType)
 }
 
 // Unmarshal parses the JSON-encoded data
@@ -65,6 +65,8 @@ type Sub1099S struct {
 	// amount must be right justified and unused positions
 	// zero-filled.
 	LocalIncomeTaxWithheld int `json:"local_income_tax_withheld"`
+
+	taxYear int
 }
 
 // Type returns type of “1099-S” record
@@ -72,6 +74,15 @@ func (r *Sub1099S) Type() string {
 	return config.Sub1099SType
 }
 
+// SetTaxYear sets tax year of the record that selects its layout revision
+func (r *Sub1099S) SetTaxYear(year int) {
+	r.taxYear = year
+}
+
+func (r *Sub1099S) layout() map[string]config.SpecField {
+	return config.SubRecordLayout(r.taxYear, config.Sub1099SType)
+}
+
 // Type returns FS code of “1099-S” record
 func (r *Sub1099S) FederalState() int {
 	return 0
@@ -89,13 +100,13 @@ func (r *Sub1099S) Parse(buf []byte) error {
 		return utils.ErrValidField
 	}
 
-	return utils.ParseValue(fields, config.Sub1099SLayout, record)
+	return utils.ParseValue(fields, r.layout(), record)
 }
 
 // Ascii returns fire ascii of “1099-S” record
 func (r *Sub1099S) Ascii() []byte {
 	var buf bytes.Buffer
-	records := config.ToSpecifications(config.Sub1099SLayout)
+	records := config.ToSpecifications(r.layout())
 	fields := reflect.ValueOf(r).Elem()
 	if !fields.IsValid() {
 		return nil
@@ -112,7 +123,7 @@ func (r *Sub1099S) Ascii() []byte {
 
 // Validate performs some checks on the record and returns an error if not Validated
 func (r *Sub1099S) Validate() error {
-	return utils.Validate(r, config.Sub1099SLayout, config.Sub1099LsType)
+	return utils.Validate(r, r.layout(), config.Sub1099LsType)
 }
 
 // customized field validation functions
@@ -59,6 +59,8 @@ type Sub1099SA struct {
 	// amount must be right justified and unused positions
 	// zero-filled.
 	LocalIncomeTaxWithheld int `json:"local_income_tax_withheld"`
+
+	taxYear int
 }
 
 // Type returns type of “1099-SA” record
@@ -66,6 +68,15 @@ func (r *Sub1099SA) Type() string {
 	return config.Sub1099SaType
 }
 
+// SetTaxYear sets tax year of the record that selects its layout revision
+func (r *Sub1099SA) SetTaxYear(year int) {
+	r.taxYear = year
+}
+
+func (r *Sub1099SA) layout() map[string]config.SpecField {
+	return config.SubRecordLayout(r.taxYear, config.Sub1099SaType)
+}
+
 // Type returns FS code of “1099-SA” record
 func (r *Sub1099SA) FederalState() int {
 	return 0
@@ -83,13 +94,13 @@ func (r *Sub1099SA) Parse(buf []byte) error {
 		return utils.ErrValidField
 	}
 
-	return utils.ParseValue(fields, config.Sub1099SALayout, record)
+	return utils.ParseValue(fields, r.layout(), record)
 }
 
 // Ascii returns fire ascii of “1099-SA” record
 func (r *Sub1099SA) Ascii() []byte {
 	var buf bytes.Buffer
-	records := config.ToSpecifications(config.Sub1099SALayout)
+	records := config.ToSpecifications(r.layout())
 	fields := reflect.ValueOf(r).Elem()
 	if !fields.IsValid() {
 		return nil
@@ -106,7 +117,7 @@ func (r *Sub1099SA) Ascii() []byte {
 
 // Validate performs some checks on the record and returns an error if not Validated
 func (r *Sub1099SA) Validate() error {
-	return utils.Validate(r, config.Sub1099SALayout, config.Sub1099SaType)
+	return utils.Validate(r, r.layout(), config.Sub1099SaType)
 }
 
 // customized field validation functions
@@ -16,6 +16,8 @@ import (
 type Sub1099SB struct {
 	// Enter Issuer’s contact name.
 	IssuersInformation string `json:"issuers_information"`
+
+	taxYear int
 }
 
 // Type returns type of “1099-SB” record
@@ -23,6 +25,15 @@ func (r *Sub1099SB) Type() string {
 	return config.Sub1099SbType
 }
 
+// SetTaxYear sets tax year of the record that selects its layout revision
+func (r *Sub1099SB) SetTaxYear(year int) {
+	r.taxYear = year
+}
+
+func (r *Sub1099SB) layout() map[string]config.SpecField {
+	return config.SubRecordLayout(r.taxYear, config.Sub1099SbType)
+}
+
 // Type returns FS code of “1099-SB” record
 func (r *Sub1099SB) FederalState() int {
 	return 0
@@ -40,13 +51,13 @@ func (r *Sub1099SB) Parse(buf []byte) error {
 		return utils.ErrValidField
 	}
 
-	return utils.ParseValue(fields, config.Sub1099SBLayout, record)
+	return utils.ParseValue(fields, r.layout(), record)
 }
 
 // Ascii returns fire ascii of “1099-SB” record
 func (r *Sub1099SB) Ascii() []byte {
 	var buf bytes.Buffer
-	records := config.ToSpecifications(config.Sub1099SBLayout)
+	records := config.ToSpecifications(r.layout())
 	fields := reflect.ValueOf(r).Elem()
 	if !fields.IsValid() {
 		return nil
@@ -63,5 +74,5 @@ func (r *Sub1099SB) Ascii() []byte {
 
 // Validate performs some checks on the record and returns an error if not Validated
 func (r *Sub1099SB) Validate() error {
-	return utils.Validate(r, config.Sub1099SBLayout, config.Sub1099SbType)
+	return utils.Validate(r, r.layout(), config.Sub1099SbType)
 }
@@ -41,6 +41,8 @@ type Sub3921 struct {
 	// revenue departments for filing requirements.
 	// If this field is not used, enter blanks.
 	SpecialDataEntries string `json:"special_data_entries"`
+
+	taxYear int
 }
 
 // Type returns type of “3921” record
@@ -48,6 +50,15 @@ func (r *Sub3921) Type() string {
 	return config.Sub3921Type
 }
 
+// SetTaxYear sets tax year of the record that selects its layout revision
+func (r *Sub3921) SetTaxYear(year int) {
+	r.taxYear = year
+}
+
+func (r *Sub3921) layout() map[string]config.SpecField {
+	return config.SubRecordLayout(r.taxYear, config.Sub3921Type)
+}
+
 // Type returns FS code of “3921” record
 func (r *Sub3921) FederalState() int {
 	return 0
@@ -65,13 +76,13 @@ func (r *Sub3921) Parse(buf []byte) error {
 		return utils.ErrValidField
 	}
 
-	return utils.ParseValue(fields, config.Sub3921Layout, record)
+	return utils.ParseValue(fields, r.layout(), record)
 }
 
 // Ascii returns fire ascii of “3921” record
 func (r *Sub3921) Ascii() []byte {
 	var buf bytes.Buffer
-	records := config.ToSpecifications(config.Sub3921Layout)
+	records := config.ToSpecifications(r.layout())
 	fields := reflect.ValueOf(r).Elem()
 	if !fields.IsValid() {
 		return nil
@@ -88,5 +99,5 @@ func (r *Sub3921) Ascii() []byte {
 
 // Validate performs some checks on the record and returns an error if not Validated
 func (r *Sub3921) Validate() error {
-	return utils.Validate(r, config.Sub3921Layout, config.Sub3921Type)
+	return utils.Validate(r, r.layout(), config.Sub3921Type)
 }
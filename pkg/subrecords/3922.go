@@ -42,6 +42,8 @@ type Sub3922 struct {
 	// revenue departments for filing requirements.
 	// If this field is not used, enter blanks.
 	SpecialDataEntries string `json:"special_data_entries"`
+
+	taxYear int
 }
 
 // Type returns type of “3922” record
@@ -49,6 +51,15 @@ func (r *Sub3922) Type() string {
 	return config.Sub3922Type
 }
 
+// SetTaxYear sets tax year of the record that selects its layout revision
+func (r *Sub3922) SetTaxYear(year int) {
+	r.taxYear = year
+}
+
+func (r *Sub3922) layout() map[string]config.SpecField {
+	return config.SubRecordLayout(r.taxYear, config.Sub3922Type)
+}
+
 // Type returns FS code of “3922” record
 func (r *Sub3922) FederalState() int {
 	return 0
@@ -66,13 +77,13 @@ func (r *Sub3922) Parse(buf []byte) error {
 		return utils.ErrValidField
 	}
 
-	return utils.ParseValue(fields, config.Sub3922Layout, record)
+	return utils.ParseValue(fields, r.layout(), record)
 }
 
 // Ascii returns fire ascii of “3922” record
 func (r *Sub3922) Ascii() []byte {
 	var buf bytes.Buffer
-	records := config.ToSpecifications(config.Sub3922Layout)
+	records := config.ToSpecifications(r.layout())
 	fields := reflect.ValueOf(r).Elem()
 	if !fields.IsValid() {
 		return nil
@@ -89,5 +100,5 @@ func (r *Sub3922) Ascii() []byte {
 
 // Validate performs some checks on the record and returns an error if not Validated
 func (r *Sub3922) Validate() error {
-	return utils.Validate(r, config.Sub3922Layout, config.Sub3922Type)
+	return utils.Validate(r, r.layout(), config.Sub3922Type)
 }
@@ -99,6 +99,8 @@ type Sub5498 struct {
 	// Enter the valid CF/SF code if this payee record is to be
 	// forwarded to a state agency as part of the CF/SF Program.
 	CombinedFSCode int `json:"combined_federal_state_code"`
+
+	taxYear int
 }
 
 // Type returns type of “5498” record
@@ -106,6 +108,15 @@ func (r *Sub5498) Type() string {
 	return config.Sub5498Type
 }
 
+// SetTaxYear sets tax year of the record that selects its layout revision
+func (r *Sub5498) SetTaxYear(year int) {
+	r.taxYear = year
+}
+
+func (r *Sub5498) layout() map[string]config.SpecField {
+	return config.SubRecordLayout(r.taxYear, config.Sub5498Type)
+}
+
 // Type returns FS code of “5498” record
 func (r *Sub5498) FederalState() int {
 	return r.CombinedFSCode
@@ -123,13 +134,13 @@ func (r *Sub5498) Parse(buf []byte) error {
 		return utils.ErrValidField
 	}
 
-	return utils.ParseValue(fields, config.Sub5498Layout, record)
+	return utils.ParseValue(fields, r.layout(), record)
 }
 
 // Ascii returns fire ascii of “5498” record
 func (r *Sub5498) Ascii() []byte {
 	var buf bytes.Buffer
-	records := config.ToSpecifications(config.Sub5498Layout)
+	records := config.ToSpecifications(r.layout())
 	fields := reflect.ValueOf(r).Elem()
 	if !fields.IsValid() {
 		return nil
@@ -146,7 +157,7 @@ func (r *Sub5498) Ascii() []byte {
 
 // Validate performs some checks on the record and returns an error if not Validated
 func (r *Sub5498) Validate() error {
-	return utils.Validate(r, config.Sub5498Layout, config.Sub5498Type)
+	return utils.Validate(r, r.layout(), config.Sub5498Type)
 }
 
 // customized field validation functions
@@ -20,6 +20,8 @@ type Sub5498ESA struct {
 	// revenue departments for filing requirements.
 	// If this field is not used, enter blanks.
 	SpecialDataEntries string `json:"special_data_entries"`
+
+	taxYear int
 }
 
 // Type returns type of “5498-ESA” record
@@ -27,6 +29,15 @@ func (r *Sub5498ESA) Type() string {
 	return config.Sub5498EsaType
 }
 
+// SetTaxYear sets tax year of the record that selects its layout revision
+func (r *Sub5498ESA) SetTaxYear(year int) {
+	r.taxYear = year
+}
+
+func (r *Sub5498ESA) layout() map[string]config.SpecField {
+	return config.SubRecordLayout(r.taxYear, config.Sub5498EsaType)
+}
+
 // Type returns FS code of “5498-ESA” record
 func (r *Sub5498ESA) FederalState() int {
 	return 0
@@ -44,13 +55,13 @@ func (r *Sub5498ESA) Parse(buf []byte) error {
 		return utils.ErrValidField
 	}
 
-	return utils.ParseValue(fields, config.Sub5498ESALayout, record)
+	return utils.ParseValue(fields, r.layout(), record)
 }
 
 // Ascii returns fire ascii of “5498-ESA” record
 func (r *Sub5498ESA) Ascii() []byte {
 	var buf bytes.Buffer
-	records := config.ToSpecifications(config.Sub5498ESALayout)
+	records := config.ToSpecifications(r.layout())
 	fields := reflect.ValueOf(r).Elem()
 	if !fields.IsValid() {
 		return nil
@@ -67,5 +78,5 @@ func (r *Sub5498ESA) Ascii() []byte {
 
 // Validate performs some checks on the record and returns an error if not Validated
 func (r *Sub5498ESA) Validate() error {
-	return utils.Validate(r, config.Sub5498ESALayout, config.Sub5498EsaType)
+	return utils.Validate(r, r.layout(), config.Sub5498EsaType)
 }
@@ -0,0 +1,107 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package subrecords
+
+import (
+	"bytes"
+	"reflect"
+	"unicode/utf8"
+
+	"github.com/moov-io/irs/pkg/config"
+	"github.com/moov-io/irs/pkg/utils"
+)
+
+type Sub5498QA struct {
+	// Enter the code for the basis of eligibility of the designated
+	// beneficiary. Otherwise, enter a blank.
+	// 1: Developmental disorders
+	// 2: Intellectual disability
+	// 3: Psychiatric disorders
+	// 4: Nervous system disorders
+	// 5: Congenital anomalies
+	// 6: Other
+	DisabilityCode string `json:"disability_code"`
+
+	// This portion of the “B” Record may be used to record
+	// information for state or local government reporting or for the
+	// filer’s own purposes. Payers should contact the state or local
+	// revenue departments for filing requirements.
+	// If this field is not used, enter blanks.
+	SpecialDataEntries string `json:"special_data_entries"`
+
+	taxYear int
+}
+
+// Type returns type of “5498-QA” record
+func (r *Sub5498QA) Type() string {
+	return config.Sub5498QaType
+}
+
+// SetTaxYear sets tax year of the record that selects its layout revision
+func (r *Sub5498QA) SetTaxYear(year int) {
+	r.taxYear = year
+}
+
+func (r *Sub5498QA) layout() map[string]config.SpecField {
+	return config.SubRecordLayout(r.taxYear, config.Sub5498QaType)
+}
+
+// Type returns FS code of “5498-QA” record
+func (r *Sub5498QA) FederalState() int {
+	return 0
+}
+
+// Parse parses the “5498-QA” record from fire ascii
+func (r *Sub5498QA) Parse(buf []byte) error {
+	record := string(buf)
+	if utf8.RuneCountInString(record) != config.SubRecordLength {
+		return utils.ErrRecordLength
+	}
+
+	fields := reflect.ValueOf(r).Elem()
+	if !fields.IsValid() {
+		return utils.ErrValidField
+	}
+
+	return utils.ParseValue(fields, r.layout(), record)
+}
+
+// Ascii returns fire ascii of “5498-QA” record
+func (r *Sub5498QA) Ascii() []byte {
+	var buf bytes.Buffer
+	records := config.ToSpecifications(r.layout())
+	fields := reflect.ValueOf(r).Elem()
+	if !fields.IsValid() {
+		return nil
+	}
+
+	buf.Grow(config.SubRecordLength)
+	for _, spec := range records {
+		value := utils.ToString(spec.Field, fields.FieldByName(spec.Name))
+		buf.WriteString(value)
+	}
+
+	return buf.Bytes()
+}
+
+// Validate performs some checks on the record and returns an error if not Validated
+func (r *Sub5498QA) Validate() error {
+	return utils.Validate(r, r.layout(), config.Sub5498QaType)
+}
+
+// customized field validation functions
+// function name should be "Validate" + field name
+
+func (r *Sub5498QA) ValidateDisabilityCode() error {
+	if len(r.DisabilityCode) > 0 {
+		switch r.DisabilityCode {
+		case "1", "2", "3", "4", "5", "6":
+			return nil
+		default:
+			return utils.NewErrValidValue("disability code")
+		}
+	}
+	return nil
+}
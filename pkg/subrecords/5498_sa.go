@@ -30,6 +30,8 @@ type Sub5498SA struct {
 	// revenue departments for filing requirements.
 	// If this field is not used, enter blanks.
 	SpecialDataEntries string `json:"special_data_entries"`
+
+	taxYear int
 }
 
 // Type returns type of “5498-SA” record
@@ -37,6 +39,15 @@ func (r *Sub5498SA) Type() string {
 	return config.Sub5498SaType
 }
 
+// SetTaxYear sets tax year of the record that selects its layout revision
+func (r *Sub5498SA) SetTaxYear(year int) {
+	r.taxYear = year
+}
+
+func (r *Sub5498SA) layout() map[string]config.SpecField {
+	return config.SubRecordLayout(r.taxYear, config.Sub5498SaType)
+}
+
 // Type returns FS code of “5498-SA” record
 func (r *Sub5498SA) FederalState() int {
 	return 0
@@ -54,13 +65,13 @@ func (r *Sub5498SA) Parse(buf []byte) error {
 		return utils.ErrValidField
 	}
 
-	return utils.ParseValue(fields, config.Sub5498SALayout, record)
+	return utils.ParseValue(fields, r.layout(), record)
 }
 
 // Ascii returns fire ascii of “5498-SA” record
 func (r *Sub5498SA) Ascii() []byte {
 	var buf bytes.Buffer
-	records := config.ToSpecifications(config.Sub5498SALayout)
+	records := config.ToSpecifications(r.layout())
 	fields := reflect.ValueOf(r).Elem()
 	if !fields.IsValid() {
 		return nil
@@ -77,7 +88,7 @@ func (r *Sub5498SA) Ascii() []byte {
 
 // Validate performs some checks on the record and returns an error if not Validated
 func (r *Sub5498SA) Validate() error {
-	return utils.Validate(r, config.Sub5498SALayout, config.Sub5498SaType)
+	return utils.Validate(r, r.layout(), config.Sub5498SaType)
 }
 
 // customized field validation functions
@@ -18,6 +18,19 @@ type SubRecord interface {
 	Validate() error
 }
 
+// TaxYearSetter is implemented by subrecords whose layout revision depends on the tax year
+// of the “B” record
+type TaxYearSetter interface {
+	SetTaxYear(int)
+}
+
+// SetTaxYear sets tax year of the subrecord if it implements TaxYearSetter
+func SetTaxYear(record SubRecord, year int) {
+	if setter, ok := record.(TaxYearSetter); ok {
+		setter.SetTaxYear(year)
+	}
+}
+
 // NewSubRecord returns a new sub record with type of return
 func NewSubRecord(recordType string) (SubRecord, error) {
 	var newRecord SubRecord
@@ -44,6 +57,8 @@ func NewSubRecord(recordType string) (SubRecord, error) {
 		newRecord = &Sub1099C{}
 	case config.Sub1099CapType:
 		newRecord = &Sub1099CAP{}
+	case config.Sub1099DaType:
+		newRecord = &Sub1099DA{}
 	case config.Sub1099DivType:
 		newRecord = &Sub1099DIV{}
 	case config.Sub1099GType:
@@ -77,6 +92,8 @@ func newSubRecord(recordType string) (SubRecord, error) {
 		newRecord = &Sub1099NEC{}
 	case config.Sub1099QType:
 		newRecord = &Sub1099Q{}
+	case config.Sub1099QaType:
+		newRecord = &Sub1099QA{}
 	case config.Sub1099RType:
 		newRecord = &Sub1099R{}
 	case config.Sub1099SType:
@@ -93,6 +110,8 @@ func newSubRecord(recordType string) (SubRecord, error) {
 		newRecord = &Sub5498{}
 	case config.Sub5498EsaType:
 		newRecord = &Sub5498ESA{}
+	case config.Sub5498QaType:
+		newRecord = &Sub5498QA{}
 	case config.Sub5498SaType:
 		newRecord = &Sub5498SA{}
 	case config.SubW2GType:
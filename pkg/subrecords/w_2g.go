@@ -90,6 +90,8 @@ type SubW2G struct {
 	// amount must be right justified and unused positions
 	// zero-filled.
 	LocalIncomeTaxWithheld int `json:"local_income_tax_withheld"`
+
+	taxYear int
 }
 
 // Type returns type of “W-2G” record
@@ -97,6 +99,15 @@ func (r *SubW2G) Type() string {
 	return config.SubW2GType
 }
 
+// SetTaxYear sets tax year of the record that selects its layout revision
+func (r *SubW2G) SetTaxYear(year int) {
+	r.taxYear = year
+}
+
+func (r *SubW2G) layout() map[string]config.SpecField {
+	return config.SubRecordLayout(r.taxYear, config.SubW2GType)
+}
+
 // Type returns FS code of “W-2G” record
 func (r *SubW2G) FederalState() int {
 	return 0
@@ -114,13 +125,13 @@ func (r *SubW2G) Parse(buf []byte) error {
 		return utils.ErrValidField
 	}
 
-	return utils.ParseValue(fields, config.SubW2GLayout, record)
+	return utils.ParseValue(fields, r.layout(), record)
 }
 
 // Ascii returns fire ascii of “W-2G” record
 func (r *SubW2G) Ascii() []byte {
 	var buf bytes.Buffer
-	records := config.ToSpecifications(config.SubW2GLayout)
+	records := config.ToSpecifications(r.layout())
 	fields := reflect.ValueOf(r).Elem()
 	if !fields.IsValid() {
 		return nil
@@ -137,7 +148,7 @@ func (r *SubW2G) Ascii() []byte {
 
 // Validate performs some checks on the record and returns an error if not Validated
 func (r *SubW2G) Validate() error {
-	return utils.Validate(r, config.SubW2GLayout, config.SubW2GType)
+	return utils.Validate(r, r.layout(), config.SubW2GType)
 }
 
 // customized field validation functions
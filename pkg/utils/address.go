@@ -0,0 +1,185 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package utils
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+
+	"github.com/moov-io/irs/pkg/config"
+)
+
+// Address is a mailing address of payer “A” and payee “B” records
+type Address struct {
+	Street  string
+	City    string
+	State   string
+	ZipCode string
+	// Country is the name of the country of a foreign address
+	Country string
+	// Foreign is true if the address is in a foreign country
+	Foreign bool
+}
+
+// lengths of the city, state and ZIP Code fields, which foreign addresses use as one 51-position field
+const (
+	addressCityLength    = 40
+	addressStateLength   = 2
+	addressZipLength     = 9
+	foreignAddressLength = addressCityLength + addressStateLength + addressZipLength
+)
+
+// transliterations are ascii forms of letters and punctuation without a canonical decomposition
+var transliterations = map[rune]string{
+	'ß': "SS", 'Æ': "AE", 'æ': "AE", 'Œ': "OE", 'œ': "OE", 'Ø': "O", 'ø': "O",
+	'Ð': "D", 'ð': "D", 'Đ': "D", 'đ': "D", 'Þ': "TH", 'þ': "TH", 'Ł': "L", 'ł': "L",
+	'Ħ': "H", 'ħ': "H", 'ı': "I", '‘': "'", '’': "'", '“': `"`, '”': `"`, '–': "-", '—': "-",
+}
+
+// stateAliases are names of states that differ from the names of config.StateAbbreviationCodes
+var stateAliases = map[string]string{
+	"NORTHERN MARIANA ISLANDS": "MP",
+	"VIRGIN ISLANDS":           "VI",
+	"WASHINGTON DC":            "DC",
+}
+
+// NormalizeText transliterates the text to upper case characters allowed in records
+//
+// Accented letters lose their accents (“Müller” is “MULLER”), other letters
+// and punctuation use their common ascii forms (“Straße” is “STRASSE”) and
+// remaining characters become blanks. Consecutive blanks are collapsed.
+func NormalizeText(text string) string {
+	var buf strings.Builder
+	for _, r := range norm.NFKD.String(text) {
+		if unicode.Is(unicode.Mn, r) {
+			continue
+		}
+		if ascii, ok := transliterations[r]; ok {
+			buf.WriteString(ascii)
+			continue
+		}
+		buf.WriteRune(unicode.ToUpper(r))
+	}
+	return strings.Join(strings.Fields(upperAlphanumericRegex.ReplaceAllString(buf.String(), " ")), " ")
+}
+
+// StateAbbreviation returns the U.S. Postal Service abbreviation of the state abbreviation or name,
+// or blank if the state is unknown
+//
+// The military state codes AA, AE and AP and their names (“Armed Forces Europe”) are supported.
+func StateAbbreviation(state string) string {
+	key := stateKey(state)
+	if _, ok := config.StateAbbreviationCodes[key]; ok {
+		return key
+	}
+	if _, ok := config.MilitaryStateCodes[key]; ok {
+		return key
+	}
+	if abbreviation, ok := stateAliases[key]; ok {
+		return abbreviation
+	}
+	for _, codes := range []map[string]string{config.StateAbbreviationCodes, config.MilitaryStateCodes} {
+		for abbreviation, name := range codes {
+			if stateKey(name) == key {
+				return abbreviation
+			}
+		}
+	}
+	return ""
+}
+
+// NormalizeAddress returns the address with text allowed in records
+//
+// U.S. addresses get state abbreviations and ZIP Codes of five or nine digits
+// without hyphens. Military addresses must have APO, FPO or DPO as city and
+// AA, AE or AP as state. Foreign addresses are laid out as one continuous
+// field of city, province or state, postal code and country over the city,
+// state and ZIP Code fields, as described by Publication 1220.
+func NormalizeAddress(address Address) (Address, error) {
+	normalized := Address{Street: NormalizeText(address.Street), Foreign: address.Foreign}
+	if address.Foreign {
+		city, state, zipCode, err := layoutForeignAddress(address.City, address.State, address.ZipCode, address.Country)
+		if err != nil {
+			return address, err
+		}
+		normalized.City, normalized.State, normalized.ZipCode = city, state, zipCode
+		return normalized, nil
+	}
+
+	city := NormalizeText(address.City)
+	if code := strings.ReplaceAll(city, ".", ""); len(config.MilitaryCityCodes[code]) > 0 {
+		city = code
+	}
+	state := StateAbbreviation(address.State)
+	if len(state) == 0 {
+		return address, NewErrValidValue("state")
+	}
+	_, militaryCity := config.MilitaryCityCodes[city]
+	_, militaryState := config.MilitaryStateCodes[state]
+	if militaryCity != militaryState {
+		return address, ErrMilitaryAddress
+	}
+	zipCode := strings.NewReplacer("-", "", " ", "").Replace(address.ZipCode)
+	if len(zipCode) > 0 && ((len(zipCode) != 5 && len(zipCode) != addressZipLength) || IsNumeric(zipCode) != nil) {
+		return address, NewErrValidValue("zip code")
+	}
+
+	normalized.City, normalized.State, normalized.ZipCode = city, state, zipCode
+	return normalized, nil
+}
+
+// ValidateZipCodeState validates that the first three digits of the ZIP Code belong to the state
+//
+// Blank and non-numeric ZIP Codes and states without known ZIP Code prefixes are not checked.
+func ValidateZipCodeState(zipCode, state string) error {
+	ranges, ok := config.ZipCodePrefixes[state]
+	if !ok || len(zipCode) < 5 || IsNumeric(zipCode) != nil {
+		return nil
+	}
+	prefix, err := strconv.Atoi(zipCode[:3])
+	if err != nil {
+		return nil
+	}
+	for _, r := range ranges {
+		if prefix >= r[0] && prefix <= r[1] {
+			return nil
+		}
+	}
+	return NewErrZipCodeState(zipCode, state)
+}
+
+func stateKey(state string) string {
+	return strings.Join(strings.Fields(strings.ReplaceAll(NormalizeText(state), ".", " ")), " ")
+}
+
+// layoutForeignAddress joins the parts with blanks and splits them over the city, state and ZIP Code fields
+//
+// A part that follows a full city or state field is joined without a blank,
+// so an address that is already laid out keeps its layout.
+func layoutForeignAddress(parts ...string) (string, string, string, error) {
+	text := ""
+	for _, part := range parts {
+		part = NormalizeText(part)
+		if len(part) == 0 {
+			continue
+		}
+		if len(text) > 0 && len(text) != addressCityLength && len(text) != addressCityLength+addressStateLength {
+			text += " "
+		}
+		text += part
+	}
+	if len(text) > foreignAddressLength {
+		return "", "", "", NewErrValidValue("foreign address")
+	}
+
+	text += strings.Repeat(" ", foreignAddressLength-len(text))
+	city := strings.TrimRight(text[:addressCityLength], " ")
+	state := strings.TrimRight(text[addressCityLength:addressCityLength+addressStateLength], " ")
+	zipCode := strings.TrimRight(text[addressCityLength+addressStateLength:], " ")
+	return city, state, zipCode, nil
+}
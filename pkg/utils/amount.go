@@ -0,0 +1,114 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package utils
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/moov-io/irs/pkg/config"
+)
+
+// maxDollars is the largest whole dollar amount of cents that fit an int64
+const maxDollars = math.MaxInt64/100 - 1
+
+// ParseDollars returns the cents of a decimal dollar amount like "1234.56"
+//
+// Amounts are parsed exactly, without floating point. A leading sign and up
+// to two decimals are allowed, sub-cent precision, exponents, dollar signs
+// and digit grouping aren't.
+func ParseDollars(value string) (int, error) {
+	sign, digits := 1, value
+	if strings.HasPrefix(digits, "-") {
+		sign, digits = -1, digits[1:]
+	} else {
+		digits = strings.TrimPrefix(digits, "+")
+	}
+	whole, fraction := digits, ""
+	if index := strings.Index(digits, "."); index >= 0 {
+		whole, fraction = digits[:index], digits[index+1:]
+	}
+	if len(whole)+len(fraction) == 0 || len(fraction) > 2 || !isDigits(whole) || !isDigits(fraction) {
+		return 0, ErrInvalidDollarAmount
+	}
+
+	dollars := int64(0)
+	if len(whole) > 0 {
+		var err error
+		if dollars, err = strconv.ParseInt(whole, 10, 64); err != nil || dollars > maxDollars {
+			return 0, ErrInvalidDollarAmount
+		}
+	}
+	cents, _ := strconv.Atoi(fraction + strings.Repeat("0", 2-len(fraction)))
+	return sign * (int(dollars)*100 + cents), nil
+}
+
+// FormatDollars returns cents as a decimal dollar amount with two decimals
+func FormatDollars(cents int) string {
+	sign := ""
+	if cents < 0 {
+		sign, cents = "-", -cents
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, cents/100, cents%100)
+}
+
+func isDigits(value string) bool {
+	for _, r := range value {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// negativeOverPunch are the characters of negative over-punch in the units position by digit
+var negativeOverPunch = map[byte]int{
+	'}': 0, 'J': 1, 'K': 2, 'L': 3, 'M': 4, 'N': 5, 'O': 6, 'P': 7, 'Q': 8, 'R': 9,
+}
+
+// ParseSignedNumeric returns the amount of a signed fixed-width field
+//
+// Amounts are digits with an optional “+” or “-” sign in the left-most
+// position, or with a negative over-punch character (“}”, “J” to “R”) in the
+// units position instead of a minus sign. Blank fields are zero.
+func ParseSignedNumeric(data string) (int, error) {
+	data = strings.Trim(data, config.BlankString)
+	if len(data) == 0 {
+		return 0, nil
+	}
+
+	sign := 1
+	switch data[0] {
+	case '-':
+		sign, data = -1, data[1:]
+	case '+':
+		data = data[1:]
+	default:
+		if digit, ok := negativeOverPunch[data[len(data)-1]]; ok {
+			sign, data = -1, data[:len(data)-1]+strconv.Itoa(digit)
+		}
+	}
+	if IsNumeric(data) != nil {
+		return 0, ErrNumeric
+	}
+	value, err := strconv.ParseInt(data, 10, 64)
+	if err != nil {
+		return 0, ErrNumeric
+	}
+	return sign * int(value), nil
+}
+
+// FormatSignedNumeric returns the amount as a signed fixed-width field of the length
+//
+// Negative amounts have a “-” sign in the left-most position, positive
+// amounts are zero filled digits without sign.
+func FormatSignedNumeric(value, length int) string {
+	if value >= 0 {
+		return fmt.Sprintf("%0*d", length, value)
+	}
+	return fmt.Sprintf("-%0*d", length-1, -value)
+}
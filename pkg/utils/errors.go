@@ -78,29 +78,239 @@ var (
 	ErrUnsupportedPdf = errors.New("is unsupported pdf")
 	// ErrUnsupportedField is given when is not supported field of B record
 	ErrUnsupportedField = errors.New("is not supported field of B record")
+	// ErrUnexpectedRecordOrder is given when a record appears where the file layout does not allow it
+	ErrUnexpectedRecordOrder = errors.New("has unexpected record order")
+	// ErrIncompleteFile is given when the file ends before the end of transmission record
+	ErrIncompleteFile = errors.New("should end with end of transmission record")
+	// ErrNoCorrections is given when the amended file has no differences from the original file
+	ErrNoCorrections = errors.New("has no corrections")
+	// ErrUnmatchedPayee is given when a payee of the amended file has no original return
+	ErrUnmatchedPayee = errors.New("has payee without original return")
+	// ErrMixedTaxYears is given when merged files or payers have different tax years
+	ErrMixedTaxYears = errors.New("has different tax years")
+	// ErrMixedTCC is given when merged files have different transmitter control codes
+	ErrMixedTCC = errors.New("has different transmitter control codes")
+	// ErrInvalidSplit is given when a file can't be split by the requested key
+	ErrInvalidSplit = errors.New("is an invalid split key")
+	// ErrInvalidMapping is given when a csv mapping refers to unknown columns or fields
+	ErrInvalidMapping = errors.New("is an invalid csv mapping")
+	// ErrMilitaryAddress is given when a military address hasn't both military city and state codes
+	ErrMilitaryAddress = errors.New("should have APO, FPO or DPO city with AA, AE or AP state")
+	// ErrZeroPaymentAmounts is given when a payee record has no payment amounts and no direct sales indicator
+	ErrZeroPaymentAmounts = errors.New("has no payment amounts and no direct sales indicator")
+	// ErrUnknownProfile is given when a validation profile doesn't exist
+	ErrUnknownProfile = errors.New("is an unknown validation profile")
+	// ErrInvalidMask is given when a kind of personal data to mask is unknown
+	ErrInvalidMask = errors.New("is an invalid kind of personal data to mask")
+	// ErrInvalidDollarAmount is given when a decimal dollar amount isn't dollars with up to two decimals
+	ErrInvalidDollarAmount = errors.New("is an invalid dollar amount")
+	// ErrProductionTestFile is given when a production file has the test file indicator
+	ErrProductionTestFile = errors.New("should not have test file indicator in production file")
+	// ErrMissingTestFileIndicator is given when a test file has no test file indicator
+	ErrMissingTestFileIndicator = errors.New("should have test file indicator in test file")
+	// ErrUnsupportedIris is given when a return can't be written to or read from an IRIS transmission
+	ErrUnsupportedIris = errors.New("is not supported by iris transmissions")
 )
 
+// Error codes reported with validation results
+const (
+	CodeInvalidValue          = "invalid_value"
+	CodeRequiredField         = "required_field"
+	CodeInvalidRecordType     = "invalid_record_type"
+	CodeInvalidSequenceNumber = "invalid_sequence_number"
+	CodeUnexpectedRecord      = "unexpected_record"
+	CodeInvalidFormat         = "invalid_format"
+	CodeInvalidRecord         = "invalid_record"
+	CodeInvalidNumberPayees   = "invalid_number_payees"
+	CodeInvalidNumberPayers   = "invalid_number_payers"
+	CodeReturnIndicator       = "incorrect_return_indicator"
+	CodeInvalidTotalAmounts   = "invalid_total_amounts"
+	CodeUnexpectedPayment     = "unexpected_payment_amount"
+	CodeUnexpectedTotal       = "unexpected_total_amount"
+	CodeInvalidTypeOfReturn   = "invalid_type_of_return"
+	CodeDuplicatedFSCode      = "duplicated_fs_code"
+	CodeCFSFProgram           = "invalid_cfsf_program"
+	CodeCFSFState             = "invalid_cfsf_state"
+	CodeMissingExtension      = "missing_extension_block"
+	CodeRecordOrder           = "unexpected_record_order"
+	CodeNoCorrections         = "no_corrections"
+	CodeUnmatchedPayee        = "unmatched_payee"
+	CodeMixedTaxYears         = "mixed_tax_years"
+	CodeMixedTCC              = "mixed_tcc"
+	CodeNameControlMismatch   = "name_control_mismatch"
+	CodeInvalidTIN            = "invalid_tin"
+	CodeTINTypeMismatch       = "tin_type_mismatch"
+	CodeTypeOfTINInferred     = "type_of_tin_inferred"
+	CodeZipCodeState          = "zip_code_state_mismatch"
+	CodeBelowThreshold        = "below_filing_threshold"
+	CodeZeroPayment           = "zero_payment_amounts"
+	CodeWithholdingExceeds    = "withholding_exceeds_gross"
+	CodeProductionTestFile    = "test_file_in_production"
+	CodeTestFileIndicator     = "missing_test_file_indicator"
+	CodeNegativeAmount        = "negative_amount"
+	CodeUnsupportedIris       = "unsupported_by_iris"
+)
+
+var errorCodes = []struct {
+	err  error
+	code string
+}{
+	{ErrNonAlphanumeric, CodeInvalidFormat},
+	{ErrNumeric, CodeInvalidFormat},
+	{ErrPhoneNumber, CodeInvalidFormat},
+	{ErrValidDate, CodeInvalidFormat},
+	{ErrEmail, CodeInvalidFormat},
+	{ErrRecordLength, CodeInvalidFormat},
+	{ErrShortRecord, CodeInvalidFormat},
+	{ErrInvalidAscii, CodeInvalidFormat},
+	{ErrValidField, CodeInvalidRecord},
+	{ErrInvalidFile, CodeInvalidRecord},
+	{ErrPayeeExtBlock, CodeMissingExtension},
+	{ErrNonExistPayer, CodeInvalidRecord},
+	{ErrNonExistEndPayer, CodeInvalidRecord},
+	{ErrNonExistPayee, CodeInvalidRecord},
+	{ErrInvalidNumberPayees, CodeInvalidNumberPayees},
+	{ErrInvalidNumberPayers, CodeInvalidNumberPayers},
+	{ErrIncorrectReturnIndicator, CodeReturnIndicator},
+	{ErrInvalidTotalAmounts, CodeInvalidTotalAmounts},
+	{ErrUnexpectedPaymentAmount, CodeUnexpectedPayment},
+	{ErrUnexpectedTotalAmount, CodeUnexpectedTotal},
+	{ErrInvalidTypeOfReturn, CodeInvalidTypeOfReturn},
+	{ErrDuplicatedFSCode, CodeDuplicatedFSCode},
+	{ErrCFSFProgram, CodeCFSFProgram},
+	{ErrCFSFState, CodeCFSFState},
+	{ErrInvalidTCC, CodeInvalidValue},
+	{ErrUnsupportedBlock, CodeMissingExtension},
+	{ErrUnexpectedRecordOrder, CodeRecordOrder},
+	{ErrIncompleteFile, CodeRecordOrder},
+	{ErrNoCorrections, CodeNoCorrections},
+	{ErrUnmatchedPayee, CodeUnmatchedPayee},
+	{ErrMixedTaxYears, CodeMixedTaxYears},
+	{ErrMixedTCC, CodeMixedTCC},
+	{ErrInvalidSplit, CodeInvalidValue},
+	{ErrInvalidMapping, CodeInvalidValue},
+	{ErrZeroPaymentAmounts, CodeZeroPayment},
+	{ErrUnknownProfile, CodeInvalidValue},
+	{ErrInvalidMask, CodeInvalidValue},
+	{ErrInvalidDollarAmount, CodeInvalidFormat},
+	{ErrProductionTestFile, CodeProductionTestFile},
+	{ErrMissingTestFileIndicator, CodeTestFileIndicator},
+	{ErrUnsupportedIris, CodeUnsupportedIris},
+}
+
+// codeError is an error with a stable error code
+type codeError struct {
+	code string
+	msg  string
+}
+
+func (e *codeError) Error() string {
+	return e.msg
+}
+
+// FieldError identifies the field of a record that failed validation
+type FieldError struct {
+	// FieldName is the name of the struct field
+	FieldName string
+	// Start is the zero-based position of the field in the record
+	Start int
+	// Length is the length of the field
+	Length int
+	// Err is the validation error of the field
+	Err error
+	// Severity is the severity of the error, see Severity
+	Severity string
+}
+
+func (e *FieldError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// ErrorCode returns the stable error code of the error
+func ErrorCode(err error) string {
+	if err == nil {
+		return ""
+	}
+	var ce *codeError
+	if errors.As(err, &ce) {
+		return ce.code
+	}
+	for _, item := range errorCodes {
+		if errors.Is(err, item.err) {
+			return item.code
+		}
+	}
+	return CodeInvalidValue
+}
+
 // NewErrValidValue returns a error that has invalid value
 func NewErrValidValue(field string) error {
-	return fmt.Errorf("is an invalid value of %s", field)
+	return &codeError{CodeInvalidValue, fmt.Sprintf("is an invalid value of %s", field)}
 }
 
 // NewErrRecordType returns a error that has invalid record type
 func NewErrRecordType(field string) error {
-	return fmt.Errorf("has invalid record type (%s)", field)
+	return &codeError{CodeInvalidRecordType, fmt.Sprintf("has invalid record type (%s)", field)}
 }
 
 // NewErrFieldRequired returns a error that has empty required field
 func NewErrFieldRequired(field string) error {
-	return fmt.Errorf("is required field (%s)", field)
+	return &codeError{CodeRequiredField, fmt.Sprintf("is required field (%s)", field)}
 }
 
 // NewErrRecordSequenceNumber returns a error that has invalid record sequence number
 func NewErrRecordSequenceNumber(field string) error {
-	return fmt.Errorf("has invalid record sequence number (%s)", field)
+	return &codeError{CodeInvalidSequenceNumber, fmt.Sprintf("has invalid record sequence number (%s)", field)}
 }
 
 // NewErrUnexpectedRecord returns a error that has unexpected record
 func NewErrUnexpectedRecord(name string, record interface{}) error {
-	return fmt.Errorf("unexpected %s record, but got %T", name, record)
+	return &codeError{CodeUnexpectedRecord, fmt.Sprintf("unexpected %s record, but got %T", name, record)}
+}
+
+// NewErrNameControl returns a error that has name control different from the derived name control
+func NewErrNameControl(supplied, derived string) error {
+	return &codeError{CodeNameControlMismatch, fmt.Sprintf("has name control %s, but derived %s", supplied, derived)}
+}
+
+// NewErrTIN returns a error that has structurally invalid tin
+func NewErrTIN(reason string) error {
+	return &codeError{CodeInvalidTIN, fmt.Sprintf("is an invalid tin, %s", reason)}
+}
+
+// NewErrTINType returns a error that has tin of a kind not allowed with the type of tin
+func NewErrTINType(kind, typeOfTIN string) error {
+	return &codeError{CodeTINTypeMismatch, fmt.Sprintf("has %s with type of tin %s", kind, typeOfTIN)}
+}
+
+// NewErrTypeOfTINInferred returns a error that has blank type of tin
+func NewErrTypeOfTINInferred(typeOfTIN string) error {
+	if len(typeOfTIN) == 0 {
+		return &codeError{CodeTypeOfTINInferred, "has blank type of tin, which can't be inferred"}
+	}
+	return &codeError{CodeTypeOfTINInferred, fmt.Sprintf("has blank type of tin, inferred %s", typeOfTIN)}
+}
+
+// NewErrZipCodeState returns a error that has zip code of another state
+func NewErrZipCodeState(zipCode, state string) error {
+	return &codeError{CodeZipCodeState, fmt.Sprintf("has zip code %s, which isn't in state %s", zipCode, state)}
+}
+
+// NewErrBelowThreshold returns a error that has payment amounts below the filing thresholds
+func NewErrBelowThreshold(amounts string) error {
+	return &codeError{CodeBelowThreshold, fmt.Sprintf("has payment amounts below filing threshold (%s)", amounts)}
+}
+
+// NewErrWithholdingExceeds returns a error that has federal income tax withheld greater than the gross amount
+func NewErrWithholdingExceeds(withheld, gross string) error {
+	return &codeError{CodeWithholdingExceeds, fmt.Sprintf("has federal income tax withheld %s greater than gross amount %s", withheld, gross)}
+}
+
+// NewErrNegativeAmount returns a error that has negative amount in an amount code that doesn't report a loss
+func NewErrNegativeAmount(typeOfReturn, code string) error {
+	return &codeError{CodeNegativeAmount, fmt.Sprintf("has negative amount of amount code %s, which %s doesn't allow", code, typeOfReturn)}
 }
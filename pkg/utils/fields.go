@@ -37,7 +37,11 @@ func ParseValue(fields reflect.Value, spec map[string]config.SpecField, record s
 
 		field := fields.FieldByName(fieldName)
 		spec, ok := spec[fieldName]
-		if !ok || !field.IsValid() || !field.CanSet() {
+		if !ok {
+			// field is not part of the layout revision
+			continue
+		}
+		if !field.IsValid() || !field.CanSet() {
 			return ErrValidField
 		}
 
@@ -57,6 +61,49 @@ func ParseValue(fields reflect.Value, spec map[string]config.SpecField, record s
 	return nil
 }
 
+// to parse every field with string and collect the errors of fields that can't be parsed,
+// unlike ParseValue parsing doesn't stop at the first invalid field
+func ParseFields(fields reflect.Value, spec map[string]config.SpecField, record string) []*FieldError {
+	var errs []*FieldError
+	for i := 0; i < fields.NumField(); i++ {
+		fieldName := fields.Type().Field(i).Name
+		// skip local variable
+		if !unicode.IsUpper([]rune(fieldName)[0]) {
+			continue
+		}
+
+		field := fields.FieldByName(fieldName)
+		spec, ok := spec[fieldName]
+		if !ok {
+			// field is not part of the layout revision
+			continue
+		}
+
+		newErr := func(err error) *FieldError {
+			return &FieldError{FieldName: fieldName, Start: spec.Start, Length: spec.Length, Err: err, Severity: SeverityError}
+		}
+
+		if !field.IsValid() || !field.CanSet() {
+			errs = append(errs, newErr(ErrValidField))
+			continue
+		}
+		if len(record) < spec.Start+spec.Length {
+			errs = append(errs, newErr(ErrShortRecord))
+			continue
+		}
+
+		data := record[spec.Start : spec.Start+spec.Length]
+		if err := isValidType(fieldName, spec, data); err != nil {
+			errs = append(errs, newErr(err))
+			continue
+		}
+		if err := parseValue(spec, field, data); err != nil {
+			errs = append(errs, newErr(err))
+		}
+	}
+	return errs
+}
+
 // to string from field
 func ToString(elm config.SpecField, data reflect.Value) string {
 	if elm.Required == config.Expandable {
@@ -85,6 +132,14 @@ func ToString(elm config.SpecField, data reflect.Value) string {
 			return fmt.Sprintf("%"+sizeStr+"s", config.BlankString)
 		}
 		return fmt.Sprintf("%0"+sizeStr+"d", data)
+	case config.SignedNumeric:
+		var value int
+		if data.CanInterface() {
+			if v, ok := data.Interface().(int); ok {
+				value = v
+			}
+		}
+		return FormatSignedNumeric(value, elm.Length)
 	case config.Percent:
 		var value int
 		if data.CanInterface() {
@@ -117,24 +172,55 @@ func ToString(elm config.SpecField, data reflect.Value) string {
 	return fillString(elm)
 }
 
-// to validate fields of record
+// to validate fields of record, errors of Validate* methods with a severity
+// other than SeverityError don't fail validation
 func Validate(r interface{}, spec map[string]config.SpecField, rType string) error {
+	if errs := validateFields(r, spec, rType, true); len(errs) > 0 {
+		return errs[0].Err
+	}
+	return nil
+}
+
+// to validate all fields of record and collect every field error of every severity
+func ValidateFields(r interface{}, spec map[string]config.SpecField, rType string) []*FieldError {
+	return validateFields(r, spec, rType, false)
+}
+
+func validateFields(r interface{}, spec map[string]config.SpecField, rType string, first bool) []*FieldError {
+	var errs []*FieldError
 	fields := reflect.ValueOf(r).Elem()
 	for i := 0; i < fields.NumField(); i++ {
 		fieldName := fields.Type().Field(i).Name
 		if !fields.IsValid() {
-			return ErrValidField
+			return append(errs, &FieldError{FieldName: fieldName, Err: ErrValidField, Severity: SeverityError})
+		}
+
+		newErr := func(err error) *FieldError {
+			fieldErr := &FieldError{FieldName: fieldName, Err: err, Severity: Severity(err)}
+			if elm, ok := spec[fieldName]; ok {
+				fieldErr.Start = elm.Start
+				fieldErr.Length = elm.Length
+			}
+			return fieldErr
 		}
 
 		if spec, ok := spec[fieldName]; ok {
 			if spec.Required == config.Required {
 				fieldValue := fields.FieldByName(fieldName)
 				if fieldValue.IsZero() {
-					return NewErrFieldRequired(fieldName)
+					errs = append(errs, newErr(NewErrFieldRequired(fieldName)))
+					if first {
+						return errs
+					}
+					continue
 				}
 				if fieldName == "RecordType" {
 					if rType != fieldValue.String() {
-						return NewErrRecordType(rType)
+						errs = append(errs, newErr(NewErrRecordType(rType)))
+						if first {
+							return errs
+						}
+						continue
 					}
 				}
 			}
@@ -148,7 +234,7 @@ func Validate(r interface{}, spec map[string]config.SpecField, rType string) err
 				continue
 			}
 
-			err := method.Call(nil)[0] //nolint:forbidigo
+			err := response[0]
 			if !err.IsNil() {
 				var value error
 				if err.CanInterface() {
@@ -156,12 +242,19 @@ func Validate(r interface{}, spec map[string]config.SpecField, rType string) err
 						value = v
 					}
 				}
-				return value
+				fieldErr := newErr(value)
+				if first && fieldErr.Severity != SeverityError {
+					continue
+				}
+				errs = append(errs, fieldErr)
+				if first {
+					return errs
+				}
 			}
 		}
 	}
 
-	return nil
+	return errs
 }
 
 // to get field
@@ -238,6 +331,9 @@ func isValidType(fieldName string, elm config.SpecField, data string) error {
 		return isAlphanumeric(data)
 	case config.Numeric, config.ZeroNumeric, config.Percent:
 		return IsNumeric(data)
+	case config.SignedNumeric:
+		_, err := ParseSignedNumeric(data)
+		return err
 	case config.TelephoneNumber:
 		if len(data) < minPhoneNumberLength {
 			break
@@ -302,7 +398,7 @@ func isEmail(data string) error {
 }
 
 func fillString(elm config.SpecField) string {
-	if elm.Type == config.ZeroNumeric {
+	if elm.Type == config.ZeroNumeric || elm.Type == config.SignedNumeric {
 		return strings.Repeat(config.ZeroString, elm.Length)
 	}
 	return strings.Repeat(config.BlankString, elm.Length)
@@ -326,6 +422,13 @@ func parseValue(elm config.SpecField, field reflect.Value, data string) error {
 		}
 		field.SetInt(value)
 		return nil
+	case config.SignedNumeric:
+		value, err := ParseSignedNumeric(data)
+		if err != nil {
+			return err
+		}
+		field.SetInt(int64(value))
+		return nil
 	case config.Percent:
 		data = strings.Trim(data, config.BlankString)
 		if len(data) == 0 {
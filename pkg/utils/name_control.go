@@ -0,0 +1,176 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package utils
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/moov-io/irs/pkg/config"
+)
+
+// Name types of name control derivation
+const (
+	NameIndividual = "individual"
+	NameBusiness   = "business"
+	NameTrust      = "trust"
+	NameEstate     = "estate"
+)
+
+const nameControlLength = 4
+
+var (
+	// dbaPattern matches “doing business as” and “trading as” parts of a name
+	dbaPattern = regexp.MustCompile(`(?i)(\s|^)(DBA|D/B/A|D B A|T/A)(\s|$).*`)
+	// estatePattern matches estate descriptions
+	estatePattern = regexp.MustCompile(`\b(ESTATE OF|ESTATE|EST OF|EST)\b`)
+	// trustNamePattern matches names of trusts
+	trustNamePattern = regexp.MustCompile(`\b(TRUST|TR)\b`)
+	// trustPattern matches trust descriptions
+	trustPattern = regexp.MustCompile(`\b(TRUST|TR|TRUSTEE|TTEE|REVOCABLE|IRREVOCABLE|LIVING|FAMILY|UA|UAD|DTD|DATED)\b|\b\d+\b`)
+)
+
+// surnamePrefixes are joined with the following word of a surname
+var surnamePrefixes = map[string]bool{
+	"DA": true, "DE": true, "DEL": true, "DELA": true, "DELLA": true, "DI": true, "DU": true,
+	"LA": true, "LE": true, "MC": true, "MAC": true, "ST": true, "SAN": true,
+	"VAN": true, "VANDER": true, "VON": true, "DER": true, "TER": true, "TEN": true,
+}
+
+// businessWords mark names of businesses rather than individuals
+var businessWords = map[string]bool{
+	"INC": true, "CO": true, "CORP": true, "CORPORATION": true, "COMPANY": true, "LLC": true,
+	"LLP": true, "LP": true, "LTD": true, "BANK": true, "FOUNDATION": true, "FUND": true,
+	"ASSOCIATION": true, "PARTNERS": true, "PARTNERSHIP": true, "GROUP": true, "HOLDINGS": true,
+	"&": true, "AND": true,
+}
+
+// NameType returns the name type of a name with the type of TIN (“1” EIN, “2” SSN)
+//
+// Names with an individual TIN are individuals, names with estate or trust
+// descriptions are estates or trusts. Names with a blank type of TIN and a
+// comma (“SMITH, JOHN”) are individuals, other names are businesses.
+func NameType(name, typeOfTIN string) string {
+	upper := normalizeName(dbaPattern.ReplaceAllString(name, ""))
+	switch {
+	case typeOfTIN == config.TinType2:
+		return NameIndividual
+	case estatePattern.MatchString(upper):
+		return NameEstate
+	case trustNamePattern.MatchString(upper):
+		return NameTrust
+	case len(typeOfTIN) == 0 && strings.Contains(name, ","):
+		return NameIndividual
+	}
+	return NameBusiness
+}
+
+// NameControl returns the name control of a name as described by the IRS name control rules
+//
+//   - Individuals: the first four characters of the surname. The name is
+//     expected surname first (“SMITH JOHN” or “SMITH, JOHN”) as required by
+//     Publication 1220. Surname prefixes are joined (“VAN DYKE” is “VAND”)
+//     and hyphens are kept (“HO-CHAN” is “HO-C”).
+//   - Businesses: the first four significant characters of the name. A leading
+//     “THE” is omitted unless the name has only two words.
+//   - Estates: the first four characters of the surname of the decedent,
+//     whose name follows the natural order (“ESTATE OF JOHN SMITH”).
+//   - Trusts: the first four characters of the surname of an individual named
+//     in the trust, otherwise the first four significant characters of the trust name.
+//
+// Only the legal name before “DBA” is used. Name controls have only letters,
+// numbers, hyphens and ampersands and may be shorter than four characters.
+func NameControl(name, nameType string) string {
+	name = normalizeName(dbaPattern.ReplaceAllString(name, ""))
+	if len(name) == 0 {
+		return ""
+	}
+
+	switch nameType {
+	case NameIndividual:
+		if index := strings.Index(name, ","); index >= 0 {
+			return firstCharacters(strings.ReplaceAll(name[:index], " ", ""))
+		}
+		return firstCharacters(surnameFirst(strings.Fields(strings.ReplaceAll(name, ",", " "))))
+	case NameEstate:
+		words := strings.Fields(estatePattern.ReplaceAllString(strings.ReplaceAll(name, ",", " "), " "))
+		return firstCharacters(surnameLast(words))
+	case NameTrust:
+		words := strings.Fields(trustPattern.ReplaceAllString(strings.ReplaceAll(name, ",", " "), " "))
+		if personalName(words) {
+			return firstCharacters(surnameLast(words))
+		}
+	}
+	return businessNameControl(name)
+}
+
+// normalizeName returns the upper case name with words of letters, numbers, hyphens and ampersands
+func normalizeName(name string) string {
+	var buf strings.Builder
+	for _, r := range strings.ToUpper(name) {
+		switch {
+		case r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '&', r == '-', r == ',':
+			buf.WriteRune(r)
+		case r == '\'' || r == '.' || unicode.IsLetter(r):
+			// apostrophes, periods and letters without ascii form are dropped
+		default:
+			buf.WriteRune(' ')
+		}
+	}
+	return strings.Join(strings.Fields(buf.String()), " ")
+}
+
+func businessNameControl(name string) string {
+	words := strings.Fields(strings.ReplaceAll(name, ",", " "))
+	if len(words) > 2 && words[0] == "THE" {
+		words = words[1:]
+	}
+	return firstCharacters(strings.Join(words, ""))
+}
+
+// surnameFirst returns the surname of words that start with the surname
+func surnameFirst(words []string) string {
+	surname := ""
+	for _, word := range words {
+		surname += word
+		if !surnamePrefixes[word] {
+			break
+		}
+	}
+	return surname
+}
+
+// surnameLast returns the surname of words that end with the surname
+func surnameLast(words []string) string {
+	if len(words) == 0 {
+		return ""
+	}
+	start := len(words) - 1
+	for start > 0 && surnamePrefixes[words[start-1]] {
+		start--
+	}
+	return strings.Join(words[start:], "")
+}
+
+// personalName returns true if the words look like a name of an individual
+func personalName(words []string) bool {
+	if len(words) < 2 || len(words) > 4 {
+		return false
+	}
+	for _, word := range words {
+		if businessWords[word] {
+			return false
+		}
+	}
+	return true
+}
+
+func firstCharacters(name string) string {
+	if len(name) > nameControlLength {
+		return name[:nameControlLength]
+	}
+	return name
+}
@@ -0,0 +1,61 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package utils
+
+import "errors"
+
+// Severities of validation errors
+//
+// Errors are problems that FIRE rejects, warnings look suspicious and infos
+// only describe the file. Validate fails only for errors.
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+	SeverityInfo    = "info"
+)
+
+// severityError is a validation error with a severity other than SeverityError
+type severityError struct {
+	err      error
+	severity string
+}
+
+func (e *severityError) Error() string {
+	return e.err.Error()
+}
+
+func (e *severityError) Unwrap() error {
+	return e.err
+}
+
+// WithSeverity returns the error with the severity, or nil if the error is nil
+//
+// Validate* methods of records use it for checks that shouldn't fail
+// validation, the error code of the error is kept.
+func WithSeverity(err error, severity string) error {
+	if err == nil {
+		return nil
+	}
+	return &severityError{err: err, severity: severity}
+}
+
+// Severity returns the severity of the error, SeverityError unless it was given by WithSeverity
+func Severity(err error) string {
+	var se *severityError
+	if errors.As(err, &se) {
+		return se.severity
+	}
+	return SeverityError
+}
+
+// FirstError returns the first error of SeverityError, errors of other severities are skipped
+func FirstError(errs ...error) error {
+	for _, err := range errs {
+		if err != nil && Severity(err) == SeverityError {
+			return err
+		}
+	}
+	return nil
+}
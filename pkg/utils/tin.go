@@ -0,0 +1,158 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package utils
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/moov-io/irs/pkg/config"
+)
+
+// Kinds of taxpayer identification numbers
+const (
+	TINKindSSN  = "SSN"
+	TINKindITIN = "ITIN"
+	TINKindATIN = "ATIN"
+	TINKindEIN  = "EIN"
+)
+
+const tinLength = 9
+
+// invalidEINPrefixes are the first two digits of EINs that are not assigned by the IRS
+var invalidEINPrefixes = map[string]bool{
+	"00": true, "07": true, "08": true, "09": true, "17": true, "18": true, "19": true,
+	"28": true, "29": true, "49": true, "69": true, "70": true, "78": true, "79": true,
+	"89": true, "96": true, "97": true,
+}
+
+// TINKinds returns every kind of TIN the nine digit number is structurally valid for
+//
+// SSNs have an area (first three digits) other than 000, 666 and 900-999, a
+// group (fourth and fifth digits) other than 00 and a serial other than 0000.
+// ITINs start with 9 and have a group of 50-65, 70-88, 90-92 or 94-99, ATINs
+// start with 9 and have a group of 93. EINs have a prefix assigned by the IRS.
+// Numbers of one repeated digit are not valid for any kind.
+func TINKinds(tin string) []string {
+	if len(tin) != tinLength || IsNumeric(tin) != nil || strings.Count(tin, tin[:1]) == tinLength {
+		return nil
+	}
+
+	var kinds []string
+	area, group, serial := tin[:3], tin[3:5], tin[5:]
+	if tin[0] == '9' {
+		groupNumber, _ := strconv.Atoi(group)
+		switch {
+		case groupNumber == 93:
+			kinds = append(kinds, TINKindATIN)
+		case groupNumber >= 50 && groupNumber <= 65, groupNumber >= 70 && groupNumber <= 88,
+			groupNumber >= 90 && groupNumber <= 92, groupNumber >= 94:
+			kinds = append(kinds, TINKindITIN)
+		}
+	} else if area != "000" && area != "666" && group != "00" && serial != "0000" {
+		kinds = append(kinds, TINKindSSN)
+	}
+	if !invalidEINPrefixes[tin[:2]] {
+		kinds = append(kinds, TINKindEIN)
+	}
+	return kinds
+}
+
+// ValidateTIN validates the structure of the TIN with the type of TIN (“1” EIN, “2” SSN, ITIN or ATIN)
+//
+// A blank type of TIN accepts every kind of TIN. ITINs and ATINs are rejected
+// with the EIN type of TIN unless the number is a valid EIN as well, since
+// EIN prefixes 90-95, 98 and 99 overlap the ITIN ranges.
+func ValidateTIN(tin, typeOfTIN string) error {
+	if len(tin) != tinLength || IsNumeric(tin) != nil {
+		return NewErrTIN("should be nine digits")
+	}
+	if strings.Count(tin, tin[:1]) == tinLength {
+		return NewErrTIN("has only one repeated digit")
+	}
+
+	kinds := TINKinds(tin)
+	switch typeOfTIN {
+	case config.TinType1:
+		if hasTINKind(kinds, TINKindEIN) {
+			return nil
+		}
+		for _, kind := range kinds {
+			if kind == TINKindITIN || kind == TINKindATIN {
+				return NewErrTINType(kind, typeOfTIN)
+			}
+		}
+		return NewErrTIN("has an unassigned EIN prefix")
+	case config.TinType2:
+		if len(individualTINKind(kinds)) > 0 {
+			return nil
+		}
+		switch area, group := tin[:3], tin[3:5]; {
+		case area == "000" || area == "666":
+			return NewErrTIN("has an invalid SSN area")
+		case tin[0] == '9':
+			return NewErrTIN("has an SSN area outside ITIN and ATIN ranges")
+		case group == "00":
+			return NewErrTIN("has an invalid SSN group")
+		}
+		return NewErrTIN("has an invalid SSN serial")
+	}
+	if len(kinds) == 0 {
+		return NewErrTIN("is not a valid SSN, ITIN, ATIN or EIN")
+	}
+	return nil
+}
+
+// InferTypeOfTIN returns the type of TIN (“1” EIN, “2” SSN, ITIN or ATIN) of the TIN
+// and name, or blank if it can't be inferred
+//
+// ITINs, ATINs and numbers that are only valid SSNs are individual, numbers
+// that are only valid EINs are businesses. Numbers valid for both kinds use
+// the name: “SMITH, JOHN” is an individual, estates, trusts and names with
+// business words (“INC”, “LLC”, ...) are businesses.
+func InferTypeOfTIN(tin, name string) string {
+	kinds := TINKinds(tin)
+	individual, business := len(individualTINKind(kinds)) > 0, hasTINKind(kinds, TINKindEIN)
+	switch {
+	case individual && !business:
+		return config.TinType2
+	case business && !individual:
+		return config.TinType1
+	case !individual:
+		return ""
+	}
+
+	switch NameType(name, "") {
+	case NameIndividual:
+		return config.TinType2
+	case NameEstate, NameTrust:
+		return config.TinType1
+	}
+	for _, word := range strings.Fields(normalizeName(dbaPattern.ReplaceAllString(name, ""))) {
+		if businessWords[word] {
+			return config.TinType1
+		}
+	}
+	return ""
+}
+
+func hasTINKind(kinds []string, kind string) bool {
+	for _, item := range kinds {
+		if item == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// individualTINKind returns the individual kind (SSN, ITIN or ATIN) of the kinds
+func individualTINKind(kinds []string) string {
+	for _, kind := range kinds {
+		if kind != TINKindEIN {
+			return kind
+		}
+	}
+	return ""
+}